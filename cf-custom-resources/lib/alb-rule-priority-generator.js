@@ -137,6 +137,70 @@ exports.nextAvailableRulePriorityHandler = async function(event, context) {
   };
 
 
+// firstTestListenerPort is the lowest port given to the test listener of a blue/green service.
+const firstTestListenerPort = 8080;
+
+/**
+ * Lists all the existing listeners of an ALB, and returns the lowest port
+ * from firstTestListenerPort that none of them listens on.
+ *
+ * @param {string} loadBalancerArn the ARN of the ALB.
+
+ * @returns {number} The next available ALB listener port.
+ */
+const calculateNextListenerPort = async function (loadBalancerArn) {
+    var elb = new aws.ELBv2();
+    var marker;
+    var ports = new Set();
+    do {
+        const listenersResponse = await elb.describeListeners({
+            LoadBalancerArn: loadBalancerArn,
+            Marker: marker
+        }).promise();
+
+        listenersResponse.Listeners.forEach(listener => ports.add(listener.Port));
+        marker = listenersResponse.NextMarker;
+    } while (marker)
+
+    let nextPort = firstTestListenerPort;
+    while (ports.has(nextPort)) {
+        nextPort++;
+    }
+    return nextPort;
+};
+
+/**
+ * Next Available ALB Listener Port handler, invoked by Lambda
+ */
+exports.nextAvailableListenerPortHandler = async function(event, context) {
+    var responseData = {};
+    var physicalResourceId;
+
+    try {
+      switch (event.RequestType) {
+        case 'Create':
+          responseData.Port = await calculateNextListenerPort(event.ResourceProperties.LoadBalancerArn);
+          physicalResourceId = `alb-listener-port-${responseData.Port}`;
+          break;
+        // Keep the port on update, the listener of the service already uses it.
+        case 'Update':
+          physicalResourceId = event.PhysicalResourceId;
+          responseData.Port = parseInt(physicalResourceId.split('-').pop());
+          break;
+        case 'Delete':
+          physicalResourceId = event.PhysicalResourceId;
+          break;
+        default:
+          throw new Error(`Unsupported request type ${event.RequestType}`);
+      }
+
+      await report(event, context, 'SUCCESS', physicalResourceId, responseData);
+    } catch (err) {
+      console.log(`Caught error ${err}.`);
+      await report(event, context, 'FAILED', physicalResourceId, null, err.message);
+    }
+  };
+
 /**
  * @private
  */
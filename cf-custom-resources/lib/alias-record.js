@@ -0,0 +1,220 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+'use strict';
+
+const aws = require('aws-sdk');
+
+// These are used for test purposes only
+let defaultResponseURL;
+let waiter;
+
+/**
+ * Upload a CloudFormation response object to S3.
+ *
+ * @param {object} event the Lambda event payload received by the handler function
+ * @param {object} context the Lambda context received by the handler function
+ * @param {string} responseStatus the response status, either 'SUCCESS' or 'FAILED'
+ * @param {string} physicalResourceId CloudFormation physical resource ID
+ * @param {object} [responseData] arbitrary response data object
+ * @param {string} [reason] reason for failure, if any, to convey to the user
+ * @returns {Promise} Promise that is resolved on success, or rejected on connection error or HTTP error response
+ */
+let report = function (event, context, responseStatus, physicalResourceId, responseData, reason) {
+    return new Promise((resolve, reject) => {
+        const https = require('https');
+        const {
+            URL
+        } = require('url');
+
+        var responseBody = JSON.stringify({
+            Status: responseStatus,
+            Reason: reason,
+            PhysicalResourceId: physicalResourceId || context.logStreamName,
+            StackId: event.StackId,
+            RequestId: event.RequestId,
+            LogicalResourceId: event.LogicalResourceId,
+            Data: responseData
+        });
+
+        const parsedUrl = new URL(event.ResponseURL || defaultResponseURL);
+        const options = {
+            hostname: parsedUrl.hostname,
+            port: 443,
+            path: parsedUrl.pathname + parsedUrl.search,
+            method: 'PUT',
+            headers: {
+                'Content-Type': '',
+                'Content-Length': responseBody.length
+            }
+        };
+
+        https.request(options)
+            .on('error', reject)
+            .on('response', res => {
+                res.resume();
+                if (res.statusCode >= 400) {
+                    reject(new Error(`Server returned error ${res.statusCode}: ${res.statusMessage}`));
+                } else {
+                    resolve();
+                }
+            })
+            .end(responseBody, 'utf8');
+    });
+};
+
+/**
+ * Creates or deletes an A record in the application's hosted zone that aliases the load balancer.
+ * The application's hosted zone can belong to another account, so the record is changed with the rootDnsRole.
+ *
+ * @param {string} action either 'UPSERT' or 'DELETE'
+ * @param {string} alias the domain name of the record (api.app.example.com)
+ * @param {string} domainName the DNS name of the application's hosted zone (app.example.com)
+ * @param {string} loadBalancerDNS the DNS name of the load balancer
+ * @param {string} loadBalancerHostedZone the canonical hosted zone ID of the load balancer
+ * @param {string} rootDnsRole the IAM role ARN that can manage domainName
+ * @param {string} [evaluateTargetHealth] 'false' if the target doesn't support health checks, such as a CloudFront distribution
+ */
+const changeAliasRecord = async function (action, alias, domainName, loadBalancerDNS, loadBalancerHostedZone, rootDnsRole, evaluateTargetHealth) {
+    const route53 = new aws.Route53({
+        credentials: new aws.ChainableTemporaryCredentials(
+            {
+                params: {RoleArn: rootDnsRole},
+                masterCredentials: (new aws.EnvironmentCredentials('AWS'))
+            })
+        });
+    if (waiter) {
+        // Used by the test suite, since waiters aren't mockable yet
+        route53.waitFor = waiter;
+    }
+
+    const hostedZones = await route53.listHostedZonesByName({
+        DNSName: domainName
+    }).promise();
+
+    if (!hostedZones.HostedZones || hostedZones.HostedZones.length == 0) {
+        throw new Error(`Couldn't find any hostedzones with DNS name ${domainName}.`)
+    }
+
+    // HostedZoneIDs are of the form /hostedzone/1234455, but the actual
+    // ID is after the last slash.
+    const hostedZoneId = hostedZones.HostedZones[0].Id.split('/').pop();
+
+    let changeBatch;
+    try {
+        changeBatch = await route53.changeResourceRecordSets({
+            ChangeBatch: {
+                Changes: [{
+                    Action: action,
+                    ResourceRecordSet: {
+                        Name: alias,
+                        Type: 'A',
+                        AliasTarget: {
+                            DNSName: loadBalancerDNS,
+                            HostedZoneId: loadBalancerHostedZone,
+                            EvaluateTargetHealth: evaluateTargetHealth !== 'false'
+                        }
+                    }
+                }]
+            },
+            HostedZoneId: hostedZoneId
+        }).promise();
+    } catch (err) {
+        // The record was already deleted, so there is nothing left to do.
+        if (action === 'DELETE' && err.code === 'InvalidChangeBatch' && err.message.includes('not found')) {
+            return;
+        }
+        throw err;
+    }
+
+    console.log(`${action} alias record ${alias} in hostedzone ${hostedZoneId}`);
+    await route53.waitFor('resourceRecordSetsChanged', {
+        // Wait up to 5 minutes
+        $waiter: {
+            delay: 30,
+            maxAttempts: 10
+        },
+        Id: changeBatch.ChangeInfo.Id
+    }).promise();
+}
+
+/**
+ * Alias record handler, invoked by Lambda
+ */
+exports.aliasRecordHandler = async function (event, context) {
+    var responseData = {};
+    var physicalResourceId;
+    const props = event.ResourceProperties || {};
+    try {
+        switch (event.RequestType) {
+            case 'Create':
+            case 'Update':
+                await changeAliasRecord(
+                    'UPSERT',
+                    props.Alias,
+                    props.DomainName,
+                    props.LoadBalancerDNS,
+                    props.LoadBalancerHostedZone,
+                    props.RootDNSRole,
+                    props.EvaluateTargetHealth,
+                );
+                const oldProps = event.OldResourceProperties;
+                if (event.RequestType === 'Update' && oldProps && oldProps.Alias !== props.Alias) {
+                    // The service answers on a new alias, so the record of the previous one is removed.
+                    await changeAliasRecord(
+                        'DELETE',
+                        oldProps.Alias,
+                        oldProps.DomainName,
+                        oldProps.LoadBalancerDNS,
+                        oldProps.LoadBalancerHostedZone,
+                        oldProps.RootDNSRole,
+                        oldProps.EvaluateTargetHealth,
+                    );
+                }
+                physicalResourceId = props.Alias;
+                break;
+            case 'Delete':
+                physicalResourceId = event.PhysicalResourceId;
+                // If the resource didn't create correctly, the physical resource ID won't be the alias.
+                if (physicalResourceId === props.Alias) {
+                    await changeAliasRecord(
+                        'DELETE',
+                        props.Alias,
+                        props.DomainName,
+                        props.LoadBalancerDNS,
+                        props.LoadBalancerHostedZone,
+                        props.RootDNSRole,
+                        props.EvaluateTargetHealth,
+                    );
+                }
+                break;
+            default:
+                throw new Error(`Unsupported request type ${event.RequestType}`);
+        }
+
+        await report(event, context, 'SUCCESS', physicalResourceId, responseData);
+    } catch (err) {
+        console.log(`Caught error ${err}.`);
+        await report(event, context, 'FAILED', physicalResourceId, null, err.message);
+    }
+};
+
+/**
+ * @private
+ */
+exports.withDefaultResponseURL = function(url) {
+  defaultResponseURL = url;
+};
+
+/**
+ * @private
+ */
+exports.withWaiter = function(w) {
+  waiter = w;
+};
+
+/**
+ * @private
+ */
+exports.reset = function() {
+  waiter = undefined;
+};
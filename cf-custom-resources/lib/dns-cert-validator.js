@@ -76,14 +76,22 @@ let report = function (event, context, responseStatus, physicalResourceId, respo
  * `*.example.com`, the hosted zone ID must point to a Route 53 zone authoritative
  * for `example.com`.
  *
+ * If the hosted zone belongs to another account, such as the application's hosted zone, the
+ * validation record is created with the rootDnsRole and the hosted zone can be looked up by name.
+ *
  * @param {string} requestId the CloudFormation request ID
  * @param {string} domainName the Common Name (CN) field for the requested certificate
  * @param {string} hostedZoneId the Route53 Hosted Zone ID
+ * @param {string} [rootDnsRole] the IAM role ARN that can manage the hosted zone
+ * @param {string} [hostedZoneName] the DNS name of the hosted zone if its ID is not known
  * @returns {string} Validated certificate ARN
  */
-const requestCertificate = async function (requestId, domainName, subjectAlternativeNames, hostedZoneId, region) {
+const requestCertificate = async function (requestId, domainName, subjectAlternativeNames, hostedZoneId, region, rootDnsRole, hostedZoneName) {
     const crypto = require('crypto');
-    const [acm, route53] = clients(region)
+    const [acm, route53] = clients(region, rootDnsRole)
+    if (!hostedZoneId) {
+        hostedZoneId = await hostedZoneIdByName(route53, hostedZoneName);
+    }
     const reqCertResponse = await acm.requestCertificate({
         DomainName: domainName,
         SubjectAlternativeNames: subjectAlternativeNames,
@@ -136,9 +144,12 @@ const requestCertificate = async function (requestId, domainName, subjectAlterna
  *
  * @param {string} arn The certificate ARN
  */
-const deleteCertificate = async function (arn, region, hostedZoneId) {
-    const [acm, route53] = clients(region)
+const deleteCertificate = async function (arn, region, hostedZoneId, rootDnsRole, hostedZoneName) {
+    const [acm, route53] = clients(region, rootDnsRole)
     try {
+        if (!hostedZoneId) {
+            hostedZoneId = await hostedZoneIdByName(route53, hostedZoneName);
+        }
         console.log(`Waiting for certificate ${arn} to become unused`);
 
         let inUseByResources;
@@ -215,11 +226,41 @@ const updateRecords = function(route53, hostedZone, action, recordName, recordTy
     }).promise();
 }
 
-const clients = function(region) {
+/**
+ * Returns the ID of the hosted zone with the DNS name.
+ *
+ * @param {object} route53 the Route53 client
+ * @param {string} domainName the DNS name of the hosted zone
+ * @returns {string} the hosted zone ID
+ */
+const hostedZoneIdByName = async function(route53, domainName) {
+    const hostedZones = await route53.listHostedZonesByName({
+        DNSName: domainName
+    }).promise();
+
+    if (!hostedZones.HostedZones || hostedZones.HostedZones.length == 0) {
+        throw new Error(`Couldn't find any hostedzones with DNS name ${domainName}.`)
+    }
+
+    // HostedZoneIDs are of the form /hostedzone/1234455, but the actual
+    // ID is after the last slash.
+    return hostedZones.HostedZones[0].Id.split('/').pop();
+}
+
+const clients = function(region, rootDnsRole) {
     const acm = new aws.ACM({
         region
     });
-    const route53 = new aws.Route53();
+    let route53 = new aws.Route53();
+    if (rootDnsRole) {
+        route53 = new aws.Route53({
+            credentials: new aws.ChainableTemporaryCredentials(
+                {
+                    params: {RoleArn: rootDnsRole},
+                    masterCredentials: (new aws.EnvironmentCredentials('AWS'))
+                })
+        });
+    }
     if (waiter) {
         // Used by the test suite, since waiters aren't mockable yet
         route53.waitFor = acm.waitFor = waiter;
@@ -245,6 +286,8 @@ exports.certificateRequestHandler = async function(event, context) {
             event.ResourceProperties.SubjectAlternativeNames,
             event.ResourceProperties.HostedZoneId,
             event.ResourceProperties.Region,
+            event.ResourceProperties.RootDNSRole,
+            event.ResourceProperties.HostedZoneName,
           );
           responseData.Arn = physicalResourceId = certificateArn;
           break;
@@ -253,7 +296,13 @@ exports.certificateRequestHandler = async function(event, context) {
           // If the resource didn't create correctly, the physical resource ID won't be the
           // certificate ARN, so don't try to delete it in that case.
           if (physicalResourceId.startsWith('arn:')) {
-            await deleteCertificate(physicalResourceId, event.ResourceProperties.Region, event.ResourceProperties.HostedZoneId);
+            await deleteCertificate(
+              physicalResourceId,
+              event.ResourceProperties.Region,
+              event.ResourceProperties.HostedZoneId,
+              event.ResourceProperties.RootDNSRole,
+              event.ResourceProperties.HostedZoneName,
+            );
           }
           break;
         default:
@@ -310,5 +310,59 @@ describe('ALB Rule Priority Generator', () => {
       });
   });
 
+  test('Create operation returns the lowest free listener port', () => {
+    const testALBArn = 'arn:aws:elasticloadbalancing:us-west-2:000000000:loadbalancer/app/lb';
+    const testNextMarkerToken = 'next';
+    const describeListenersFake = sinon.stub();
+    describeListenersFake.onCall(0).resolves({
+      Listeners: [{ Port: 80 }, { Port: 8080 }],
+      NextMarker: testNextMarkerToken
+    });
+    describeListenersFake.onCall(1).resolves({
+      Listeners: [{ Port: 443 }, { Port: 8081 }]
+    });
+    AWS.mock('ELBv2', 'describeListeners', describeListenersFake);
+
+    const request = nock(ResponseURL).put('/', body => {
+      return body.Status === 'SUCCESS' && body.Data.Port === 8082 && body.PhysicalResourceId === 'alb-listener-port-8082';
+    }).reply(200);
+
+    return LambdaTester(albRulePriorityHandler.nextAvailableListenerPortHandler)
+      .event({
+        RequestType: 'Create',
+        RequestId: testRequestId,
+        ResourceProperties: {
+          LoadBalancerArn: testALBArn
+        }
+      })
+      .expectResolve(() => {
+        sinon.assert.calledWith(describeListenersFake.secondCall, sinon.match({
+            LoadBalancerArn: testALBArn,
+            Marker: testNextMarkerToken
+        }));
+        expect(request.isDone()).toBe(true);
+      });
+  });
+
+  test('Update operation keeps the listener port', () => {
+    const describeListenersFake = sinon.fake.resolves({ Listeners: [] });
+    AWS.mock('ELBv2', 'describeListeners', describeListenersFake);
+
+    const request = nock(ResponseURL).put('/', body => {
+      return body.Status === 'SUCCESS' && body.Data.Port === 8083 && body.PhysicalResourceId === 'alb-listener-port-8083';
+    }).reply(200);
+
+    return LambdaTester(albRulePriorityHandler.nextAvailableListenerPortHandler)
+      .event({
+        RequestType: 'Update',
+        RequestId: testRequestId,
+        PhysicalResourceId: 'alb-listener-port-8083'
+      })
+      .expectResolve(() => {
+        sinon.assert.notCalled(describeListenersFake);
+        expect(request.isDone()).toBe(true);
+      });
+  });
+
 });
 
@@ -0,0 +1,232 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+'use strict';
+
+describe('Alias Record Handler', () => {
+  const AWS = require('aws-sdk-mock');
+  const LambdaTester = require('lambda-tester').noVersionCheck();
+  const sinon = require('sinon');
+  const handler = require('../lib/alias-record');
+  const nock = require('nock');
+  const ResponseURL = 'https://cloudwatch-response-mock.example.com/';
+
+  let origLog = console.log;
+
+  const testRequestId = 'f4ef1b10-c39a-44e3-99c0-fbf7e53c3943';
+  const testProps = {
+    Alias: 'api.app.example.com',
+    DomainName: 'app.example.com',
+    LoadBalancerDNS: 'app-publi-1234.us-west-2.elb.amazonaws.com',
+    LoadBalancerHostedZone: 'Z1H1FL5HABSF5',
+    RootDNSRole: 'arn:aws:iam::00000000000:role/DNSDelegationRole',
+  };
+
+  beforeEach(() => {
+    handler.withDefaultResponseURL(ResponseURL);
+    handler.withWaiter(function() {
+      // Mock waiter is merely a self-fulfilling promise
+      return {
+        promise: () => {
+          return new Promise((resolve) => {
+            resolve();
+          });
+        }
+      };
+    });
+    console.log = function() { };
+  });
+  afterEach(() => {
+    handler.reset();
+    AWS.restore();
+    console.log = origLog;
+  });
+
+  const mockHostedZone = () => {
+    const listHostedZonesByNameFake = sinon.fake.resolves({
+      HostedZones: [{
+        Id: '/hostedzone/Z1234455'
+      }]
+    });
+    AWS.mock('Route53', 'listHostedZonesByName', listHostedZonesByNameFake);
+    return listHostedZonesByNameFake;
+  };
+
+  test('Empty event payload fails', () => {
+    const request = nock(ResponseURL).put('/', body => {
+      return body.Status === 'FAILED' && body.Reason === 'Unsupported request type undefined';
+    }).reply(200);
+    return LambdaTester(handler.aliasRecordHandler)
+      .event({})
+      .expectResolve(() => {
+        expect(request.isDone()).toBe(true);
+      });
+  });
+
+  test('Create operation upserts the alias record', () => {
+    const listHostedZonesByNameFake = mockHostedZone();
+    const changeResourceRecordSetsFake = sinon.fake.resolves({
+      ChangeInfo: {
+        Id: 'bogus'
+      }
+    });
+    AWS.mock('Route53', 'changeResourceRecordSets', changeResourceRecordSetsFake);
+
+    const request = nock(ResponseURL).put('/', body => {
+      return body.Status === 'SUCCESS' && body.PhysicalResourceId === testProps.Alias;
+    }).reply(200);
+
+    return LambdaTester(handler.aliasRecordHandler)
+      .event({
+        RequestType: 'Create',
+        RequestId: testRequestId,
+        ResourceProperties: testProps,
+      })
+      .expectResolve(() => {
+        sinon.assert.calledWith(listHostedZonesByNameFake, sinon.match({
+          DNSName: 'app.example.com'
+        }));
+        sinon.assert.calledWith(changeResourceRecordSetsFake, sinon.match({
+          ChangeBatch: {
+            Changes: [{
+              Action: 'UPSERT',
+              ResourceRecordSet: {
+                Name: 'api.app.example.com',
+                Type: 'A',
+                AliasTarget: {
+                  DNSName: 'app-publi-1234.us-west-2.elb.amazonaws.com',
+                  HostedZoneId: 'Z1H1FL5HABSF5',
+                  EvaluateTargetHealth: true
+                }
+              }
+            }]
+          },
+          HostedZoneId: 'Z1234455'
+        }));
+        expect(request.isDone()).toBe(true);
+      });
+  });
+
+  test('Create operation does not evaluate the health of a CloudFront distribution', () => {
+    mockHostedZone();
+    const changeResourceRecordSetsFake = sinon.fake.resolves({
+      ChangeInfo: {
+        Id: 'bogus'
+      }
+    });
+    AWS.mock('Route53', 'changeResourceRecordSets', changeResourceRecordSetsFake);
+
+    const request = nock(ResponseURL).put('/', body => {
+      return body.Status === 'SUCCESS';
+    }).reply(200);
+
+    return LambdaTester(handler.aliasRecordHandler)
+      .event({
+        RequestType: 'Create',
+        RequestId: testRequestId,
+        ResourceProperties: Object.assign({}, testProps, {
+          LoadBalancerDNS: 'd111111abcdef8.cloudfront.net',
+          LoadBalancerHostedZone: 'Z2FDTNDATAQYW2',
+          EvaluateTargetHealth: 'false',
+        }),
+      })
+      .expectResolve(() => {
+        sinon.assert.calledWith(changeResourceRecordSetsFake, sinon.match({
+          ChangeBatch: {
+            Changes: [sinon.match({
+              ResourceRecordSet: sinon.match({
+                AliasTarget: {
+                  DNSName: 'd111111abcdef8.cloudfront.net',
+                  HostedZoneId: 'Z2FDTNDATAQYW2',
+                  EvaluateTargetHealth: false
+                }
+              })
+            })]
+          }
+        }));
+        expect(request.isDone()).toBe(true);
+      });
+  });
+
+  test('Update operation deletes the record of the previous alias', () => {
+    mockHostedZone();
+    const changeResourceRecordSetsFake = sinon.fake.resolves({
+      ChangeInfo: {
+        Id: 'bogus'
+      }
+    });
+    AWS.mock('Route53', 'changeResourceRecordSets', changeResourceRecordSetsFake);
+
+    const request = nock(ResponseURL).put('/', body => {
+      return body.Status === 'SUCCESS';
+    }).reply(200);
+
+    return LambdaTester(handler.aliasRecordHandler)
+      .event({
+        RequestType: 'Update',
+        RequestId: testRequestId,
+        PhysicalResourceId: 'www.app.example.com',
+        ResourceProperties: testProps,
+        OldResourceProperties: Object.assign({}, testProps, { Alias: 'www.app.example.com' }),
+      })
+      .expectResolve(() => {
+        sinon.assert.calledTwice(changeResourceRecordSetsFake);
+        sinon.assert.calledWith(changeResourceRecordSetsFake, sinon.match({
+          ChangeBatch: {
+            Changes: [sinon.match({
+              Action: 'DELETE',
+              ResourceRecordSet: sinon.match({
+                Name: 'www.app.example.com'
+              })
+            })]
+          }
+        }));
+        expect(request.isDone()).toBe(true);
+      });
+  });
+
+  test('Delete operation is idempotent', () => {
+    mockHostedZone();
+    const changeResourceRecordSetsFake = sinon.fake.rejects({
+      code: 'InvalidChangeBatch',
+      message: 'Tried to delete resource record set [name=\'api.app.example.com.\', type=\'A\'] but it was not found'
+    });
+    AWS.mock('Route53', 'changeResourceRecordSets', changeResourceRecordSetsFake);
+
+    const request = nock(ResponseURL).put('/', body => {
+      return body.Status === 'SUCCESS';
+    }).reply(200);
+
+    return LambdaTester(handler.aliasRecordHandler)
+      .event({
+        RequestType: 'Delete',
+        RequestId: testRequestId,
+        PhysicalResourceId: testProps.Alias,
+        ResourceProperties: testProps,
+      })
+      .expectResolve(() => {
+        sinon.assert.calledOnce(changeResourceRecordSetsFake);
+        expect(request.isDone()).toBe(true);
+      });
+  });
+
+  test('Delete operation skips resources that failed to create', () => {
+    const changeResourceRecordSetsFake = sinon.fake.resolves({});
+    AWS.mock('Route53', 'changeResourceRecordSets', changeResourceRecordSetsFake);
+
+    const request = nock(ResponseURL).put('/', body => {
+      return body.Status === 'SUCCESS';
+    }).reply(200);
+
+    return LambdaTester(handler.aliasRecordHandler)
+      .event({
+        RequestType: 'Delete',
+        RequestId: testRequestId,
+        PhysicalResourceId: 'mockedLogStreamName',
+        ResourceProperties: testProps,
+      })
+      .expectResolve(() => {
+        sinon.assert.notCalled(changeResourceRecordSetsFake);
+        expect(request.isDone()).toBe(true);
+      });
+  });
+});
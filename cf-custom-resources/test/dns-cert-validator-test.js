@@ -144,6 +144,65 @@ describe('DNS Validated Certificate Handler', () => {
 
   });
 
+  test('Create operation looks up the hosted zone by name', () => {
+    const requestCertificateFake = sinon.fake.resolves({
+      CertificateArn: testCertificateArn,
+    });
+    const describeCertificateFake = sinon.fake.resolves({
+      Certificate: {
+        CertificateArn: testCertificateArn,
+        DomainValidationOptions: [{
+          ValidationStatus: 'SUCCESS',
+          ResourceRecord: {
+            Name: testRRName,
+            Type: 'CNAME',
+            Value: testRRValue
+          }
+        }]
+      }
+    });
+    const listHostedZonesByNameFake = sinon.fake.resolves({
+      HostedZones: [{
+        Id: '/hostedzone/Z1234455'
+      }]
+    });
+    const changeResourceRecordSetsFake = sinon.fake.resolves({
+      ChangeInfo: {
+        Id: 'bogus'
+      }
+    });
+
+    AWS.mock('ACM', 'requestCertificate', requestCertificateFake);
+    AWS.mock('ACM', 'describeCertificate', describeCertificateFake);
+    AWS.mock('Route53', 'listHostedZonesByName', listHostedZonesByNameFake);
+    AWS.mock('Route53', 'changeResourceRecordSets', changeResourceRecordSetsFake);
+
+    const request = nock(ResponseURL).put('/', body => {
+      return body.Status === 'SUCCESS';
+    }).reply(200);
+
+    return LambdaTester(handler.certificateRequestHandler)
+      .event({
+        RequestType: 'Create',
+        RequestId: testRequestId,
+        ResourceProperties: {
+          DomainName: 'api.app.example.com',
+          HostedZoneName: 'app.example.com',
+          RootDNSRole: 'arn:aws:iam::00000000000:role/DNSDelegationRole',
+          Region: 'us-east-1',
+        }
+      })
+      .expectResolve(() => {
+        sinon.assert.calledWith(listHostedZonesByNameFake, sinon.match({
+          DNSName: 'app.example.com'
+        }));
+        sinon.assert.calledWith(changeResourceRecordSetsFake, sinon.match({
+          HostedZoneId: 'Z1234455'
+        }));
+        expect(request.isDone()).toBe(true);
+      });
+  });
+
   test('Create operation fails after more than 60s if certificate has no DomainValidationOptions', () => {
     handler.withRandom(() => 0);
     const requestCertificateFake = sinon.fake.resolves({
@@ -0,0 +1,53 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package cloudfront provides a client to make API requests to Amazon CloudFront.
+package cloudfront
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/cloudfront"
+)
+
+type api interface {
+	CreateInvalidation(input *cloudfront.CreateInvalidationInput) (*cloudfront.CreateInvalidationOutput, error)
+}
+
+// CloudFront wraps an Amazon CloudFront client.
+type CloudFront struct {
+	client api
+	now    func() time.Time
+}
+
+// New returns a CloudFront client configured against the input session.
+func New(s *session.Session) *CloudFront {
+	return &CloudFront{
+		client: cloudfront.New(s),
+		now:    time.Now,
+	}
+}
+
+// CreateInvalidation removes the files matching the paths, such as "/*", from the edge caches of the distribution,
+// and returns the ID of the invalidation.
+func (c *CloudFront) CreateInvalidation(distributionID string, paths []string) (string, error) {
+	resp, err := c.client.CreateInvalidation(&cloudfront.CreateInvalidationInput{
+		DistributionId: aws.String(distributionID),
+		InvalidationBatch: &cloudfront.InvalidationBatch{
+			// The caller reference makes the request idempotent, every deployment invalidates the caches again.
+			CallerReference: aws.String(strconv.FormatInt(c.now().UnixNano(), 10)),
+			Paths: &cloudfront.Paths{
+				Items:    aws.StringSlice(paths),
+				Quantity: aws.Int64(int64(len(paths))),
+			},
+		},
+	})
+	if err != nil {
+		return "", fmt.Errorf("create invalidation of distribution %s: %w", distributionID, err)
+	}
+	return aws.StringValue(resp.Invalidation.Id), nil
+}
@@ -0,0 +1,167 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package codedeploy provides a client to make API requests to AWS CodeDeploy.
+package codedeploy
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/codedeploy"
+)
+
+const (
+	appSpecVersion     = "0.0"
+	ecsServiceResource = "AWS::ECS::Service"
+)
+
+// Polling of a deployment until it completes.
+var (
+	waitForDeploymentDelay       = 15 * time.Second
+	waitForDeploymentMaxAttempts = 360
+)
+
+type api interface {
+	CreateDeployment(input *codedeploy.CreateDeploymentInput) (*codedeploy.CreateDeploymentOutput, error)
+	GetDeployment(input *codedeploy.GetDeploymentInput) (*codedeploy.GetDeploymentOutput, error)
+}
+
+// CodeDeploy wraps an AWS CodeDeploy client.
+type CodeDeploy struct {
+	client api
+}
+
+// ECSDeploymentInput holds the fields required to replace the tasks of an ECS service with a blue/green deployment.
+type ECSDeploymentInput struct {
+	ApplicationName     string
+	DeploymentGroupName string
+	TaskDefinition      string // ARN of the task definition of the new tasks.
+	ContainerName       string // Container that receives the traffic of the load balancer.
+	ContainerPort       uint16
+}
+
+// Deployment holds the outcome of a completed deployment.
+type Deployment struct {
+	ID                   string
+	Status               string // One of Succeeded, Failed or Stopped.
+	ErrorMessage         string
+	RollbackDeploymentID string // Deployment that restored the previous tasks if the deployment was rolled back.
+}
+
+// Succeeded returns whether the traffic was shifted to the new tasks.
+func (d *Deployment) Succeeded() bool {
+	return d.Status == codedeploy.DeploymentStatusSucceeded
+}
+
+// RolledBack returns whether the previous tasks were restored after the deployment failed.
+func (d *Deployment) RolledBack() bool {
+	return d.RollbackDeploymentID != ""
+}
+
+// New returns a CodeDeploy client configured against the input session.
+func New(s *session.Session) *CodeDeploy {
+	return &CodeDeploy{
+		client: codedeploy.New(s),
+	}
+}
+
+// CreateECSDeployment starts a blue/green deployment of the task definition to the ECS service of the deployment group,
+// and returns the ID of the deployment.
+func (c *CodeDeploy) CreateECSDeployment(in *ECSDeploymentInput) (string, error) {
+	appSpec, err := ecsAppSpec(in)
+	if err != nil {
+		return "", err
+	}
+	resp, err := c.client.CreateDeployment(&codedeploy.CreateDeploymentInput{
+		ApplicationName:     aws.String(in.ApplicationName),
+		DeploymentGroupName: aws.String(in.DeploymentGroupName),
+		Revision: &codedeploy.RevisionLocation{
+			RevisionType: aws.String(codedeploy.RevisionLocationTypeAppSpecContent),
+			AppSpecContent: &codedeploy.AppSpecContent{
+				Content: aws.String(appSpec),
+			},
+		},
+	})
+	if err != nil {
+		return "", fmt.Errorf("create deployment for deployment group %s: %w", in.DeploymentGroupName, err)
+	}
+	return aws.StringValue(resp.DeploymentId), nil
+}
+
+// WaitForDeployment blocks until the deployment succeeds, fails or is stopped, and returns its outcome.
+func (c *CodeDeploy) WaitForDeployment(id string) (*Deployment, error) {
+	for i := 0; i < waitForDeploymentMaxAttempts; i++ {
+		resp, err := c.client.GetDeployment(&codedeploy.GetDeploymentInput{
+			DeploymentId: aws.String(id),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("get deployment %s: %w", id, err)
+		}
+		info := resp.DeploymentInfo
+		switch status := aws.StringValue(info.Status); status {
+		case codedeploy.DeploymentStatusSucceeded, codedeploy.DeploymentStatusFailed, codedeploy.DeploymentStatusStopped:
+			deployment := &Deployment{
+				ID:     id,
+				Status: status,
+			}
+			if info.ErrorInformation != nil {
+				deployment.ErrorMessage = aws.StringValue(info.ErrorInformation.Message)
+			}
+			if info.RollbackInfo != nil {
+				deployment.RollbackDeploymentID = aws.StringValue(info.RollbackInfo.RollbackDeploymentId)
+			}
+			return deployment, nil
+		}
+		time.Sleep(waitForDeploymentDelay)
+	}
+	return nil, fmt.Errorf("wait for deployment %s to complete: exceeded %d attempts", id, waitForDeploymentMaxAttempts)
+}
+
+// ecsAppSpec returns the AppSpec file of the deployment in JSON.
+// See https://docs.aws.amazon.com/codedeploy/latest/userguide/reference-appspec-file-structure-resources.html#reference-appspec-file-structure-resources-ecs
+func ecsAppSpec(in *ECSDeploymentInput) (string, error) {
+	type loadBalancerInfo struct {
+		ContainerName string `json:"ContainerName"`
+		ContainerPort uint16 `json:"ContainerPort"`
+	}
+	type properties struct {
+		TaskDefinition   string           `json:"TaskDefinition"`
+		LoadBalancerInfo loadBalancerInfo `json:"LoadBalancerInfo"`
+	}
+	type targetService struct {
+		Type       string     `json:"Type"`
+		Properties properties `json:"Properties"`
+	}
+	type resource struct {
+		TargetService targetService `json:"TargetService"`
+	}
+	appSpec := struct {
+		Version   string     `json:"version"`
+		Resources []resource `json:"Resources"`
+	}{
+		Version: appSpecVersion,
+		Resources: []resource{
+			{
+				TargetService: targetService{
+					Type: ecsServiceResource,
+					Properties: properties{
+						TaskDefinition: in.TaskDefinition,
+						LoadBalancerInfo: loadBalancerInfo{
+							ContainerName: in.ContainerName,
+							ContainerPort: in.ContainerPort,
+						},
+					},
+				},
+			},
+		},
+	}
+	out, err := json.Marshal(appSpec)
+	if err != nil {
+		return "", fmt.Errorf("marshal AppSpec: %w", err)
+	}
+	return string(out), nil
+}
@@ -0,0 +1,182 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package codedeploy
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/aws/codedeploy/mocks"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/codedeploy"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCodeDeploy_CreateECSDeployment(t *testing.T) {
+	in := &ECSDeploymentInput{
+		ApplicationName:     "phonetool-test-payments",
+		DeploymentGroupName: "phonetool-test-payments",
+		TaskDefinition:      "arn:aws:ecs:us-west-2:123456789012:task-definition/phonetool-test-payments:2",
+		ContainerName:       "payments",
+		ContainerPort:       8080,
+	}
+	wantedInput := &codedeploy.CreateDeploymentInput{
+		ApplicationName:     aws.String("phonetool-test-payments"),
+		DeploymentGroupName: aws.String("phonetool-test-payments"),
+		Revision: &codedeploy.RevisionLocation{
+			RevisionType: aws.String("AppSpecContent"),
+			AppSpecContent: &codedeploy.AppSpecContent{
+				Content: aws.String(`{"version":"0.0","Resources":[{"TargetService":{"Type":"AWS::ECS::Service","Properties":{"TaskDefinition":"arn:aws:ecs:us-west-2:123456789012:task-definition/phonetool-test-payments:2","LoadBalancerInfo":{"ContainerName":"payments","ContainerPort":8080}}}}]}`),
+			},
+		},
+	}
+	testCases := map[string]struct {
+		mockClient func(m *mocks.Mockapi)
+
+		wantedID  string
+		wantedErr error
+	}{
+		"returns the ID of the deployment": {
+			mockClient: func(m *mocks.Mockapi) {
+				m.EXPECT().CreateDeployment(wantedInput).Return(&codedeploy.CreateDeploymentOutput{
+					DeploymentId: aws.String("d-1234"),
+				}, nil)
+			},
+			wantedID: "d-1234",
+		},
+		"wraps the error": {
+			mockClient: func(m *mocks.Mockapi) {
+				m.EXPECT().CreateDeployment(wantedInput).Return(nil, errors.New("some error"))
+			},
+			wantedErr: fmt.Errorf("create deployment for deployment group phonetool-test-payments: some error"),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockClient := mocks.NewMockapi(ctrl)
+			tc.mockClient(mockClient)
+			cd := CodeDeploy{
+				client: mockClient,
+			}
+
+			// WHEN
+			id, err := cd.CreateECSDeployment(in)
+
+			// THEN
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.wantedID, id)
+			}
+		})
+	}
+}
+
+func TestCodeDeploy_WaitForDeployment(t *testing.T) {
+	getInput := &codedeploy.GetDeploymentInput{
+		DeploymentId: aws.String("d-1234"),
+	}
+	testCases := map[string]struct {
+		mockClient func(m *mocks.Mockapi)
+
+		wanted    *Deployment
+		wantedErr error
+	}{
+		"returns the deployment once it succeeds": {
+			mockClient: func(m *mocks.Mockapi) {
+				gomock.InOrder(
+					m.EXPECT().GetDeployment(getInput).Return(&codedeploy.GetDeploymentOutput{
+						DeploymentInfo: &codedeploy.DeploymentInfo{
+							Status: aws.String(codedeploy.DeploymentStatusInProgress),
+						},
+					}, nil),
+					m.EXPECT().GetDeployment(getInput).Return(&codedeploy.GetDeploymentOutput{
+						DeploymentInfo: &codedeploy.DeploymentInfo{
+							Status: aws.String(codedeploy.DeploymentStatusSucceeded),
+						},
+					}, nil),
+				)
+			},
+			wanted: &Deployment{
+				ID:     "d-1234",
+				Status: "Succeeded",
+			},
+		},
+		"returns the error and the rollback of a failed deployment": {
+			mockClient: func(m *mocks.Mockapi) {
+				m.EXPECT().GetDeployment(getInput).Return(&codedeploy.GetDeploymentOutput{
+					DeploymentInfo: &codedeploy.DeploymentInfo{
+						Status: aws.String(codedeploy.DeploymentStatusStopped),
+						ErrorInformation: &codedeploy.ErrorInformation{
+							Message: aws.String("One or more alarms have been activated"),
+						},
+						RollbackInfo: &codedeploy.RollbackInfo{
+							RollbackDeploymentId: aws.String("d-5678"),
+						},
+					},
+				}, nil)
+			},
+			wanted: &Deployment{
+				ID:                   "d-1234",
+				Status:               "Stopped",
+				ErrorMessage:         "One or more alarms have been activated",
+				RollbackDeploymentID: "d-5678",
+			},
+		},
+		"wraps the error": {
+			mockClient: func(m *mocks.Mockapi) {
+				m.EXPECT().GetDeployment(getInput).Return(nil, errors.New("some error"))
+			},
+			wantedErr: fmt.Errorf("get deployment d-1234: some error"),
+		},
+		"errors if the deployment never completes": {
+			mockClient: func(m *mocks.Mockapi) {
+				m.EXPECT().GetDeployment(getInput).Return(&codedeploy.GetDeploymentOutput{
+					DeploymentInfo: &codedeploy.DeploymentInfo{
+						Status: aws.String(codedeploy.DeploymentStatusInProgress),
+					},
+				}, nil).Times(3)
+			},
+			wantedErr: fmt.Errorf("wait for deployment d-1234 to complete: exceeded 3 attempts"),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockClient := mocks.NewMockapi(ctrl)
+			tc.mockClient(mockClient)
+			cd := CodeDeploy{
+				client: mockClient,
+			}
+			defer func(delay time.Duration, attempts int) {
+				waitForDeploymentDelay, waitForDeploymentMaxAttempts = delay, attempts
+			}(waitForDeploymentDelay, waitForDeploymentMaxAttempts)
+			waitForDeploymentDelay, waitForDeploymentMaxAttempts = 0, 3
+
+			// WHEN
+			got, err := cd.WaitForDeployment("d-1234")
+
+			// THEN
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.wanted, got)
+			}
+		})
+	}
+}
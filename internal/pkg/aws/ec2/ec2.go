@@ -0,0 +1,128 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package ec2 provides a client to make API requests to Amazon Elastic Compute Cloud.
+package ec2
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ec2"
+)
+
+const nameTagKey = "Name"
+
+type api interface {
+	DescribeVpcs(input *ec2.DescribeVpcsInput) (*ec2.DescribeVpcsOutput, error)
+	DescribeSubnets(input *ec2.DescribeSubnetsInput) (*ec2.DescribeSubnetsOutput, error)
+}
+
+// VPC holds the identifiers and the address range of a VPC.
+type VPC struct {
+	ID   string
+	Name string // Value of the "Name" tag, empty if the VPC isn't named.
+	CIDR string // Primary IPv4 CIDR block.
+}
+
+// String returns the ID of the VPC followed by its name if it has one, such as "vpc-0123 (shared)".
+func (v VPC) String() string {
+	return withName(v.ID, v.Name)
+}
+
+// Subnet holds the identifiers and the availability zone of a subnet.
+type Subnet struct {
+	ID               string
+	Name             string // Value of the "Name" tag, empty if the subnet isn't named.
+	AvailabilityZone string
+}
+
+// String returns the ID of the subnet followed by its name if it has one, such as "subnet-0123 (public-a)".
+func (s Subnet) String() string {
+	return withName(s.ID, s.Name)
+}
+
+// EC2 wraps an Amazon EC2 client.
+type EC2 struct {
+	client api
+}
+
+// New returns an EC2 client configured against the input session.
+func New(s *session.Session) *EC2 {
+	return &EC2{
+		client: ec2.New(s),
+	}
+}
+
+// ListVPCs returns the VPCs of the account in the region of the session.
+func (c *EC2) ListVPCs() ([]VPC, error) {
+	var vpcs []VPC
+	var nextToken *string
+	for {
+		resp, err := c.client.DescribeVpcs(&ec2.DescribeVpcsInput{
+			NextToken: nextToken,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("describe VPCs: %w", err)
+		}
+		for _, vpc := range resp.Vpcs {
+			vpcs = append(vpcs, VPC{
+				ID:   aws.StringValue(vpc.VpcId),
+				Name: nameTag(vpc.Tags),
+				CIDR: aws.StringValue(vpc.CidrBlock),
+			})
+		}
+		if resp.NextToken == nil {
+			return vpcs, nil
+		}
+		nextToken = resp.NextToken
+	}
+}
+
+// ListSubnets returns the subnets of a VPC.
+func (c *EC2) ListSubnets(vpcID string) ([]Subnet, error) {
+	var subnets []Subnet
+	var nextToken *string
+	for {
+		resp, err := c.client.DescribeSubnets(&ec2.DescribeSubnetsInput{
+			Filters: []*ec2.Filter{
+				{
+					Name:   aws.String("vpc-id"),
+					Values: aws.StringSlice([]string{vpcID}),
+				},
+			},
+			NextToken: nextToken,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("describe subnets of VPC %s: %w", vpcID, err)
+		}
+		for _, subnet := range resp.Subnets {
+			subnets = append(subnets, Subnet{
+				ID:               aws.StringValue(subnet.SubnetId),
+				Name:             nameTag(subnet.Tags),
+				AvailabilityZone: aws.StringValue(subnet.AvailabilityZone),
+			})
+		}
+		if resp.NextToken == nil {
+			return subnets, nil
+		}
+		nextToken = resp.NextToken
+	}
+}
+
+func nameTag(tags []*ec2.Tag) string {
+	for _, tag := range tags {
+		if aws.StringValue(tag.Key) == nameTagKey {
+			return aws.StringValue(tag.Value)
+		}
+	}
+	return ""
+}
+
+func withName(id, name string) string {
+	if name == "" {
+		return id
+	}
+	return fmt.Sprintf("%s (%s)", id, name)
+}
@@ -0,0 +1,165 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ec2
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/aws/ec2/mocks"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEC2_ListVPCs(t *testing.T) {
+	testCases := map[string]struct {
+		mockClient func(m *mocks.Mockapi)
+
+		wantedVPCs []VPC
+		wantedErr  error
+	}{
+		"returns the VPCs of every page": {
+			mockClient: func(m *mocks.Mockapi) {
+				m.EXPECT().DescribeVpcs(&ec2.DescribeVpcsInput{}).Return(&ec2.DescribeVpcsOutput{
+					Vpcs: []*ec2.Vpc{
+						{
+							VpcId:     aws.String("vpc-0123"),
+							CidrBlock: aws.String("10.0.0.0/16"),
+							Tags: []*ec2.Tag{
+								{Key: aws.String("team"), Value: aws.String("network")},
+								{Key: aws.String("Name"), Value: aws.String("shared")},
+							},
+						},
+					},
+					NextToken: aws.String("token"),
+				}, nil)
+				m.EXPECT().DescribeVpcs(&ec2.DescribeVpcsInput{
+					NextToken: aws.String("token"),
+				}).Return(&ec2.DescribeVpcsOutput{
+					Vpcs: []*ec2.Vpc{
+						{
+							VpcId:     aws.String("vpc-4567"),
+							CidrBlock: aws.String("172.16.0.0/16"),
+						},
+					},
+				}, nil)
+			},
+			wantedVPCs: []VPC{
+				{ID: "vpc-0123", Name: "shared", CIDR: "10.0.0.0/16"},
+				{ID: "vpc-4567", CIDR: "172.16.0.0/16"},
+			},
+		},
+		"wraps the error": {
+			mockClient: func(m *mocks.Mockapi) {
+				m.EXPECT().DescribeVpcs(gomock.Any()).Return(nil, errors.New("some error"))
+			},
+			wantedErr: errors.New("describe VPCs: some error"),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			m := mocks.NewMockapi(ctrl)
+			tc.mockClient(m)
+			client := EC2{
+				client: m,
+			}
+
+			// WHEN
+			vpcs, err := client.ListVPCs()
+
+			// THEN
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.wantedVPCs, vpcs)
+		})
+	}
+}
+
+func TestEC2_ListSubnets(t *testing.T) {
+	filters := []*ec2.Filter{
+		{
+			Name:   aws.String("vpc-id"),
+			Values: aws.StringSlice([]string{"vpc-0123"}),
+		},
+	}
+	testCases := map[string]struct {
+		mockClient func(m *mocks.Mockapi)
+
+		wantedSubnets []Subnet
+		wantedErr     error
+	}{
+		"returns the subnets of every page": {
+			mockClient: func(m *mocks.Mockapi) {
+				m.EXPECT().DescribeSubnets(&ec2.DescribeSubnetsInput{
+					Filters: filters,
+				}).Return(&ec2.DescribeSubnetsOutput{
+					Subnets: []*ec2.Subnet{
+						{
+							SubnetId:         aws.String("subnet-0123"),
+							AvailabilityZone: aws.String("us-west-2a"),
+							Tags: []*ec2.Tag{
+								{Key: aws.String("Name"), Value: aws.String("public-a")},
+							},
+						},
+					},
+					NextToken: aws.String("token"),
+				}, nil)
+				m.EXPECT().DescribeSubnets(&ec2.DescribeSubnetsInput{
+					Filters:   filters,
+					NextToken: aws.String("token"),
+				}).Return(&ec2.DescribeSubnetsOutput{
+					Subnets: []*ec2.Subnet{
+						{
+							SubnetId:         aws.String("subnet-4567"),
+							AvailabilityZone: aws.String("us-west-2b"),
+						},
+					},
+				}, nil)
+			},
+			wantedSubnets: []Subnet{
+				{ID: "subnet-0123", Name: "public-a", AvailabilityZone: "us-west-2a"},
+				{ID: "subnet-4567", AvailabilityZone: "us-west-2b"},
+			},
+		},
+		"wraps the error": {
+			mockClient: func(m *mocks.Mockapi) {
+				m.EXPECT().DescribeSubnets(gomock.Any()).Return(nil, errors.New("some error"))
+			},
+			wantedErr: errors.New("describe subnets of VPC vpc-0123: some error"),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			m := mocks.NewMockapi(ctrl)
+			tc.mockClient(m)
+			client := EC2{
+				client: m,
+			}
+
+			// WHEN
+			subnets, err := client.ListSubnets("vpc-0123")
+
+			// THEN
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.wantedSubnets, subnets)
+		})
+	}
+}
@@ -22,6 +22,12 @@ const (
 	imageDigestPrefix      = "sha256:"
 )
 
+// Polling of the deployments of a service until it reaches a steady state.
+var (
+	waitForSteadyDeploymentDelay       = 10 * time.Second
+	waitForSteadyDeploymentMaxAttempts = 180
+)
+
 type api interface {
 	DescribeTasks(input *ecs.DescribeTasksInput) (*ecs.DescribeTasksOutput, error)
 	DescribeTaskDefinition(input *ecs.DescribeTaskDefinitionInput) (*ecs.DescribeTaskDefinitionOutput, error)
@@ -136,6 +142,26 @@ func (e *ECS) Service(clusterName, serviceName string) (*Service, error) {
 	return nil, fmt.Errorf("cannot find service %s", serviceName)
 }
 
+// WaitForSteadyDeployment blocks until a single deployment of the service runs all of its desired tasks,
+// and returns the task definition of that deployment.
+// If the deployment circuit breaker rolled back a failed deployment, it's the task definition of the last completed deployment.
+func (e *ECS) WaitForSteadyDeployment(clusterName, serviceName string) (string, error) {
+	for i := 0; i < waitForSteadyDeploymentMaxAttempts; i++ {
+		svc, err := e.Service(clusterName, serviceName)
+		if err != nil {
+			return "", err
+		}
+		if len(svc.Deployments) == 1 {
+			deployment := svc.Deployments[0]
+			if aws.Int64Value(deployment.RunningCount) == aws.Int64Value(deployment.DesiredCount) {
+				return aws.StringValue(deployment.TaskDefinition), nil
+			}
+		}
+		time.Sleep(waitForSteadyDeploymentDelay)
+	}
+	return "", fmt.Errorf("wait for service %s to reach a steady state: exceeded %d attempts", serviceName, waitForSteadyDeploymentMaxAttempts)
+}
+
 // ServiceTasks calls ECS API and returns ECS tasks running in the cluster.
 func (e *ECS) ServiceTasks(clusterName, serviceName string) ([]*Task, error) {
 	var tasks []*Task
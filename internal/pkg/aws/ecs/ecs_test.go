@@ -186,6 +186,115 @@ func TestECS_Service(t *testing.T) {
 	}
 }
 
+func TestECS_WaitForSteadyDeployment(t *testing.T) {
+	describeInput := &ecs.DescribeServicesInput{
+		Cluster:  aws.String("mockCluster"),
+		Services: aws.StringSlice([]string{"mockService"}),
+	}
+	testCases := map[string]struct {
+		mockECSClient func(m *mocks.Mockapi)
+
+		wantTaskDef string
+		wantErr     error
+	}{
+		"returns the task definition once a single deployment runs all of its tasks": {
+			mockECSClient: func(m *mocks.Mockapi) {
+				gomock.InOrder(
+					m.EXPECT().DescribeServices(describeInput).Return(&ecs.DescribeServicesOutput{
+						Services: []*ecs.Service{
+							{
+								ServiceName: aws.String("mockService"),
+								Deployments: []*ecs.Deployment{
+									{
+										TaskDefinition: aws.String("mockTaskDef:2"),
+										DesiredCount:   aws.Int64(2),
+										RunningCount:   aws.Int64(1),
+									},
+									{
+										TaskDefinition: aws.String("mockTaskDef:1"),
+										DesiredCount:   aws.Int64(2),
+										RunningCount:   aws.Int64(2),
+									},
+								},
+							},
+						},
+					}, nil),
+					m.EXPECT().DescribeServices(describeInput).Return(&ecs.DescribeServicesOutput{
+						Services: []*ecs.Service{
+							{
+								ServiceName: aws.String("mockService"),
+								Deployments: []*ecs.Deployment{
+									{
+										TaskDefinition: aws.String("mockTaskDef:2"),
+										DesiredCount:   aws.Int64(2),
+										RunningCount:   aws.Int64(2),
+									},
+								},
+							},
+						},
+					}, nil),
+				)
+			},
+			wantTaskDef: "mockTaskDef:2",
+		},
+		"errors if failed to describe service": {
+			mockECSClient: func(m *mocks.Mockapi) {
+				m.EXPECT().DescribeServices(describeInput).Return(nil, errors.New("some error"))
+			},
+			wantErr: fmt.Errorf("describe service mockService: some error"),
+		},
+		"errors if the service never reaches a steady state": {
+			mockECSClient: func(m *mocks.Mockapi) {
+				m.EXPECT().DescribeServices(describeInput).Return(&ecs.DescribeServicesOutput{
+					Services: []*ecs.Service{
+						{
+							ServiceName: aws.String("mockService"),
+							Deployments: []*ecs.Deployment{
+								{
+									TaskDefinition: aws.String("mockTaskDef:2"),
+									DesiredCount:   aws.Int64(2),
+									RunningCount:   aws.Int64(0),
+								},
+							},
+						},
+					},
+				}, nil).Times(3)
+			},
+			wantErr: fmt.Errorf("wait for service mockService to reach a steady state: exceeded 3 attempts"),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockECSClient := mocks.NewMockapi(ctrl)
+			tc.mockECSClient(mockECSClient)
+
+			service := ECS{
+				client: mockECSClient,
+			}
+			defer func(delay time.Duration, attempts int) {
+				waitForSteadyDeploymentDelay, waitForSteadyDeploymentMaxAttempts = delay, attempts
+			}(waitForSteadyDeploymentDelay, waitForSteadyDeploymentMaxAttempts)
+			waitForSteadyDeploymentDelay, waitForSteadyDeploymentMaxAttempts = 0, 3
+
+			// WHEN
+			gotTaskDef, gotErr := service.WaitForSteadyDeployment("mockCluster", "mockService")
+
+			// THEN
+			if tc.wantErr != nil {
+				require.EqualError(t, gotErr, tc.wantErr.Error())
+			} else {
+				require.NoError(t, gotErr)
+				require.Equal(t, tc.wantTaskDef, gotTaskDef)
+			}
+		})
+	}
+}
+
 func TestECS_Tasks(t *testing.T) {
 	testCases := map[string]struct {
 		clusterName   string
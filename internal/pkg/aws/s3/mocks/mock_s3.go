@@ -92,6 +92,21 @@ func (mr *Mocks3ApiMockRecorder) ListObjectVersions(input interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListObjectVersions", reflect.TypeOf((*Mocks3Api)(nil).ListObjectVersions), input)
 }
 
+// ListObjectsV2 mocks base method
+func (m *Mocks3Api) ListObjectsV2(input *s3.ListObjectsV2Input) (*s3.ListObjectsV2Output, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListObjectsV2", input)
+	ret0, _ := ret[0].(*s3.ListObjectsV2Output)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListObjectsV2 indicates an expected call of ListObjectsV2
+func (mr *Mocks3ApiMockRecorder) ListObjectsV2(input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListObjectsV2", reflect.TypeOf((*Mocks3Api)(nil).ListObjectsV2), input)
+}
+
 // DeleteObjects mocks base method
 func (m *Mocks3Api) DeleteObjects(input *s3.DeleteObjectsInput) (*s3.DeleteObjectsOutput, error) {
 	m.ctrl.T.Helper()
@@ -106,3 +121,18 @@ func (mr *Mocks3ApiMockRecorder) DeleteObjects(input interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteObjects", reflect.TypeOf((*Mocks3Api)(nil).DeleteObjects), input)
 }
+
+// GetObject mocks base method
+func (m *Mocks3Api) GetObject(input *s3.GetObjectInput) (*s3.GetObjectOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetObject", input)
+	ret0, _ := ret[0].(*s3.GetObjectOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetObject indicates an expected call of GetObject
+func (mr *Mocks3ApiMockRecorder) GetObject(input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetObject", reflect.TypeOf((*Mocks3Api)(nil).GetObject), input)
+}
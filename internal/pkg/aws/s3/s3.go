@@ -7,8 +7,12 @@ package s3
 import (
 	"fmt"
 	"io"
+	"io/ioutil"
+	"mime"
+	"net/url"
 	"path"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -19,6 +23,8 @@ import (
 
 const (
 	artifactDirName = "manual"
+
+	maxDeleteObjects = 1000 // Maximum number of keys in a DeleteObjects request.
 )
 
 type s3ManagerApi interface {
@@ -27,7 +33,9 @@ type s3ManagerApi interface {
 
 type s3Api interface {
 	ListObjectVersions(input *s3.ListObjectVersionsInput) (*s3.ListObjectVersionsOutput, error)
+	ListObjectsV2(input *s3.ListObjectsV2Input) (*s3.ListObjectsV2Output, error)
 	DeleteObjects(input *s3.DeleteObjectsInput) (*s3.DeleteObjectsOutput, error)
+	GetObject(input *s3.GetObjectInput) (*s3.GetObjectOutput, error)
 }
 
 // S3 wraps an Amazon Simple Storage Service client.
@@ -61,6 +69,110 @@ func (s *S3) PutArtifact(bucket, fileName string, data io.Reader) (string, error
 	return resp.Location, nil
 }
 
+// PutObject uploads data to the bucket under the key.
+// The content type of the object is derived from the extension of the key, such as "text/html" for ".html".
+func (s *S3) PutObject(bucket, key string, data io.Reader) error {
+	in := &s3manager.UploadInput{
+		Body:   data,
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	}
+	if contentType := mime.TypeByExtension(path.Ext(key)); contentType != "" {
+		in.ContentType = aws.String(contentType)
+	}
+	if _, err := s.s3Manager.Upload(in); err != nil {
+		return fmt.Errorf("put %s to bucket %s: %w", key, bucket, err)
+	}
+	return nil
+}
+
+// ListObjectKeys returns the keys of all the objects in the bucket.
+func (s *S3) ListObjectKeys(bucket string) ([]string, error) {
+	var keys []string
+	in := &s3.ListObjectsV2Input{
+		Bucket: aws.String(bucket),
+	}
+	for {
+		resp, err := s.s3Client.ListObjectsV2(in)
+		if err != nil {
+			return nil, fmt.Errorf("list objects of bucket %s: %w", bucket, err)
+		}
+		for _, object := range resp.Contents {
+			keys = append(keys, aws.StringValue(object.Key))
+		}
+		if !aws.BoolValue(resp.IsTruncated) {
+			return keys, nil
+		}
+		in.ContinuationToken = resp.NextContinuationToken
+	}
+}
+
+// DeleteObjects deletes the objects stored under the keys in the bucket.
+func (s *S3) DeleteObjects(bucket string, keys []string) error {
+	for start := 0; start < len(keys); start += maxDeleteObjects {
+		end := start + maxDeleteObjects
+		if end > len(keys) {
+			end = len(keys)
+		}
+		var objects []*s3.ObjectIdentifier
+		for _, key := range keys[start:end] {
+			objects = append(objects, &s3.ObjectIdentifier{
+				Key: aws.String(key),
+			})
+		}
+		if _, err := s.s3Client.DeleteObjects(&s3.DeleteObjectsInput{
+			Bucket: aws.String(bucket),
+			Delete: &s3.Delete{
+				Objects: objects,
+				Quiet:   aws.Bool(true),
+			},
+		}); err != nil {
+			return fmt.Errorf("delete objects from bucket %s: %w", bucket, err)
+		}
+	}
+	return nil
+}
+
+// GetObject returns the content of the object stored under the key in the bucket.
+func (s *S3) GetObject(bucket, key string) ([]byte, error) {
+	resp, err := s.s3Client.GetObject(&s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get %s from bucket %s: %w", key, bucket, err)
+	}
+	defer resp.Body.Close()
+	content, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read %s from bucket %s: %w", key, bucket, err)
+	}
+	return content, nil
+}
+
+// ParseURL splits the URL of an S3 object, such as the URL returned by PutArtifact, into its bucket and key.
+// Both virtual-hosted-style URLs, "https://bucket.s3.region.amazonaws.com/key",
+// and path-style URLs, "https://s3.region.amazonaws.com/bucket/key", are supported.
+func ParseURL(objectURL string) (bucket string, key string, err error) {
+	parsed, err := url.Parse(objectURL)
+	if err != nil {
+		return "", "", fmt.Errorf("parse url %s: %w", objectURL, err)
+	}
+	objectPath := strings.TrimPrefix(parsed.Path, "/")
+	if strings.HasPrefix(parsed.Host, "s3.") || strings.HasPrefix(parsed.Host, "s3-") {
+		parts := strings.SplitN(objectPath, "/", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return "", "", fmt.Errorf("url %s is not the url of an S3 object", objectURL)
+		}
+		return parts[0], parts[1], nil
+	}
+	i := strings.Index(parsed.Host, ".s3")
+	if i <= 0 || objectPath == "" {
+		return "", "", fmt.Errorf("url %s is not the url of an S3 object", objectURL)
+	}
+	return parsed.Host[:i], objectPath, nil
+}
+
 // EmptyBucket deletes all objects within the bucket.
 func (s *S3) EmptyBucket(bucket string) error {
 	var listResp *s3.ListObjectVersionsOutput
@@ -7,7 +7,9 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -89,6 +91,268 @@ func TestS3_PutArtifact(t *testing.T) {
 	}
 }
 
+func TestS3_GetObject(t *testing.T) {
+	testCases := map[string]struct {
+		mockS3Client func(m *mocks.Mocks3Api)
+
+		wantedContent string
+		wantedErr     error
+	}{
+		"returns the content of the object": {
+			mockS3Client: func(m *mocks.Mocks3Api) {
+				m.EXPECT().GetObject(&s3.GetObjectInput{
+					Bucket: aws.String("mockBucket"),
+					Key:    aws.String("manual/1600000000/api.env"),
+				}).Return(&s3.GetObjectOutput{
+					Body: ioutil.NopCloser(strings.NewReader("LOG_LEVEL=info")),
+				}, nil)
+			},
+			wantedContent: "LOG_LEVEL=info",
+		},
+		"should wrap up error if fail to get the object": {
+			mockS3Client: func(m *mocks.Mocks3Api) {
+				m.EXPECT().GetObject(gomock.Any()).Return(nil, errors.New("some error"))
+			},
+			wantedErr: errors.New("get manual/1600000000/api.env from bucket mockBucket: some error"),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockS3Client := mocks.NewMocks3Api(ctrl)
+			tc.mockS3Client(mockS3Client)
+			service := S3{
+				s3Client: mockS3Client,
+			}
+
+			// WHEN
+			content, err := service.GetObject("mockBucket", "manual/1600000000/api.env")
+
+			// THEN
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.wantedContent, string(content))
+		})
+	}
+}
+
+func TestS3_PutObject(t *testing.T) {
+	testCases := map[string]struct {
+		inKey               string
+		mockS3ManagerClient func(m *mocks.Mocks3ManagerApi)
+
+		wantedErr error
+	}{
+		"sets the content type from the extension of the key": {
+			inKey: "index.html",
+			mockS3ManagerClient: func(m *mocks.Mocks3ManagerApi) {
+				m.EXPECT().Upload(&s3manager.UploadInput{
+					Body:        strings.NewReader("<html></html>"),
+					Bucket:      aws.String("mockBucket"),
+					Key:         aws.String("index.html"),
+					ContentType: aws.String("text/html; charset=utf-8"),
+				}).Return(&s3manager.UploadOutput{}, nil)
+			},
+		},
+		"leaves the content type to S3 if the extension is unknown": {
+			inKey: "LICENSE",
+			mockS3ManagerClient: func(m *mocks.Mocks3ManagerApi) {
+				m.EXPECT().Upload(&s3manager.UploadInput{
+					Body:   strings.NewReader("<html></html>"),
+					Bucket: aws.String("mockBucket"),
+					Key:    aws.String("LICENSE"),
+				}).Return(&s3manager.UploadOutput{}, nil)
+			},
+		},
+		"should wrap up error if fail to upload": {
+			inKey: "index.html",
+			mockS3ManagerClient: func(m *mocks.Mocks3ManagerApi) {
+				m.EXPECT().Upload(gomock.Any()).Return(nil, errors.New("some error"))
+			},
+			wantedErr: errors.New("put index.html to bucket mockBucket: some error"),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockS3ManagerClient := mocks.NewMocks3ManagerApi(ctrl)
+			tc.mockS3ManagerClient(mockS3ManagerClient)
+			service := S3{
+				s3Manager: mockS3ManagerClient,
+			}
+
+			// WHEN
+			err := service.PutObject("mockBucket", tc.inKey, strings.NewReader("<html></html>"))
+
+			// THEN
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestS3_ListObjectKeys(t *testing.T) {
+	testCases := map[string]struct {
+		mockS3Client func(m *mocks.Mocks3Api)
+
+		wantedKeys []string
+		wantedErr  error
+	}{
+		"returns the keys of every page": {
+			mockS3Client: func(m *mocks.Mocks3Api) {
+				m.EXPECT().ListObjectsV2(&s3.ListObjectsV2Input{
+					Bucket: aws.String("mockBucket"),
+				}).Return(&s3.ListObjectsV2Output{
+					Contents:              []*s3.Object{{Key: aws.String("index.html")}},
+					IsTruncated:           aws.Bool(true),
+					NextContinuationToken: aws.String("token"),
+				}, nil)
+				m.EXPECT().ListObjectsV2(&s3.ListObjectsV2Input{
+					Bucket:            aws.String("mockBucket"),
+					ContinuationToken: aws.String("token"),
+				}).Return(&s3.ListObjectsV2Output{
+					Contents:    []*s3.Object{{Key: aws.String("css/main.css")}},
+					IsTruncated: aws.Bool(false),
+				}, nil)
+			},
+			wantedKeys: []string{"index.html", "css/main.css"},
+		},
+		"should wrap up error if fail to list objects": {
+			mockS3Client: func(m *mocks.Mocks3Api) {
+				m.EXPECT().ListObjectsV2(gomock.Any()).Return(nil, errors.New("some error"))
+			},
+			wantedErr: errors.New("list objects of bucket mockBucket: some error"),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockS3Client := mocks.NewMocks3Api(ctrl)
+			tc.mockS3Client(mockS3Client)
+			service := S3{
+				s3Client: mockS3Client,
+			}
+
+			// WHEN
+			keys, err := service.ListObjectKeys("mockBucket")
+
+			// THEN
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.wantedKeys, keys)
+		})
+	}
+}
+
+func TestS3_DeleteObjects(t *testing.T) {
+	testCases := map[string]struct {
+		mockS3Client func(m *mocks.Mocks3Api)
+
+		wantedErr error
+	}{
+		"deletes the objects": {
+			mockS3Client: func(m *mocks.Mocks3Api) {
+				m.EXPECT().DeleteObjects(&s3.DeleteObjectsInput{
+					Bucket: aws.String("mockBucket"),
+					Delete: &s3.Delete{
+						Objects: []*s3.ObjectIdentifier{
+							{Key: aws.String("old.html")},
+							{Key: aws.String("css/old.css")},
+						},
+						Quiet: aws.Bool(true),
+					},
+				}).Return(&s3.DeleteObjectsOutput{}, nil)
+			},
+		},
+		"should wrap up error if fail to delete objects": {
+			mockS3Client: func(m *mocks.Mocks3Api) {
+				m.EXPECT().DeleteObjects(gomock.Any()).Return(nil, errors.New("some error"))
+			},
+			wantedErr: errors.New("delete objects from bucket mockBucket: some error"),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockS3Client := mocks.NewMocks3Api(ctrl)
+			tc.mockS3Client(mockS3Client)
+			service := S3{
+				s3Client: mockS3Client,
+			}
+
+			// WHEN
+			err := service.DeleteObjects("mockBucket", []string{"old.html", "css/old.css"})
+
+			// THEN
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestParseURL(t *testing.T) {
+	testCases := map[string]struct {
+		inURL string
+
+		wantedBucket string
+		wantedKey    string
+		wantedErr    error
+	}{
+		"virtual-hosted-style url": {
+			inURL:        "https://stackset-bucket.s3.us-west-2.amazonaws.com/manual/1600000000/api.env",
+			wantedBucket: "stackset-bucket",
+			wantedKey:    "manual/1600000000/api.env",
+		},
+		"path-style url": {
+			inURL:        "https://s3.us-west-2.amazonaws.com/stackset-bucket/manual/1600000000/api.env",
+			wantedBucket: "stackset-bucket",
+			wantedKey:    "manual/1600000000/api.env",
+		},
+		"error if the url doesn't have a key": {
+			inURL:     "https://stackset-bucket.s3.us-west-2.amazonaws.com/",
+			wantedErr: errors.New("url https://stackset-bucket.s3.us-west-2.amazonaws.com/ is not the url of an S3 object"),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			bucket, key, err := ParseURL(tc.inURL)
+
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.wantedBucket, bucket)
+			require.Equal(t, tc.wantedKey, key)
+		})
+	}
+}
+
 func TestS3_EmptyBucket(t *testing.T) {
 	batchObject1 := make([]*s3.ObjectVersion, 1000)
 	batchObject2 := make([]*s3.ObjectVersion, 10)
@@ -7,8 +7,10 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/aws/cloudformation"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/aws/ec2"
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/aws/identity"
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/aws/profile"
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/aws/session"
@@ -27,6 +29,22 @@ const (
 
 	fmtEnvInitProfilePrompt  = "Which named profile should we use to create %s?"
 	envInitProfileHelpPrompt = "The AWS CLI named profile with the permissions to create an environment."
+
+	envInitImportVPCPrompt     = "Would you like to place the environment in an existing VPC?"
+	envInitImportVPCHelpPrompt = `By default, a new VPC with two public and two private subnets is created for the environment.
+You can import a VPC instead, such as a VPC shared by your organization, along with its subnets.`
+	envInitVPCPrompt                = "Which VPC would you like to use?"
+	envInitVPCHelpPrompt            = "The VPC that the load balancer, services and jobs of the environment are placed in."
+	envInitPublicSubnetsPrompt      = "Which public subnets would you like to use?"
+	envInitPublicSubnetsHelpPrompt  = "Subnets with a route to an internet gateway, in at least two availability zones, for the public load balancer."
+	envInitPrivateSubnetsPrompt     = "Which private subnets would you like to use?"
+	envInitPrivateSubnetsHelpPrompt = "Subnets in at least two availability zones for the tasks that aren't reachable from the internet."
+	fmtEnvInitSubnetOption          = "%s in %s"
+
+	minImportedSubnetAZs = 2 // The public load balancer requires subnets in at least two availability zones.
+
+	minLBIdleTimeoutSeconds = 1
+	maxLBIdleTimeoutSeconds = 4000
 )
 
 const (
@@ -45,6 +63,7 @@ const (
 
 var (
 	errNamedProfilesNotFound = fmt.Errorf("no named AWS profiles found, run %s first please", color.HighlightCode("aws configure"))
+	errVPCsNotFound          = errors.New("no VPCs found")
 )
 
 type initEnvVars struct {
@@ -52,6 +71,13 @@ type initEnvVars struct {
 	EnvName      string // Name of the environment.
 	EnvProfile   string // AWS profile used to create an environment.
 	IsProduction bool   // Marks the environment as "production" to create it with additional guardrails.
+
+	ImportVPCID            string   // ID of an existing VPC to place the environment in instead of creating one.
+	ImportPublicSubnetIDs  []string // IDs of the public subnets of the imported VPC.
+	ImportPrivateSubnetIDs []string // IDs of the private subnets of the imported VPC.
+
+	LBIdleTimeout time.Duration // Idle timeout of the connections to the public load balancer.
+	NATGateways   bool          // Whether to create NAT gateways for the tasks placed in the private subnets.
 }
 
 type initEnvOpts struct {
@@ -65,6 +91,9 @@ type initEnvOpts struct {
 	envIdentity   identityService
 	profileConfig profileNames
 	prog          progress
+	vpcLister     vpcSubnetLister
+
+	importVPCCIDR string // Primary CIDR block of the imported VPC.
 
 	// initialize profile-specific env clients
 	initProfileClients func(*initEnvOpts) error
@@ -77,6 +106,7 @@ var initEnvProfileClients = func(o *initEnvOpts) error {
 	}
 	o.envIdentity = identity.New(profileSess)
 	o.envDeployer = deploycfn.New(profileSess)
+	o.vpcLister = ec2.New(profileSess)
 	return nil
 }
 
@@ -116,15 +146,30 @@ func (o *initEnvOpts) Validate() error {
 	if o.AppName() == "" {
 		return fmt.Errorf("no application found: run %s or %s into your workspace please", color.HighlightCode("app init"), color.HighlightCode("cd"))
 	}
+	if o.LBIdleTimeout != 0 {
+		if o.LBIdleTimeout < minLBIdleTimeoutSeconds*time.Second || o.LBIdleTimeout > maxLBIdleTimeoutSeconds*time.Second ||
+			o.LBIdleTimeout%time.Second != 0 {
+			return fmt.Errorf("load balancer idle timeout %s must be a whole number of seconds between %d and %d",
+				o.LBIdleTimeout, minLBIdleTimeoutSeconds, maxLBIdleTimeoutSeconds)
+		}
+	}
+	if o.NATGateways && o.ImportVPCID != "" {
+		return fmt.Errorf("--%s can't be used with --%s, the imported VPC routes the traffic of its private subnets", natGatewaysFlag, importVPCIDFlag)
+	}
 	return nil
 }
 
 // Ask asks for fields that are required but not passed in.
 func (o *initEnvOpts) Ask() error {
+	// Only ask whether to import a VPC when the command runs interactively, so that scripts that pass flags keep working.
+	isInteractive := o.EnvName == ""
 	if err := o.askEnvName(); err != nil {
 		return err
 	}
-	return o.askEnvProfile()
+	if err := o.askEnvProfile(); err != nil {
+		return err
+	}
+	return o.askImportVPC(isInteractive)
 }
 
 // Execute deploys a new environment with CloudFormation and adds it to SSM.
@@ -184,6 +229,16 @@ func (o *initEnvOpts) deployEnv(app *config.Application) error {
 		ToolsAccountPrincipalARN: caller.RootUserARN,
 		AppDNSName:               app.Domain,
 		AdditionalTags:           app.Tags,
+		LBIdleTimeout:            o.LBIdleTimeout,
+		NATGateways:              o.NATGateways,
+	}
+	if o.importsVPC() {
+		deployEnvInput.ImportVPC = &deploy.ImportVPCConfig{
+			ID:               o.ImportVPCID,
+			CIDR:             o.importVPCCIDR,
+			PublicSubnetIDs:  o.ImportPublicSubnetIDs,
+			PrivateSubnetIDs: o.ImportPrivateSubnetIDs,
+		}
 	}
 
 	o.prog.Start(fmt.Sprintf(fmtDeployEnvStart, color.HighlightUserInput(o.EnvName)))
@@ -282,6 +337,150 @@ func (o *initEnvOpts) askEnvProfile() error {
 	return nil
 }
 
+func (o *initEnvOpts) askImportVPC(isInteractive bool) error {
+	if !o.importsVPC() {
+		if !isInteractive {
+			return nil
+		}
+		importVPC, err := o.prompt.Confirm(envInitImportVPCPrompt, envInitImportVPCHelpPrompt)
+		if err != nil {
+			return fmt.Errorf("confirm importing a VPC: %w", err)
+		}
+		if !importVPC {
+			return nil
+		}
+	}
+
+	// The VPC belongs to the account of the environment.
+	if err := o.initProfileClients(o); err != nil {
+		return err
+	}
+	if err := o.askVPCID(); err != nil {
+		return err
+	}
+	subnets, err := o.vpcLister.ListSubnets(o.ImportVPCID)
+	if err != nil {
+		return fmt.Errorf("list subnets of VPC %s: %w", o.ImportVPCID, err)
+	}
+	if len(o.ImportPublicSubnetIDs) == 0 {
+		ids, err := o.askSubnetIDs(envInitPublicSubnetsPrompt, envInitPublicSubnetsHelpPrompt, subnets)
+		if err != nil {
+			return fmt.Errorf("select public subnets: %w", err)
+		}
+		o.ImportPublicSubnetIDs = ids
+	}
+	if len(o.ImportPrivateSubnetIDs) == 0 {
+		ids, err := o.askSubnetIDs(envInitPrivateSubnetsPrompt, envInitPrivateSubnetsHelpPrompt, subnets)
+		if err != nil {
+			return fmt.Errorf("select private subnets: %w", err)
+		}
+		o.ImportPrivateSubnetIDs = ids
+	}
+	if err := validateImportedSubnets("public", o.ImportPublicSubnetIDs, o.ImportVPCID, subnets); err != nil {
+		return err
+	}
+	if err := validateImportedSubnets("private", o.ImportPrivateSubnetIDs, o.ImportVPCID, subnets); err != nil {
+		return err
+	}
+	o.ImportPublicSubnetIDs = spreadSubnetsAcrossAZs(o.ImportPublicSubnetIDs, subnets)
+	o.ImportPrivateSubnetIDs = spreadSubnetsAcrossAZs(o.ImportPrivateSubnetIDs, subnets)
+	return nil
+}
+
+// askVPCID asks for the VPC to import if it isn't passed by flag, and looks up its CIDR block.
+func (o *initEnvOpts) askVPCID() error {
+	vpcs, err := o.vpcLister.ListVPCs()
+	if err != nil {
+		return fmt.Errorf("list VPCs: %w", err)
+	}
+	if len(vpcs) == 0 {
+		return errVPCsNotFound
+	}
+	if o.ImportVPCID == "" {
+		var options []string
+		idFor := make(map[string]string)
+		for _, vpc := range vpcs {
+			options = append(options, vpc.String())
+			idFor[vpc.String()] = vpc.ID
+		}
+		vpc, err := o.prompt.SelectOne(envInitVPCPrompt, envInitVPCHelpPrompt, options)
+		if err != nil {
+			return fmt.Errorf("select VPC: %w", err)
+		}
+		o.ImportVPCID = idFor[vpc]
+	}
+	for _, vpc := range vpcs {
+		if vpc.ID == o.ImportVPCID {
+			o.importVPCCIDR = vpc.CIDR
+			return nil
+		}
+	}
+	return fmt.Errorf("VPC %s not found", o.ImportVPCID)
+}
+
+func (o *initEnvOpts) askSubnetIDs(msg, help string, subnets []ec2.Subnet) ([]string, error) {
+	var options []string
+	idFor := make(map[string]string)
+	for _, subnet := range subnets {
+		option := fmt.Sprintf(fmtEnvInitSubnetOption, subnet, subnet.AvailabilityZone)
+		options = append(options, option)
+		idFor[option] = subnet.ID
+	}
+	selected, err := o.prompt.MultiSelect(msg, help, options)
+	if err != nil {
+		return nil, err
+	}
+	var ids []string
+	for _, option := range selected {
+		ids = append(ids, idFor[option])
+	}
+	return ids, nil
+}
+
+// validateImportedSubnets returns an error if one of the subnets isn't in the VPC,
+// or if the subnets don't span enough availability zones.
+func validateImportedSubnets(kind string, ids []string, vpcID string, vpcSubnets []ec2.Subnet) error {
+	azFor := make(map[string]string)
+	for _, subnet := range vpcSubnets {
+		azFor[subnet.ID] = subnet.AvailabilityZone
+	}
+	azs := make(map[string]bool)
+	for _, id := range ids {
+		az, ok := azFor[id]
+		if !ok {
+			return fmt.Errorf("%s subnet %s is not in VPC %s", kind, id, vpcID)
+		}
+		azs[az] = true
+	}
+	if len(azs) < minImportedSubnetAZs {
+		return fmt.Errorf("%s subnets must span at least %d availability zones", kind, minImportedSubnetAZs)
+	}
+	return nil
+}
+
+// spreadSubnetsAcrossAZs returns the subnets with the first two in different availability zones,
+// since services and EFS mount targets are only placed in the first two subnets of the environment.
+// The other subnets keep their order.
+func spreadSubnetsAcrossAZs(ids []string, vpcSubnets []ec2.Subnet) []string {
+	azFor := make(map[string]string)
+	for _, subnet := range vpcSubnets {
+		azFor[subnet.ID] = subnet.AvailabilityZone
+	}
+	for i := 1; i < len(ids); i++ {
+		if azFor[ids[i]] == azFor[ids[0]] {
+			continue
+		}
+		spread := append([]string{ids[0], ids[i]}, ids[1:i]...)
+		return append(spread, ids[i+1:]...)
+	}
+	return ids
+}
+
+// importsVPC returns true if any of the values to import a VPC is set.
+func (o *initEnvOpts) importsVPC() bool {
+	return o.ImportVPCID != "" || len(o.ImportPublicSubnetIDs) != 0 || len(o.ImportPrivateSubnetIDs) != 0
+}
+
 func (o *initEnvOpts) humanizeEnvironmentEvents(resourceEvents []deploy.ResourceEvent) []termprogress.TabRow {
 	matcher := map[termprogress.Text]termprogress.ResourceMatcher{
 		textVPC: func(event deploy.Resource) bool {
@@ -310,6 +509,12 @@ func (o *initEnvOpts) humanizeEnvironmentEvents(resourceEvents []deploy.Resource
 				strings.Contains(event.Type, "ElasticLoadBalancingV2")
 		},
 	}
+	if o.importsVPC() {
+		// The network resources already exist, only the resources that are created are shown.
+		for _, text := range []termprogress.Text{textVPC, textInternetGateway, textPublicSubnets, textPrivateSubnets, textRouteTables} {
+			delete(matcher, text)
+		}
+	}
 	resourceCounts := map[termprogress.Text]int{
 		textVPC:             1,
 		textInternetGateway: 2,
@@ -341,7 +546,19 @@ func BuildEnvInitCmd() *cobra.Command {
   /code $ copilot env init --name test --profile default
 
   Creates a prod-iad environment using your "prod-admin" AWS profile.
-  /code $ copilot env init --name prod-iad --profile prod-admin --prod`,
+  /code $ copilot env init --name prod-iad --profile prod-admin --prod
+
+  Creates a test environment in an existing VPC.
+  /code $ copilot env init --name test --profile default \
+    --import-vpc-id vpc-0123 \
+    --import-public-subnets subnet-0a,subnet-0b \
+    --import-private-subnets subnet-1a,subnet-1b
+
+  Creates a test environment whose load balancer keeps idle connections open for 5 minutes.
+  /code $ copilot env init --name test --profile default --lb-idle-timeout 5m
+
+  Creates a prod environment with NAT gateways for the services placed in private subnets.
+  /code $ copilot env init --name prod --profile prod-admin --prod --nat-gateways`,
 		RunE: runCmdE(func(cmd *cobra.Command, args []string) error {
 			opts, err := newInitEnvOpts(vars)
 			if err != nil {
@@ -359,5 +576,10 @@ func BuildEnvInitCmd() *cobra.Command {
 	cmd.Flags().StringVarP(&vars.EnvName, nameFlag, nameFlagShort, "", envFlagDescription)
 	cmd.Flags().StringVar(&vars.EnvProfile, profileFlag, "", profileFlagDescription)
 	cmd.Flags().BoolVar(&vars.IsProduction, prodEnvFlag, false, prodEnvFlagDescription)
+	cmd.Flags().StringVar(&vars.ImportVPCID, importVPCIDFlag, "", importVPCIDFlagDescription)
+	cmd.Flags().StringSliceVar(&vars.ImportPublicSubnetIDs, importPublicSubnetsFlag, nil, importPublicSubnetsFlagDescription)
+	cmd.Flags().StringSliceVar(&vars.ImportPrivateSubnetIDs, importPrivateSubnetsFlag, nil, importPrivateSubnetsFlagDescription)
+	cmd.Flags().DurationVar(&vars.LBIdleTimeout, lbIdleTimeoutFlag, 0, lbIdleTimeoutFlagDescription)
+	cmd.Flags().BoolVar(&vars.NATGateways, natGatewaysFlag, false, natGatewaysFlagDescription)
 	return cmd
 }
@@ -7,8 +7,10 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/aws/cloudformation"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/aws/ec2"
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/aws/identity"
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/config"
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/deploy"
@@ -22,8 +24,11 @@ import (
 
 func TestInitEnvOpts_Validate(t *testing.T) {
 	testCases := map[string]struct {
-		inEnvName string
-		inAppName string
+		inEnvName       string
+		inAppName       string
+		inLBIdleTimeout time.Duration
+		inNATGateways   bool
+		inImportVPCID   string
 
 		wantedErr string
 	}{
@@ -43,6 +48,38 @@ func TestInitEnvOpts_Validate(t *testing.T) {
 
 			wantedErr: "no application found: run `app init` or `cd` into your workspace please",
 		},
+		"valid load balancer idle timeout": {
+			inEnvName:       "test-pdx",
+			inAppName:       "phonetool",
+			inLBIdleTimeout: 5 * time.Minute,
+		},
+		"load balancer idle timeout too long": {
+			inEnvName:       "test-pdx",
+			inAppName:       "phonetool",
+			inLBIdleTimeout: 2 * time.Hour,
+
+			wantedErr: "load balancer idle timeout 2h0m0s must be a whole number of seconds between 1 and 4000",
+		},
+		"load balancer idle timeout not in seconds": {
+			inEnvName:       "test-pdx",
+			inAppName:       "phonetool",
+			inLBIdleTimeout: 1500 * time.Millisecond,
+
+			wantedErr: "load balancer idle timeout 1.5s must be a whole number of seconds between 1 and 4000",
+		},
+		"NAT gateways in a new VPC": {
+			inEnvName:     "test-pdx",
+			inAppName:     "phonetool",
+			inNATGateways: true,
+		},
+		"NAT gateways in an imported VPC": {
+			inEnvName:     "test-pdx",
+			inAppName:     "phonetool",
+			inNATGateways: true,
+			inImportVPCID: "vpc-0123",
+
+			wantedErr: "--nat-gateways can't be used with --import-vpc-id, the imported VPC routes the traffic of its private subnets",
+		},
 	}
 
 	for name, tc := range testCases {
@@ -50,8 +87,11 @@ func TestInitEnvOpts_Validate(t *testing.T) {
 			// GIVEN
 			opts := &initEnvOpts{
 				initEnvVars: initEnvVars{
-					EnvName:    tc.inEnvName,
-					GlobalOpts: &GlobalOpts{appName: tc.inAppName},
+					EnvName:       tc.inEnvName,
+					GlobalOpts:    &GlobalOpts{appName: tc.inAppName},
+					LBIdleTimeout: tc.inLBIdleTimeout,
+					NATGateways:   tc.inNATGateways,
+					ImportVPCID:   tc.inImportVPCID,
 				},
 			}
 
@@ -72,18 +112,34 @@ func TestInitEnvOpts_Ask(t *testing.T) {
 
 	mockEnv := "test"
 	mockProfile := "default"
+	mockVPCs := []ec2.VPC{
+		{ID: "vpc-0123", Name: "shared", CIDR: "10.0.0.0/16"},
+	}
+	mockSubnets := []ec2.Subnet{
+		{ID: "subnet-0a", Name: "public-a", AvailabilityZone: "us-west-2a"},
+		{ID: "subnet-0b", Name: "public-b", AvailabilityZone: "us-west-2b"},
+		{ID: "subnet-1a", AvailabilityZone: "us-west-2a"},
+		{ID: "subnet-1b", AvailabilityZone: "us-west-2b"},
+	}
 
 	testCases := map[string]struct {
-		inputEnv     string
-		inputProfile string
-		inputApp     string
-
-		setupMocks func(*mocks.Mockprompter, *mocks.MockprofileNames)
-
-		wantedError error
+		inputEnv            string
+		inputProfile        string
+		inputApp            string
+		inputVPC            string
+		inputPublicSubnets  []string
+		inputPrivateSubnets []string
+
+		setupMocks func(*mocks.Mockprompter, *mocks.MockprofileNames, *mocks.MockvpcSubnetLister)
+
+		wantedVPC            string
+		wantedVPCCIDR        string
+		wantedPublicSubnets  []string
+		wantedPrivateSubnets []string
+		wantedError          error
 	}{
 		"with no flags set": {
-			setupMocks: func(mockPrompter *mocks.Mockprompter, mockCfg *mocks.MockprofileNames) {
+			setupMocks: func(mockPrompter *mocks.Mockprompter, mockCfg *mocks.MockprofileNames, mockVPC *mocks.MockvpcSubnetLister) {
 				mockPrompter.EXPECT().
 					Get(
 						gomock.Eq(envInitNamePrompt),
@@ -97,10 +153,11 @@ func TestInitEnvOpts_Ask(t *testing.T) {
 						gomock.Eq(envInitProfileHelpPrompt),
 						gomock.Any()).
 					Return(mockProfile, nil)
+				mockPrompter.EXPECT().Confirm(envInitImportVPCPrompt, envInitImportVPCHelpPrompt).Return(false, nil)
 			},
 		},
 		"with no existing named profiles": {
-			setupMocks: func(mockPrompter *mocks.Mockprompter, mockCfg *mocks.MockprofileNames) {
+			setupMocks: func(mockPrompter *mocks.Mockprompter, mockCfg *mocks.MockprofileNames, mockVPC *mocks.MockvpcSubnetLister) {
 				mockPrompter.EXPECT().
 					Get(
 						gomock.Eq(envInitNamePrompt),
@@ -111,6 +168,112 @@ func TestInitEnvOpts_Ask(t *testing.T) {
 			},
 			wantedError: errNamedProfilesNotFound,
 		},
+		"doesn't ask to import a VPC if the name and profile are passed by flags": {
+			inputEnv:     mockEnv,
+			inputProfile: mockProfile,
+			setupMocks: func(mockPrompter *mocks.Mockprompter, mockCfg *mocks.MockprofileNames, mockVPC *mocks.MockvpcSubnetLister) {
+				mockPrompter.EXPECT().Confirm(gomock.Any(), gomock.Any()).Times(0)
+			},
+		},
+		"imports a VPC and its subnets selected by the user": {
+			setupMocks: func(mockPrompter *mocks.Mockprompter, mockCfg *mocks.MockprofileNames, mockVPC *mocks.MockvpcSubnetLister) {
+				mockPrompter.EXPECT().Get(envInitNamePrompt, envInitNameHelpPrompt, gomock.Any()).Return(mockEnv, nil)
+				mockCfg.EXPECT().Names().Return([]string{mockProfile})
+				mockPrompter.EXPECT().SelectOne(fmt.Sprintf(fmtEnvInitProfilePrompt, mockEnv), envInitProfileHelpPrompt, gomock.Any()).Return(mockProfile, nil)
+				mockPrompter.EXPECT().Confirm(envInitImportVPCPrompt, envInitImportVPCHelpPrompt).Return(true, nil)
+				mockVPC.EXPECT().ListVPCs().Return([]ec2.VPC{
+					{ID: "vpc-0123", Name: "shared", CIDR: "10.0.0.0/16"},
+					{ID: "vpc-4567", CIDR: "172.16.0.0/16"},
+				}, nil)
+				mockPrompter.EXPECT().SelectOne(envInitVPCPrompt, envInitVPCHelpPrompt, []string{"vpc-0123 (shared)", "vpc-4567"}).Return("vpc-0123 (shared)", nil)
+				mockVPC.EXPECT().ListSubnets("vpc-0123").Return(mockSubnets, nil)
+				options := []string{
+					"subnet-0a (public-a) in us-west-2a",
+					"subnet-0b (public-b) in us-west-2b",
+					"subnet-1a in us-west-2a",
+					"subnet-1b in us-west-2b",
+				}
+				mockPrompter.EXPECT().MultiSelect(envInitPublicSubnetsPrompt, envInitPublicSubnetsHelpPrompt, options).Return(options[:2], nil)
+				mockPrompter.EXPECT().MultiSelect(envInitPrivateSubnetsPrompt, envInitPrivateSubnetsHelpPrompt, options).Return(options[2:], nil)
+			},
+			wantedVPC:            "vpc-0123",
+			wantedVPCCIDR:        "10.0.0.0/16",
+			wantedPublicSubnets:  []string{"subnet-0a", "subnet-0b"},
+			wantedPrivateSubnets: []string{"subnet-1a", "subnet-1b"},
+		},
+		"imports the VPC and subnets passed by flags": {
+			inputEnv:            mockEnv,
+			inputProfile:        mockProfile,
+			inputVPC:            "vpc-0123",
+			inputPublicSubnets:  []string{"subnet-0a", "subnet-0b"},
+			inputPrivateSubnets: []string{"subnet-1a", "subnet-1b"},
+			setupMocks: func(mockPrompter *mocks.Mockprompter, mockCfg *mocks.MockprofileNames, mockVPC *mocks.MockvpcSubnetLister) {
+				mockVPC.EXPECT().ListVPCs().Return(mockVPCs, nil)
+				mockVPC.EXPECT().ListSubnets("vpc-0123").Return(mockSubnets, nil)
+			},
+			wantedVPC:            "vpc-0123",
+			wantedVPCCIDR:        "10.0.0.0/16",
+			wantedPublicSubnets:  []string{"subnet-0a", "subnet-0b"},
+			wantedPrivateSubnets: []string{"subnet-1a", "subnet-1b"},
+		},
+		"places the first two subnets in different availability zones": {
+			inputEnv:            mockEnv,
+			inputProfile:        mockProfile,
+			inputVPC:            "vpc-0123",
+			inputPublicSubnets:  []string{"subnet-0a", "subnet-1a", "subnet-0b"},
+			inputPrivateSubnets: []string{"subnet-1b", "subnet-0b", "subnet-1a"},
+			setupMocks: func(mockPrompter *mocks.Mockprompter, mockCfg *mocks.MockprofileNames, mockVPC *mocks.MockvpcSubnetLister) {
+				mockVPC.EXPECT().ListVPCs().Return(mockVPCs, nil)
+				mockVPC.EXPECT().ListSubnets("vpc-0123").Return(mockSubnets, nil)
+			},
+			wantedVPC:            "vpc-0123",
+			wantedVPCCIDR:        "10.0.0.0/16",
+			wantedPublicSubnets:  []string{"subnet-0a", "subnet-0b", "subnet-1a"},
+			wantedPrivateSubnets: []string{"subnet-1b", "subnet-1a", "subnet-0b"},
+		},
+		"returns an error if the VPC doesn't exist": {
+			inputEnv:     mockEnv,
+			inputProfile: mockProfile,
+			inputVPC:     "vpc-9999",
+			setupMocks: func(mockPrompter *mocks.Mockprompter, mockCfg *mocks.MockprofileNames, mockVPC *mocks.MockvpcSubnetLister) {
+				mockVPC.EXPECT().ListVPCs().Return(mockVPCs, nil)
+			},
+			wantedError: errors.New("VPC vpc-9999 not found"),
+		},
+		"returns a wrapped error if the subnets can't be listed": {
+			inputEnv:     mockEnv,
+			inputProfile: mockProfile,
+			inputVPC:     "vpc-0123",
+			setupMocks: func(mockPrompter *mocks.Mockprompter, mockCfg *mocks.MockprofileNames, mockVPC *mocks.MockvpcSubnetLister) {
+				mockVPC.EXPECT().ListVPCs().Return(mockVPCs, nil)
+				mockVPC.EXPECT().ListSubnets("vpc-0123").Return(nil, errors.New("some error"))
+			},
+			wantedError: errors.New("list subnets of VPC vpc-0123: some error"),
+		},
+		"returns an error if a subnet isn't in the VPC": {
+			inputEnv:            mockEnv,
+			inputProfile:        mockProfile,
+			inputVPC:            "vpc-0123",
+			inputPublicSubnets:  []string{"subnet-0a", "subnet-9z"},
+			inputPrivateSubnets: []string{"subnet-1a", "subnet-1b"},
+			setupMocks: func(mockPrompter *mocks.Mockprompter, mockCfg *mocks.MockprofileNames, mockVPC *mocks.MockvpcSubnetLister) {
+				mockVPC.EXPECT().ListVPCs().Return(mockVPCs, nil)
+				mockVPC.EXPECT().ListSubnets("vpc-0123").Return(mockSubnets, nil)
+			},
+			wantedError: errors.New("public subnet subnet-9z is not in VPC vpc-0123"),
+		},
+		"returns an error if the subnets are in a single availability zone": {
+			inputEnv:            mockEnv,
+			inputProfile:        mockProfile,
+			inputVPC:            "vpc-0123",
+			inputPublicSubnets:  []string{"subnet-0a", "subnet-0b"},
+			inputPrivateSubnets: []string{"subnet-1a"},
+			setupMocks: func(mockPrompter *mocks.Mockprompter, mockCfg *mocks.MockprofileNames, mockVPC *mocks.MockvpcSubnetLister) {
+				mockVPC.EXPECT().ListVPCs().Return(mockVPCs, nil)
+				mockVPC.EXPECT().ListSubnets("vpc-0123").Return(mockSubnets, nil)
+			},
+			wantedError: errors.New("private subnets must span at least 2 availability zones"),
+		},
 	}
 
 	for name, tc := range testCases {
@@ -120,19 +283,27 @@ func TestInitEnvOpts_Ask(t *testing.T) {
 
 			mockPrompter := mocks.NewMockprompter(ctrl)
 			mockCfg := mocks.NewMockprofileNames(ctrl)
+			mockVPC := mocks.NewMockvpcSubnetLister(ctrl)
 			// GIVEN
 			addEnv := &initEnvOpts{
 				initEnvVars: initEnvVars{
-					EnvName:    tc.inputEnv,
-					EnvProfile: tc.inputProfile,
+					EnvName:                tc.inputEnv,
+					EnvProfile:             tc.inputProfile,
+					ImportVPCID:            tc.inputVPC,
+					ImportPublicSubnetIDs:  tc.inputPublicSubnets,
+					ImportPrivateSubnetIDs: tc.inputPrivateSubnets,
 					GlobalOpts: &GlobalOpts{
 						prompt:  mockPrompter,
 						appName: tc.inputApp,
 					},
 				},
 				profileConfig: mockCfg,
+				initProfileClients: func(o *initEnvOpts) error {
+					o.vpcLister = mockVPC
+					return nil
+				},
 			}
-			tc.setupMocks(mockPrompter, mockCfg)
+			tc.setupMocks(mockPrompter, mockCfg, mockVPC)
 
 			// WHEN
 			err := addEnv.Ask()
@@ -141,6 +312,10 @@ func TestInitEnvOpts_Ask(t *testing.T) {
 			if tc.wantedError == nil {
 				require.NoError(t, err)
 				require.Equal(t, mockEnv, addEnv.EnvName, "expected environment names to match")
+				require.Equal(t, tc.wantedVPC, addEnv.ImportVPCID)
+				require.Equal(t, tc.wantedVPCCIDR, addEnv.importVPCCIDR)
+				require.Equal(t, tc.wantedPublicSubnets, addEnv.ImportPublicSubnetIDs)
+				require.Equal(t, tc.wantedPrivateSubnets, addEnv.ImportPrivateSubnetIDs)
 			} else {
 				require.EqualError(t, err, tc.wantedError.Error())
 			}
@@ -150,9 +325,11 @@ func TestInitEnvOpts_Ask(t *testing.T) {
 
 func TestInitEnvOpts_Execute(t *testing.T) {
 	testCases := map[string]struct {
-		inAppName string
-		inEnvName string
-		inProd    bool
+		inAppName       string
+		inEnvName       string
+		inProd          bool
+		inLBIdleTimeout time.Duration
+		inNATGateways   bool
 
 		expectstore    func(m *mocks.Mockstore)
 		expectDeployer func(m *mocks.Mockdeployer)
@@ -473,6 +650,72 @@ func TestInitEnvOpts_Execute(t *testing.T) {
 				m.EXPECT().AddEnvToApp(gomock.Any(), gomock.Any()).Return(nil)
 			},
 		},
+		"passes the idle timeout of the load balancer": {
+			inAppName:       "phonetool",
+			inEnvName:       "test",
+			inLBIdleTimeout: 5 * time.Minute,
+
+			expectstore: func(m *mocks.Mockstore) {
+				m.EXPECT().GetApplication("phonetool").Return(&config.Application{Name: "phonetool"}, nil)
+				m.EXPECT().CreateEnvironment(gomock.Any()).Return(nil)
+			},
+			expectIdentity: func(m *mocks.MockidentityService) {
+				m.EXPECT().Get().Return(identity.Caller{RootUserARN: "some arn"}, nil)
+			},
+			expectProgress: func(m *mocks.Mockprogress) {
+				m.EXPECT().Start(gomock.Any()).AnyTimes()
+				m.EXPECT().Stop(gomock.Any()).AnyTimes()
+			},
+			expectDeployer: func(m *mocks.Mockdeployer) {
+				m.EXPECT().DeployEnvironment(&deploy.CreateEnvironmentInput{
+					Name:                     "test",
+					AppName:                  "phonetool",
+					PublicLoadBalancer:       true,
+					ToolsAccountPrincipalARN: "some arn",
+					LBIdleTimeout:            5 * time.Minute,
+				}).Return(&cloudformation.ErrStackAlreadyExists{})
+				m.EXPECT().GetEnvironment("phonetool", "test").Return(&config.Environment{
+					AccountID: "1234",
+					Region:    "mars-1",
+					Name:      "test",
+					App:       "phonetool",
+				}, nil)
+				m.EXPECT().AddEnvToApp(gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
+		"passes whether to create NAT gateways": {
+			inAppName:     "phonetool",
+			inEnvName:     "test",
+			inNATGateways: true,
+
+			expectstore: func(m *mocks.Mockstore) {
+				m.EXPECT().GetApplication("phonetool").Return(&config.Application{Name: "phonetool"}, nil)
+				m.EXPECT().CreateEnvironment(gomock.Any()).Return(nil)
+			},
+			expectIdentity: func(m *mocks.MockidentityService) {
+				m.EXPECT().Get().Return(identity.Caller{RootUserARN: "some arn"}, nil)
+			},
+			expectProgress: func(m *mocks.Mockprogress) {
+				m.EXPECT().Start(gomock.Any()).AnyTimes()
+				m.EXPECT().Stop(gomock.Any()).AnyTimes()
+			},
+			expectDeployer: func(m *mocks.Mockdeployer) {
+				m.EXPECT().DeployEnvironment(&deploy.CreateEnvironmentInput{
+					Name:                     "test",
+					AppName:                  "phonetool",
+					PublicLoadBalancer:       true,
+					ToolsAccountPrincipalARN: "some arn",
+					NATGateways:              true,
+				}).Return(&cloudformation.ErrStackAlreadyExists{})
+				m.EXPECT().GetEnvironment("phonetool", "test").Return(&config.Environment{
+					AccountID: "1234",
+					Region:    "mars-1",
+					Name:      "test",
+					App:       "phonetool",
+				}, nil)
+				m.EXPECT().AddEnvToApp(gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
 		"failed to delegate DNS (app has Domain and env and apps are different)": {
 			inAppName: "phonetool",
 			inEnvName: "test",
@@ -570,9 +813,11 @@ func TestInitEnvOpts_Execute(t *testing.T) {
 
 			opts := &initEnvOpts{
 				initEnvVars: initEnvVars{
-					EnvName:      tc.inEnvName,
-					GlobalOpts:   &GlobalOpts{appName: tc.inAppName},
-					IsProduction: tc.inProd,
+					EnvName:       tc.inEnvName,
+					GlobalOpts:    &GlobalOpts{appName: tc.inAppName},
+					IsProduction:  tc.inProd,
+					LBIdleTimeout: tc.inLBIdleTimeout,
+					NATGateways:   tc.inNATGateways,
 				},
 				store:       mockstore,
 				envDeployer: mockDeployer,
@@ -45,6 +45,14 @@ const (
 	localFlag             = "local"
 	deleteSecretFlag      = "delete-secret"
 	svcPortFlag           = "port"
+	scheduleFlag          = "schedule"
+	sitePathFlag          = "path"
+
+	importVPCIDFlag          = "import-vpc-id"
+	importPublicSubnetsFlag  = "import-public-subnets"
+	importPrivateSubnetsFlag = "import-private-subnets"
+	lbIdleTimeoutFlag        = "lb-idle-timeout"
+	natGatewaysFlag          = "nat-gateways"
 
 	storageTypeFlag = "storage-type"
 )
@@ -108,6 +116,18 @@ Defaults to all logs. Only one of end-time / follow may be used.`
 	envProfilesFlagDescription       = "Optional. Environments and the profile to use to delete the environment."
 	deleteSecretFlagDescription      = "Deletes AWS Secrets Manager secret associated with a pipeline source repository."
 	svcPortFlagDescription           = "Optional. The port on which your service listens."
+	scheduleFlagDescription          = `The schedule on which to run this job.
+Must be a rate or cron expression, or one of @hourly, @daily, @weekly, @monthly, @yearly.`
+	sitePathFlagDescription = "Directory of the files of your static site, relative to the root of your workspace."
+
+	importVPCIDFlagDescription          = "Optional. ID of an existing VPC to place the environment in."
+	importPublicSubnetsFlagDescription  = "Optional. IDs of the public subnets of the imported VPC, in at least two availability zones."
+	importPrivateSubnetsFlagDescription = "Optional. IDs of the private subnets of the imported VPC, in at least two availability zones."
+	lbIdleTimeoutFlagDescription        = `Optional. How long connections to the public load balancer can stay idle.
+Shared by the services of the environment, between 1s and 4000s. Defaults to 60s.`
+	natGatewaysFlagDescription = `Optional. Create a NAT gateway in each public subnet of the new VPC.
+Services placed in the private subnets need them to pull images and send logs.
+NAT gateways are billed by the hour even without traffic, so leave them out if no service is private.`
 
 	storageFlagDescription        = "Name of the storage resource to create."
 	storageServiceFlagDescription = "Name of the service to associate with storage."
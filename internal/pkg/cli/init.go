@@ -16,6 +16,7 @@ import (
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/deploy/cloudformation"
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/docker"
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/docker/dockerfile"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/manifest"
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/term/color"
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/term/command"
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/term/log"
@@ -47,6 +48,8 @@ type initVars struct {
 	profile        string
 	imageTag       string
 	port           uint16
+	schedule       string
+	sitePath       string
 }
 
 type initOpts struct {
@@ -66,7 +69,9 @@ type initOpts struct {
 	svcType        *string
 	svcName        *string
 	svcPort        *uint16
+	schedule       *string
 	dockerfilePath *string
+	sitePath       *string
 
 	prompt prompter
 }
@@ -111,6 +116,8 @@ func newInitOpts(vars initVars) (*initOpts, error) {
 			Name:           vars.svcName,
 			DockerfilePath: vars.dockerfilePath,
 			Port:           vars.port,
+			Schedule:       vars.schedule,
+			SitePath:       vars.sitePath,
 			GlobalOpts:     NewGlobalOpts(),
 		},
 		fs:          &afero.Afero{Fs: afero.NewOsFs()},
@@ -166,7 +173,9 @@ func newInitOpts(vars initVars) (*initOpts, error) {
 		svcType:        &initSvcCmd.ServiceType,
 		svcName:        &initSvcCmd.Name,
 		svcPort:        &initSvcCmd.Port,
+		schedule:       &initSvcCmd.Schedule,
 		dockerfilePath: &initSvcCmd.DockerfilePath,
+		sitePath:       &initSvcCmd.SitePath,
 
 		prompt: prompt,
 	}, nil
@@ -187,8 +196,20 @@ containerized services that operate together.`)
 		return err
 	}
 
-	log.Infof("Ok great, we'll set up a %s named %s in application %s listening on port %s.\n",
-		color.HighlightUserInput(*o.svcType), color.HighlightUserInput(*o.svcName), color.HighlightUserInput(*o.appName), color.HighlightUserInput(fmt.Sprintf("%d", *o.svcPort)))
+	switch *o.svcType {
+	case manifest.ScheduledJobType:
+		log.Infof("Ok great, we'll set up a %s named %s in application %s running on the schedule %s.\n",
+			color.HighlightUserInput(*o.svcType), color.HighlightUserInput(*o.svcName), color.HighlightUserInput(*o.appName), color.HighlightUserInput(*o.schedule))
+	case manifest.WorkerServiceType:
+		log.Infof("Ok great, we'll set up a %s named %s in application %s consuming messages from a queue.\n",
+			color.HighlightUserInput(*o.svcType), color.HighlightUserInput(*o.svcName), color.HighlightUserInput(*o.appName))
+	case manifest.StaticSiteType:
+		log.Infof("Ok great, we'll set up a %s named %s in application %s serving the files in %s.\n",
+			color.HighlightUserInput(*o.svcType), color.HighlightUserInput(*o.svcName), color.HighlightUserInput(*o.appName), color.HighlightUserInput(*o.sitePath))
+	default:
+		log.Infof("Ok great, we'll set up a %s named %s in application %s listening on port %s.\n",
+			color.HighlightUserInput(*o.svcType), color.HighlightUserInput(*o.svcName), color.HighlightUserInput(*o.appName), color.HighlightUserInput(fmt.Sprintf("%d", *o.svcPort)))
+	}
 
 	if err := o.initAppCmd.Execute(); err != nil {
 		return fmt.Errorf("execute app init: %w", err)
@@ -298,6 +319,8 @@ func BuildInitCmd() *cobra.Command {
 	cmd.Flags().BoolVar(&vars.shouldDeploy, deployFlag, false, deployTestFlagDescription)
 	cmd.Flags().StringVar(&vars.imageTag, imageTagFlag, "", imageTagFlagDescription)
 	cmd.Flags().Uint16Var(&vars.port, svcPortFlag, 0, svcPortFlagDescription)
+	cmd.Flags().StringVar(&vars.schedule, scheduleFlag, "", scheduleFlagDescription)
+	cmd.Flags().StringVar(&vars.sitePath, sitePathFlag, "", sitePathFlagDescription)
 	cmd.SetUsageTemplate(template.Usage)
 	cmd.Annotations = map[string]string{
 		"group": group.GettingStarted,
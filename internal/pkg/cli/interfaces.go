@@ -7,14 +7,18 @@ import (
 	"encoding"
 	"io"
 
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/aws/cloudformation"
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/aws/cloudwatchlogs"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/aws/codedeploy"
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/aws/codepipeline"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/aws/ec2"
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/aws/ecr"
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/aws/ecs"
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/config"
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/deploy"
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/deploy/cloudformation/stack"
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/describe"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/docker"
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/term/command"
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/workspace"
 	"github.com/aws/aws-sdk-go/aws/session"
@@ -147,7 +151,7 @@ type stackSerializer interface {
 }
 
 type dockerService interface {
-	Build(uri, tag, path string) error
+	Build(args *docker.BuildArguments) error
 	Login(uri, username, password string) error
 	Push(uri, tag string) error
 }
@@ -216,6 +220,25 @@ type wsSvcReader interface {
 	svcManifestReader
 }
 
+type wsFileReader interface {
+	ReadFile(path string) ([]byte, error)
+}
+
+type wsFileLister interface {
+	ListFiles(dir string) ([]string, error)
+}
+
+type wsSvcFileReader interface {
+	wsSvcReader
+	wsFileReader
+	wsFileLister
+}
+
+type wsSvcManifestUpgrader interface {
+	wsSvcReader
+	OverwriteServiceManifest(data []byte, name string) (string, error)
+}
+
 type wsPipelineDeleter interface {
 	DeletePipelineManifest() error
 	wsPipelineManifestReader
@@ -239,6 +262,21 @@ type bucketEmptier interface {
 	EmptyBucket(bucket string) error
 }
 
+type bucketSyncer interface {
+	PutObject(bucket, key string, data io.Reader) error
+	ListObjectKeys(bucket string) ([]string, error)
+	DeleteObjects(bucket string, keys []string) error
+}
+
+type cacheInvalidator interface {
+	CreateInvalidation(distributionID string, paths []string) (string, error)
+}
+
+type vpcSubnetLister interface {
+	ListVPCs() ([]ec2.VPC, error)
+	ListSubnets(vpcID string) ([]ec2.Subnet, error)
+}
+
 // Interfaces for deploying resources through CloudFormation. Facilitates mocking.
 type environmentDeployer interface {
 	DeployEnvironment(env *deploy.CreateEnvironmentInput) error
@@ -300,6 +338,26 @@ type serviceArnGetter interface {
 	GetServiceArn() (*ecs.ServiceArn, error)
 }
 
+type serviceDeploymentDescriber interface {
+	serviceArnGetter
+	TaskDefinitionArn() (string, error)
+	ServiceOutputs() (map[string]string, error)
+}
+
+type ecsDeploymentWaiter interface {
+	Service(clusterName, serviceName string) (*ecs.Service, error)
+	WaitForSteadyDeployment(clusterName, serviceName string) (string, error)
+}
+
+type stackDescriber interface {
+	Describe(stackName string) (*cloudformation.StackDescription, error)
+}
+
+type ecsBlueGreenDeployer interface {
+	CreateECSDeployment(in *codedeploy.ECSDeploymentInput) (string, error)
+	WaitForDeployment(id string) (*codedeploy.Deployment, error)
+}
+
 type statusDescriber interface {
 	Describe() (*describe.ServiceStatusDesc, error)
 }
@@ -6,14 +6,18 @@ package mocks
 
 import (
 	encoding "encoding"
+	cloudformation "github.com/aws/amazon-ecs-cli-v2/internal/pkg/aws/cloudformation"
 	cloudwatchlogs "github.com/aws/amazon-ecs-cli-v2/internal/pkg/aws/cloudwatchlogs"
+	codedeploy "github.com/aws/amazon-ecs-cli-v2/internal/pkg/aws/codedeploy"
 	codepipeline "github.com/aws/amazon-ecs-cli-v2/internal/pkg/aws/codepipeline"
+	ec2 "github.com/aws/amazon-ecs-cli-v2/internal/pkg/aws/ec2"
 	ecr "github.com/aws/amazon-ecs-cli-v2/internal/pkg/aws/ecr"
 	ecs "github.com/aws/amazon-ecs-cli-v2/internal/pkg/aws/ecs"
 	config "github.com/aws/amazon-ecs-cli-v2/internal/pkg/config"
 	deploy "github.com/aws/amazon-ecs-cli-v2/internal/pkg/deploy"
 	stack "github.com/aws/amazon-ecs-cli-v2/internal/pkg/deploy/cloudformation/stack"
 	describe "github.com/aws/amazon-ecs-cli-v2/internal/pkg/describe"
+	docker "github.com/aws/amazon-ecs-cli-v2/internal/pkg/docker"
 	command "github.com/aws/amazon-ecs-cli-v2/internal/pkg/term/command"
 	workspace "github.com/aws/amazon-ecs-cli-v2/internal/pkg/workspace"
 	session "github.com/aws/aws-sdk-go/aws/session"
@@ -1344,17 +1348,17 @@ func (m *MockdockerService) EXPECT() *MockdockerServiceMockRecorder {
 }
 
 // Build mocks base method
-func (m *MockdockerService) Build(uri, tag, path string) error {
+func (m *MockdockerService) Build(args *docker.BuildArguments) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Build", uri, tag, path)
+	ret := m.ctrl.Call(m, "Build", args)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Build indicates an expected call of Build
-func (mr *MockdockerServiceMockRecorder) Build(uri, tag, path interface{}) *gomock.Call {
+func (mr *MockdockerServiceMockRecorder) Build(args interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Build", reflect.TypeOf((*MockdockerService)(nil).Build), uri, tag, path)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Build", reflect.TypeOf((*MockdockerService)(nil).Build), args)
 }
 
 // Login mocks base method
@@ -2016,6 +2020,233 @@ func (mr *MockwsSvcReaderMockRecorder) ReadServiceManifest(svcName interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadServiceManifest", reflect.TypeOf((*MockwsSvcReader)(nil).ReadServiceManifest), svcName)
 }
 
+// MockwsFileReader is a mock of wsFileReader interface
+type MockwsFileReader struct {
+	ctrl     *gomock.Controller
+	recorder *MockwsFileReaderMockRecorder
+}
+
+// MockwsFileReaderMockRecorder is the mock recorder for MockwsFileReader
+type MockwsFileReaderMockRecorder struct {
+	mock *MockwsFileReader
+}
+
+// NewMockwsFileReader creates a new mock instance
+func NewMockwsFileReader(ctrl *gomock.Controller) *MockwsFileReader {
+	mock := &MockwsFileReader{ctrl: ctrl}
+	mock.recorder = &MockwsFileReaderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockwsFileReader) EXPECT() *MockwsFileReaderMockRecorder {
+	return m.recorder
+}
+
+// ReadFile mocks base method
+func (m *MockwsFileReader) ReadFile(path string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadFile", path)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadFile indicates an expected call of ReadFile
+func (mr *MockwsFileReaderMockRecorder) ReadFile(path interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadFile", reflect.TypeOf((*MockwsFileReader)(nil).ReadFile), path)
+}
+
+// MockwsFileLister is a mock of wsFileLister interface
+type MockwsFileLister struct {
+	ctrl     *gomock.Controller
+	recorder *MockwsFileListerMockRecorder
+}
+
+// MockwsFileListerMockRecorder is the mock recorder for MockwsFileLister
+type MockwsFileListerMockRecorder struct {
+	mock *MockwsFileLister
+}
+
+// NewMockwsFileLister creates a new mock instance
+func NewMockwsFileLister(ctrl *gomock.Controller) *MockwsFileLister {
+	mock := &MockwsFileLister{ctrl: ctrl}
+	mock.recorder = &MockwsFileListerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockwsFileLister) EXPECT() *MockwsFileListerMockRecorder {
+	return m.recorder
+}
+
+// ListFiles mocks base method
+func (m *MockwsFileLister) ListFiles(dir string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFiles", dir)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFiles indicates an expected call of ListFiles
+func (mr *MockwsFileListerMockRecorder) ListFiles(dir interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFiles", reflect.TypeOf((*MockwsFileLister)(nil).ListFiles), dir)
+}
+
+// MockwsSvcFileReader is a mock of wsSvcFileReader interface
+type MockwsSvcFileReader struct {
+	ctrl     *gomock.Controller
+	recorder *MockwsSvcFileReaderMockRecorder
+}
+
+// MockwsSvcFileReaderMockRecorder is the mock recorder for MockwsSvcFileReader
+type MockwsSvcFileReaderMockRecorder struct {
+	mock *MockwsSvcFileReader
+}
+
+// NewMockwsSvcFileReader creates a new mock instance
+func NewMockwsSvcFileReader(ctrl *gomock.Controller) *MockwsSvcFileReader {
+	mock := &MockwsSvcFileReader{ctrl: ctrl}
+	mock.recorder = &MockwsSvcFileReaderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockwsSvcFileReader) EXPECT() *MockwsSvcFileReaderMockRecorder {
+	return m.recorder
+}
+
+// ServiceNames mocks base method
+func (m *MockwsSvcFileReader) ServiceNames() ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ServiceNames")
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ServiceNames indicates an expected call of ServiceNames
+func (mr *MockwsSvcFileReaderMockRecorder) ServiceNames() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ServiceNames", reflect.TypeOf((*MockwsSvcFileReader)(nil).ServiceNames))
+}
+
+// ReadServiceManifest mocks base method
+func (m *MockwsSvcFileReader) ReadServiceManifest(svcName string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadServiceManifest", svcName)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadServiceManifest indicates an expected call of ReadServiceManifest
+func (mr *MockwsSvcFileReaderMockRecorder) ReadServiceManifest(svcName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadServiceManifest", reflect.TypeOf((*MockwsSvcFileReader)(nil).ReadServiceManifest), svcName)
+}
+
+// ReadFile mocks base method
+func (m *MockwsSvcFileReader) ReadFile(path string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadFile", path)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadFile indicates an expected call of ReadFile
+func (mr *MockwsSvcFileReaderMockRecorder) ReadFile(path interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadFile", reflect.TypeOf((*MockwsSvcFileReader)(nil).ReadFile), path)
+}
+
+// ListFiles mocks base method
+func (m *MockwsSvcFileReader) ListFiles(dir string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFiles", dir)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFiles indicates an expected call of ListFiles
+func (mr *MockwsSvcFileReaderMockRecorder) ListFiles(dir interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFiles", reflect.TypeOf((*MockwsSvcFileReader)(nil).ListFiles), dir)
+}
+
+// MockwsSvcManifestUpgrader is a mock of wsSvcManifestUpgrader interface
+type MockwsSvcManifestUpgrader struct {
+	ctrl     *gomock.Controller
+	recorder *MockwsSvcManifestUpgraderMockRecorder
+}
+
+// MockwsSvcManifestUpgraderMockRecorder is the mock recorder for MockwsSvcManifestUpgrader
+type MockwsSvcManifestUpgraderMockRecorder struct {
+	mock *MockwsSvcManifestUpgrader
+}
+
+// NewMockwsSvcManifestUpgrader creates a new mock instance
+func NewMockwsSvcManifestUpgrader(ctrl *gomock.Controller) *MockwsSvcManifestUpgrader {
+	mock := &MockwsSvcManifestUpgrader{ctrl: ctrl}
+	mock.recorder = &MockwsSvcManifestUpgraderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockwsSvcManifestUpgrader) EXPECT() *MockwsSvcManifestUpgraderMockRecorder {
+	return m.recorder
+}
+
+// ServiceNames mocks base method
+func (m *MockwsSvcManifestUpgrader) ServiceNames() ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ServiceNames")
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ServiceNames indicates an expected call of ServiceNames
+func (mr *MockwsSvcManifestUpgraderMockRecorder) ServiceNames() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ServiceNames", reflect.TypeOf((*MockwsSvcManifestUpgrader)(nil).ServiceNames))
+}
+
+// ReadServiceManifest mocks base method
+func (m *MockwsSvcManifestUpgrader) ReadServiceManifest(svcName string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadServiceManifest", svcName)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadServiceManifest indicates an expected call of ReadServiceManifest
+func (mr *MockwsSvcManifestUpgraderMockRecorder) ReadServiceManifest(svcName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadServiceManifest", reflect.TypeOf((*MockwsSvcManifestUpgrader)(nil).ReadServiceManifest), svcName)
+}
+
+// OverwriteServiceManifest mocks base method
+func (m *MockwsSvcManifestUpgrader) OverwriteServiceManifest(data []byte, name string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OverwriteServiceManifest", data, name)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OverwriteServiceManifest indicates an expected call of OverwriteServiceManifest
+func (mr *MockwsSvcManifestUpgraderMockRecorder) OverwriteServiceManifest(data, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OverwriteServiceManifest", reflect.TypeOf((*MockwsSvcManifestUpgrader)(nil).OverwriteServiceManifest), data, name)
+}
+
 // MockwsPipelineDeleter is a mock of wsPipelineDeleter interface
 type MockwsPipelineDeleter struct {
 	ctrl     *gomock.Controller
@@ -2248,6 +2479,163 @@ func (mr *MockbucketEmptierMockRecorder) EmptyBucket(bucket interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EmptyBucket", reflect.TypeOf((*MockbucketEmptier)(nil).EmptyBucket), bucket)
 }
 
+// MockbucketSyncer is a mock of bucketSyncer interface
+type MockbucketSyncer struct {
+	ctrl     *gomock.Controller
+	recorder *MockbucketSyncerMockRecorder
+}
+
+// MockbucketSyncerMockRecorder is the mock recorder for MockbucketSyncer
+type MockbucketSyncerMockRecorder struct {
+	mock *MockbucketSyncer
+}
+
+// NewMockbucketSyncer creates a new mock instance
+func NewMockbucketSyncer(ctrl *gomock.Controller) *MockbucketSyncer {
+	mock := &MockbucketSyncer{ctrl: ctrl}
+	mock.recorder = &MockbucketSyncerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockbucketSyncer) EXPECT() *MockbucketSyncerMockRecorder {
+	return m.recorder
+}
+
+// PutObject mocks base method
+func (m *MockbucketSyncer) PutObject(bucket, key string, data io.Reader) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutObject", bucket, key, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PutObject indicates an expected call of PutObject
+func (mr *MockbucketSyncerMockRecorder) PutObject(bucket, key, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutObject", reflect.TypeOf((*MockbucketSyncer)(nil).PutObject), bucket, key, data)
+}
+
+// ListObjectKeys mocks base method
+func (m *MockbucketSyncer) ListObjectKeys(bucket string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListObjectKeys", bucket)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListObjectKeys indicates an expected call of ListObjectKeys
+func (mr *MockbucketSyncerMockRecorder) ListObjectKeys(bucket interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListObjectKeys", reflect.TypeOf((*MockbucketSyncer)(nil).ListObjectKeys), bucket)
+}
+
+// DeleteObjects mocks base method
+func (m *MockbucketSyncer) DeleteObjects(bucket string, keys []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteObjects", bucket, keys)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteObjects indicates an expected call of DeleteObjects
+func (mr *MockbucketSyncerMockRecorder) DeleteObjects(bucket, keys interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteObjects", reflect.TypeOf((*MockbucketSyncer)(nil).DeleteObjects), bucket, keys)
+}
+
+// MockcacheInvalidator is a mock of cacheInvalidator interface
+type MockcacheInvalidator struct {
+	ctrl     *gomock.Controller
+	recorder *MockcacheInvalidatorMockRecorder
+}
+
+// MockcacheInvalidatorMockRecorder is the mock recorder for MockcacheInvalidator
+type MockcacheInvalidatorMockRecorder struct {
+	mock *MockcacheInvalidator
+}
+
+// NewMockcacheInvalidator creates a new mock instance
+func NewMockcacheInvalidator(ctrl *gomock.Controller) *MockcacheInvalidator {
+	mock := &MockcacheInvalidator{ctrl: ctrl}
+	mock.recorder = &MockcacheInvalidatorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockcacheInvalidator) EXPECT() *MockcacheInvalidatorMockRecorder {
+	return m.recorder
+}
+
+// CreateInvalidation mocks base method
+func (m *MockcacheInvalidator) CreateInvalidation(distributionID string, paths []string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateInvalidation", distributionID, paths)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateInvalidation indicates an expected call of CreateInvalidation
+func (mr *MockcacheInvalidatorMockRecorder) CreateInvalidation(distributionID, paths interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateInvalidation", reflect.TypeOf((*MockcacheInvalidator)(nil).CreateInvalidation), distributionID, paths)
+}
+
+// MockvpcSubnetLister is a mock of vpcSubnetLister interface
+type MockvpcSubnetLister struct {
+	ctrl     *gomock.Controller
+	recorder *MockvpcSubnetListerMockRecorder
+}
+
+// MockvpcSubnetListerMockRecorder is the mock recorder for MockvpcSubnetLister
+type MockvpcSubnetListerMockRecorder struct {
+	mock *MockvpcSubnetLister
+}
+
+// NewMockvpcSubnetLister creates a new mock instance
+func NewMockvpcSubnetLister(ctrl *gomock.Controller) *MockvpcSubnetLister {
+	mock := &MockvpcSubnetLister{ctrl: ctrl}
+	mock.recorder = &MockvpcSubnetListerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockvpcSubnetLister) EXPECT() *MockvpcSubnetListerMockRecorder {
+	return m.recorder
+}
+
+// ListVPCs mocks base method
+func (m *MockvpcSubnetLister) ListVPCs() ([]ec2.VPC, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListVPCs")
+	ret0, _ := ret[0].([]ec2.VPC)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListVPCs indicates an expected call of ListVPCs
+func (mr *MockvpcSubnetListerMockRecorder) ListVPCs() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListVPCs", reflect.TypeOf((*MockvpcSubnetLister)(nil).ListVPCs))
+}
+
+// ListSubnets mocks base method
+func (m *MockvpcSubnetLister) ListSubnets(vpcID string) ([]ec2.Subnet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSubnets", vpcID)
+	ret0, _ := ret[0].([]ec2.Subnet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSubnets indicates an expected call of ListSubnets
+func (mr *MockvpcSubnetListerMockRecorder) ListSubnets(vpcID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSubnets", reflect.TypeOf((*MockvpcSubnetLister)(nil).ListSubnets), vpcID)
+}
+
 // MockenvironmentDeployer is a mock of environmentDeployer interface
 type MockenvironmentDeployer struct {
 	ctrl     *gomock.Controller
@@ -3076,6 +3464,218 @@ func (mr *MockserviceArnGetterMockRecorder) GetServiceArn() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetServiceArn", reflect.TypeOf((*MockserviceArnGetter)(nil).GetServiceArn))
 }
 
+// MockserviceDeploymentDescriber is a mock of serviceDeploymentDescriber interface
+type MockserviceDeploymentDescriber struct {
+	ctrl     *gomock.Controller
+	recorder *MockserviceDeploymentDescriberMockRecorder
+}
+
+// MockserviceDeploymentDescriberMockRecorder is the mock recorder for MockserviceDeploymentDescriber
+type MockserviceDeploymentDescriberMockRecorder struct {
+	mock *MockserviceDeploymentDescriber
+}
+
+// NewMockserviceDeploymentDescriber creates a new mock instance
+func NewMockserviceDeploymentDescriber(ctrl *gomock.Controller) *MockserviceDeploymentDescriber {
+	mock := &MockserviceDeploymentDescriber{ctrl: ctrl}
+	mock.recorder = &MockserviceDeploymentDescriberMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockserviceDeploymentDescriber) EXPECT() *MockserviceDeploymentDescriberMockRecorder {
+	return m.recorder
+}
+
+// GetServiceArn mocks base method
+func (m *MockserviceDeploymentDescriber) GetServiceArn() (*ecs.ServiceArn, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetServiceArn")
+	ret0, _ := ret[0].(*ecs.ServiceArn)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetServiceArn indicates an expected call of GetServiceArn
+func (mr *MockserviceDeploymentDescriberMockRecorder) GetServiceArn() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetServiceArn", reflect.TypeOf((*MockserviceDeploymentDescriber)(nil).GetServiceArn))
+}
+
+// TaskDefinitionArn mocks base method
+func (m *MockserviceDeploymentDescriber) TaskDefinitionArn() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TaskDefinitionArn")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TaskDefinitionArn indicates an expected call of TaskDefinitionArn
+func (mr *MockserviceDeploymentDescriberMockRecorder) TaskDefinitionArn() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TaskDefinitionArn", reflect.TypeOf((*MockserviceDeploymentDescriber)(nil).TaskDefinitionArn))
+}
+
+// ServiceOutputs mocks base method
+func (m *MockserviceDeploymentDescriber) ServiceOutputs() (map[string]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ServiceOutputs")
+	ret0, _ := ret[0].(map[string]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ServiceOutputs indicates an expected call of ServiceOutputs
+func (mr *MockserviceDeploymentDescriberMockRecorder) ServiceOutputs() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ServiceOutputs", reflect.TypeOf((*MockserviceDeploymentDescriber)(nil).ServiceOutputs))
+}
+
+// MockecsDeploymentWaiter is a mock of ecsDeploymentWaiter interface
+type MockecsDeploymentWaiter struct {
+	ctrl     *gomock.Controller
+	recorder *MockecsDeploymentWaiterMockRecorder
+}
+
+// MockecsDeploymentWaiterMockRecorder is the mock recorder for MockecsDeploymentWaiter
+type MockecsDeploymentWaiterMockRecorder struct {
+	mock *MockecsDeploymentWaiter
+}
+
+// NewMockecsDeploymentWaiter creates a new mock instance
+func NewMockecsDeploymentWaiter(ctrl *gomock.Controller) *MockecsDeploymentWaiter {
+	mock := &MockecsDeploymentWaiter{ctrl: ctrl}
+	mock.recorder = &MockecsDeploymentWaiterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockecsDeploymentWaiter) EXPECT() *MockecsDeploymentWaiterMockRecorder {
+	return m.recorder
+}
+
+// Service mocks base method
+func (m *MockecsDeploymentWaiter) Service(clusterName, serviceName string) (*ecs.Service, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Service", clusterName, serviceName)
+	ret0, _ := ret[0].(*ecs.Service)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Service indicates an expected call of Service
+func (mr *MockecsDeploymentWaiterMockRecorder) Service(clusterName, serviceName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Service", reflect.TypeOf((*MockecsDeploymentWaiter)(nil).Service), clusterName, serviceName)
+}
+
+// WaitForSteadyDeployment mocks base method
+func (m *MockecsDeploymentWaiter) WaitForSteadyDeployment(clusterName, serviceName string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WaitForSteadyDeployment", clusterName, serviceName)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WaitForSteadyDeployment indicates an expected call of WaitForSteadyDeployment
+func (mr *MockecsDeploymentWaiterMockRecorder) WaitForSteadyDeployment(clusterName, serviceName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitForSteadyDeployment", reflect.TypeOf((*MockecsDeploymentWaiter)(nil).WaitForSteadyDeployment), clusterName, serviceName)
+}
+
+// MockstackDescriber is a mock of stackDescriber interface
+type MockstackDescriber struct {
+	ctrl     *gomock.Controller
+	recorder *MockstackDescriberMockRecorder
+}
+
+// MockstackDescriberMockRecorder is the mock recorder for MockstackDescriber
+type MockstackDescriberMockRecorder struct {
+	mock *MockstackDescriber
+}
+
+// NewMockstackDescriber creates a new mock instance
+func NewMockstackDescriber(ctrl *gomock.Controller) *MockstackDescriber {
+	mock := &MockstackDescriber{ctrl: ctrl}
+	mock.recorder = &MockstackDescriberMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockstackDescriber) EXPECT() *MockstackDescriberMockRecorder {
+	return m.recorder
+}
+
+// Describe mocks base method
+func (m *MockstackDescriber) Describe(stackName string) (*cloudformation.StackDescription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Describe", stackName)
+	ret0, _ := ret[0].(*cloudformation.StackDescription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Describe indicates an expected call of Describe
+func (mr *MockstackDescriberMockRecorder) Describe(stackName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Describe", reflect.TypeOf((*MockstackDescriber)(nil).Describe), stackName)
+}
+
+// MockecsBlueGreenDeployer is a mock of ecsBlueGreenDeployer interface
+type MockecsBlueGreenDeployer struct {
+	ctrl     *gomock.Controller
+	recorder *MockecsBlueGreenDeployerMockRecorder
+}
+
+// MockecsBlueGreenDeployerMockRecorder is the mock recorder for MockecsBlueGreenDeployer
+type MockecsBlueGreenDeployerMockRecorder struct {
+	mock *MockecsBlueGreenDeployer
+}
+
+// NewMockecsBlueGreenDeployer creates a new mock instance
+func NewMockecsBlueGreenDeployer(ctrl *gomock.Controller) *MockecsBlueGreenDeployer {
+	mock := &MockecsBlueGreenDeployer{ctrl: ctrl}
+	mock.recorder = &MockecsBlueGreenDeployerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockecsBlueGreenDeployer) EXPECT() *MockecsBlueGreenDeployerMockRecorder {
+	return m.recorder
+}
+
+// CreateECSDeployment mocks base method
+func (m *MockecsBlueGreenDeployer) CreateECSDeployment(in *codedeploy.ECSDeploymentInput) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateECSDeployment", in)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateECSDeployment indicates an expected call of CreateECSDeployment
+func (mr *MockecsBlueGreenDeployerMockRecorder) CreateECSDeployment(in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateECSDeployment", reflect.TypeOf((*MockecsBlueGreenDeployer)(nil).CreateECSDeployment), in)
+}
+
+// WaitForDeployment mocks base method
+func (m *MockecsBlueGreenDeployer) WaitForDeployment(id string) (*codedeploy.Deployment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WaitForDeployment", id)
+	ret0, _ := ret[0].(*codedeploy.Deployment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WaitForDeployment indicates an expected call of WaitForDeployment
+func (mr *MockecsBlueGreenDeployerMockRecorder) WaitForDeployment(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitForDeployment", reflect.TypeOf((*MockecsBlueGreenDeployer)(nil).WaitForDeployment), id)
+}
+
 // MockstatusDescriber is a mock of statusDescriber interface
 type MockstatusDescriber struct {
 	ctrl     *gomock.Controller
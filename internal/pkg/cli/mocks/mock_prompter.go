@@ -83,6 +83,21 @@ func (mr *MockprompterMockRecorder) SelectOne(message, help, options interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectOne", reflect.TypeOf((*Mockprompter)(nil).SelectOne), message, help, options)
 }
 
+// MultiSelect mocks base method
+func (m *Mockprompter) MultiSelect(message, help string, options []string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MultiSelect", message, help, options)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MultiSelect indicates an expected call of MultiSelect
+func (mr *MockprompterMockRecorder) MultiSelect(message, help, options interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MultiSelect", reflect.TypeOf((*Mockprompter)(nil).MultiSelect), message, help, options)
+}
+
 // Confirm mocks base method
 func (m *Mockprompter) Confirm(message, help string, options ...prompt.ConfirmOption) (bool, error) {
 	m.ctrl.T.Helper()
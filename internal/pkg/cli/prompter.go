@@ -9,5 +9,6 @@ type prompter interface {
 	Get(message, help string, validator prompt.ValidatorFunc, opts ...prompt.GetOption) (string, error)
 	GetSecret(message, help string) (string, error)
 	SelectOne(message, help string, options []string) (string, error)
+	MultiSelect(message, help string, options []string) ([]string, error)
 	Confirm(message, help string, options ...prompt.ConfirmOption) (bool, error)
 }
@@ -5,14 +5,35 @@ package cli
 
 import (
 	"errors"
+	"fmt"
+	"path/filepath"
 
 	"github.com/aws/amazon-ecs-cli-v2/cmd/copilot/template"
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/cli/group"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/manifest"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/workspace"
 	"github.com/spf13/cobra"
 )
 
 var errNoAppInWorkspace = errors.New("could not find an application attached to this workspace, please run `app init` first")
 
+// unmarshalSvcManifest deserializes the manifest of a service read from the workspace.
+// The errors locate the problems with the path of the manifest, such as "copilot/api/manifest.yml:6:3: ...".
+func unmarshalSvcManifest(name string, raw []byte) (interface{}, error) {
+	mft, err := manifest.UnmarshalService(raw)
+	if err == nil {
+		return mft, nil
+	}
+	path := filepath.Join(workspace.CopilotDirName, name, workspace.ManifestFileName)
+	var invalid *manifest.ErrInvalidManifest
+	if errors.As(err, &invalid) {
+		// The messages of the wrapping errors are already formatted, so the located errors are returned on their own.
+		invalid.Path = path
+		return nil, invalid
+	}
+	return nil, fmt.Errorf("%s: %w", path, err)
+}
+
 // BuildSvcCmd is the top level command for service.
 func BuildSvcCmd() *cobra.Command {
 	cmd := &cobra.Command{
@@ -26,6 +47,8 @@ Services are long-running Amazon ECS services.`,
 	cmd.AddCommand(BuildSvcInitCmd())
 	cmd.AddCommand(BuildSvcListCmd())
 	cmd.AddCommand(BuildSvcPackageCmd())
+	cmd.AddCommand(BuildSvcValidateCmd())
+	cmd.AddCommand(BuildSvcUpgradeManifestCmd())
 	cmd.AddCommand(BuildSvcDeployCmd())
 	cmd.AddCommand(BuildSvcDeleteCmd())
 	cmd.AddCommand(BuildSvcShowCmd())
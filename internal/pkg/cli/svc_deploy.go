@@ -4,13 +4,19 @@
 package cli
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
+	"path"
+	"path/filepath"
 	"strings"
 
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/addons"
 	awscloudformation "github.com/aws/amazon-ecs-cli-v2/internal/pkg/aws/cloudformation"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/aws/cloudfront"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/aws/codedeploy"
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/aws/ecr"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/aws/ecs"
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/aws/s3"
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/aws/session"
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/aws/tags"
@@ -20,12 +26,14 @@ import (
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/deploy/cloudformation/stack"
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/describe"
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/docker"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/envfile"
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/manifest"
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/term/color"
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/term/command"
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/term/log"
 	termprogress "github.com/aws/amazon-ecs-cli-v2/internal/pkg/term/progress"
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/workspace"
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/spf13/cobra"
 )
 
@@ -49,7 +57,7 @@ type deploySvcOpts struct {
 	deploySvcVars
 
 	store        store
-	ws           wsSvcReader
+	ws           wsSvcFileReader
 	ecr          ecrService
 	docker       dockerService
 	s3           artifactUploader
@@ -58,6 +66,12 @@ type deploySvcOpts struct {
 	appCFN       appResourcesGetter
 	svcCFN       cloudformation.CloudFormation
 	sessProvider sessionProvider
+	svcDescriber serviceDeploymentDescriber
+	svcStack     stackDescriber
+	ecs          ecsDeploymentWaiter
+	codeDeploy   ecsBlueGreenDeployer
+	siteBucket   bucketSyncer
+	cdn          cacheInvalidator
 
 	spinner progress
 	sel     wsSelector
@@ -66,6 +80,7 @@ type deploySvcOpts struct {
 	targetApp         *config.Application
 	targetEnvironment *config.Environment
 	targetSvc         *config.Service
+	targetSvcMft      interface{}
 }
 
 func newSvcDeployOpts(vars deploySvcVars) (*deploySvcOpts, error) {
@@ -144,21 +159,50 @@ func (o *deploySvcOpts) Execute() error {
 	}
 	o.targetSvc = svc
 
-	if err := o.configureClients(); err != nil {
+	mft, err := o.manifest()
+	if err != nil {
 		return err
 	}
+	o.targetSvcMft = mft
 
-	if err := o.pushToECRRepo(); err != nil {
+	if err := o.configureClients(); err != nil {
 		return err
 	}
 
+	if o.targetSvc.Type == manifest.StaticSiteType {
+		// A static site doesn't run any container, its files are uploaded to the bucket of its stack instead.
+		return o.deployStaticSite()
+	}
+
+	if location := o.imageLocation(); location == "" {
+		if err := o.pushToECRRepo(); err != nil {
+			return err
+		}
+	} else {
+		log.Infof("Using the pre-built image %s, skipping the build.\n", color.HighlightResource(location))
+	}
+
 	// TODO: delete addons template from S3 bucket when deleting the environment.
 	addonsURL, err := o.pushAddonsTemplateToS3Bucket()
 	if err != nil {
 		return err
 	}
 
-	if err := o.deploySvc(addonsURL); err != nil {
+	envFiles, err := o.pushEnvFilesToS3Bucket()
+	if err != nil {
+		return err
+	}
+
+	if err := o.deploySvc(addonsURL, envFiles); err != nil {
+		return err
+	}
+
+	if bgMft := o.blueGreenManifest(); bgMft != nil {
+		err = o.deployBlueGreen(bgMft)
+	} else {
+		err = o.waitForDeployment()
+	}
+	if err != nil {
 		return err
 	}
 
@@ -266,6 +310,22 @@ func (o *deploySvcOpts) configureClients() error {
 	// CF client against env account profile AND target environment region
 	o.svcCFN = cloudformation.New(envSession)
 
+	o.svcStack = awscloudformation.New(envSession)
+
+	o.ecs = ecs.New(envSession)
+
+	o.codeDeploy = codedeploy.New(envSession)
+
+	o.siteBucket = s3.New(envSession)
+
+	o.cdn = cloudfront.New(envSession)
+
+	svcDescriber, err := describe.NewServiceDescriber(o.AppName(), o.targetEnvironment.Name, o.Name)
+	if err != nil {
+		return fmt.Errorf("create describer for service %s: %w", o.Name, err)
+	}
+	o.svcDescriber = svcDescriber
+
 	addonsSvc, err := addons.New(o.Name)
 	if err != nil {
 		return fmt.Errorf("initiate addons service: %w", err)
@@ -289,13 +349,13 @@ func (o *deploySvcOpts) pushToECRRepo() error {
 		return fmt.Errorf("get ECR repository URI: %w", err)
 	}
 
-	path, err := o.getDockerfilePath()
+	buildArgs, err := o.buildArgs(uri)
 	if err != nil {
 		return err
 	}
 
-	if err := o.docker.Build(uri, o.ImageTag, path); err != nil {
-		return fmt.Errorf("build Dockerfile at %s with tag %s: %w", path, o.ImageTag, err)
+	if err := o.docker.Build(buildArgs); err != nil {
+		return fmt.Errorf("build Dockerfile at %s with tag %s: %w", buildArgs.Dockerfile, o.ImageTag, err)
 	}
 
 	auth, err := o.ecr.GetECRAuth()
@@ -309,26 +369,40 @@ func (o *deploySvcOpts) pushToECRRepo() error {
 	return o.docker.Push(uri, o.ImageTag)
 }
 
-func (o *deploySvcOpts) getDockerfilePath() (string, error) {
-	type dfPath interface {
-		DockerfilePath() string
+// imageLocation returns the location of the service's pre-built container image in the target environment.
+// If the image needs to be built from a Dockerfile, it returns the empty string.
+func (o *deploySvcOpts) imageLocation() string {
+	type imageLocator interface {
+		ImageLocation(envName string) string
 	}
 
-	manifestBytes, err := o.ws.ReadServiceManifest(o.Name)
-	if err != nil {
-		return "", fmt.Errorf("read manifest file %s: %w", o.Name, err)
+	l, ok := o.targetSvcMft.(imageLocator)
+	if !ok {
+		return ""
 	}
+	return l.ImageLocation(o.targetEnvironment.Name)
+}
 
-	svc, err := manifest.UnmarshalService(manifestBytes)
-	if err != nil {
-		return "", fmt.Errorf("unmarshal svc manifest: %w", err)
+// buildArgs returns the docker build arguments of the service's image in the target environment.
+func (o *deploySvcOpts) buildArgs(uri string) (*docker.BuildArguments, error) {
+	type dockerBuilder interface {
+		BuildArgs(envName string) manifest.DockerBuildArgs
 	}
 
-	mf, ok := svc.(dfPath)
+	mf, ok := o.targetSvcMft.(dockerBuilder)
 	if !ok {
-		return "", fmt.Errorf("service %s does not have a dockerfile path", o.Name)
-	}
-	return strings.TrimSuffix(mf.DockerfilePath(), "/Dockerfile"), nil
+		return nil, fmt.Errorf("service %s does not have a dockerfile path", o.Name)
+	}
+	args := mf.BuildArgs(o.targetEnvironment.Name)
+	return &docker.BuildArguments{
+		URI:        uri,
+		ImageTag:   o.ImageTag,
+		Dockerfile: aws.StringValue(args.Dockerfile),
+		Context:    aws.StringValue(args.Context),
+		Target:     aws.StringValue(args.Target),
+		CacheFrom:  args.CacheFrom,
+		Args:       args.Args,
+	}, nil
 }
 
 // pushAddonsTemplateToS3Bucket generates the addons template for the service and pushes it to S3.
@@ -357,19 +431,62 @@ func (o *deploySvcOpts) pushAddonsTemplateToS3Bucket() (string, error) {
 	return url, nil
 }
 
+// pushEnvFilesToS3Bucket validates the environment files of the service's containers in the target environment and pushes them to S3.
+// If none of the containers have an environment file, it returns nil and no errors.
+// Otherwise, it returns the S3 objects storing the environment files as "bucket/key", keyed by the container name.
+func (o *deploySvcOpts) pushEnvFilesToS3Bucket() (map[string]string, error) {
+	type envFilesGetter interface {
+		EnvFiles(envName string) map[string]string
+	}
+
+	mf, ok := o.targetSvcMft.(envFilesGetter)
+	if !ok {
+		return nil, nil
+	}
+	paths := mf.EnvFiles(o.targetEnvironment.Name)
+	if len(paths) == 0 {
+		return nil, nil
+	}
+	resources, err := o.appCFN.GetAppResourcesByRegion(o.targetApp, o.targetEnvironment.Region)
+	if err != nil {
+		return nil, fmt.Errorf("get app resources: %w", err)
+	}
+
+	objects := make(map[string]string, len(paths))
+	for container, filePath := range paths {
+		content, err := o.ws.ReadFile(filePath)
+		if err != nil {
+			return nil, fmt.Errorf("read environment file %s: %w", filePath, err)
+		}
+		if _, err := envfile.Parse(content); err != nil {
+			return nil, fmt.Errorf("parse environment file %s: %w", filePath, err)
+		}
+		url, err := o.s3.PutArtifact(resources.S3Bucket, fmt.Sprintf(config.EnvFileNameFormat, o.Name, container), bytes.NewReader(content))
+		if err != nil {
+			return nil, fmt.Errorf("put environment file %s to bucket %s: %w", filePath, resources.S3Bucket, err)
+		}
+		bucket, key, err := s3.ParseURL(url)
+		if err != nil {
+			return nil, err
+		}
+		objects[container] = path.Join(bucket, key)
+	}
+	return objects, nil
+}
+
 func (o *deploySvcOpts) manifest() (interface{}, error) {
 	raw, err := o.ws.ReadServiceManifest(o.Name)
 	if err != nil {
 		return nil, fmt.Errorf("read service %s manifest from workspace: %w", o.Name, err)
 	}
-	mft, err := manifest.UnmarshalService(raw)
+	mft, err := unmarshalSvcManifest(o.Name, raw)
 	if err != nil {
 		return nil, fmt.Errorf("unmarshal service %s manifest: %w", o.Name, err)
 	}
 	return mft, nil
 }
 
-func (o *deploySvcOpts) runtimeConfig(addonsURL string) (*stack.RuntimeConfig, error) {
+func (o *deploySvcOpts) runtimeConfig(addonsURL string, envFiles map[string]string) (*stack.RuntimeConfig, error) {
 	resources, err := o.appCFN.GetAppResourcesByRegion(o.targetApp, o.targetEnvironment.Region)
 	if err != nil {
 		return nil, fmt.Errorf("get application %s resources from region %s: %w", o.targetApp.Name, o.targetEnvironment.Region, err)
@@ -387,21 +504,28 @@ func (o *deploySvcOpts) runtimeConfig(addonsURL string) (*stack.RuntimeConfig, e
 		ImageTag:          o.ImageTag,
 		AddonsTemplateURL: addonsURL,
 		AdditionalTags:    tags.Merge(o.targetApp.Tags, o.ResourceTags),
+		EnvFiles:          envFiles,
 	}, nil
 }
 
-func (o *deploySvcOpts) stackConfiguration(addonsURL string) (cloudformation.StackConfiguration, error) {
-	mft, err := o.manifest()
-	if err != nil {
-		return nil, err
-	}
-	rc, err := o.runtimeConfig(addonsURL)
+func (o *deploySvcOpts) stackConfiguration(addonsURL string, envFiles map[string]string) (cloudformation.StackConfiguration, error) {
+	rc, err := o.runtimeConfig(addonsURL, envFiles)
 	if err != nil {
 		return nil, err
 	}
 	var conf cloudformation.StackConfiguration
-	switch t := mft.(type) {
+	switch t := o.targetSvcMft.(type) {
 	case *manifest.LoadBalancedWebService:
+		if t.Alias != nil {
+			if err := validateAlias(aws.StringValue(t.Alias), o.targetApp); err != nil {
+				return nil, err
+			}
+		}
+		if t.ApplyEnv(o.targetEnvironment.Name).Deployment.IsBlueGreen() {
+			if rc.DeployedTaskDefinition, err = o.deployedTaskDefinition(); err != nil {
+				return nil, err
+			}
+		}
 		if o.targetApp.RequiresDNSDelegation() {
 			conf, err = stack.NewHTTPSLoadBalancedWebService(t, o.targetEnvironment.Name, o.targetEnvironment.App, *rc)
 		} else {
@@ -409,6 +533,17 @@ func (o *deploySvcOpts) stackConfiguration(addonsURL string) (cloudformation.Sta
 		}
 	case *manifest.BackendService:
 		conf, err = stack.NewBackendService(t, o.targetEnvironment.Name, o.targetEnvironment.App, *rc)
+	case *manifest.WorkerService:
+		conf, err = stack.NewWorkerService(t, o.targetEnvironment.Name, o.targetEnvironment.App, *rc)
+	case *manifest.ScheduledJob:
+		conf, err = stack.NewScheduledJob(t, o.targetEnvironment.Name, o.targetEnvironment.App, *rc)
+	case *manifest.StaticSite:
+		if alias := t.ApplyEnv(o.targetEnvironment.Name).Alias; alias != nil {
+			if err := validateAlias(aws.StringValue(alias), o.targetApp); err != nil {
+				return nil, err
+			}
+		}
+		conf, err = stack.NewStaticSite(t, o.targetEnvironment.Name, o.targetEnvironment.App, *rc)
 	default:
 		return nil, fmt.Errorf("unknown manifest type %T while creating the CloudFormation stack", t)
 	}
@@ -418,9 +553,28 @@ func (o *deploySvcOpts) stackConfiguration(addonsURL string) (cloudformation.Sta
 	return conf, nil
 }
 
-func (o *deploySvcOpts) deploySvc(addonsURL string) error {
-	conf, err := o.stackConfiguration(addonsURL)
+// validateAlias returns an error if the alias can't be served from the hosted zone of the application.
+func validateAlias(alias string, app *config.Application) error {
+	if !app.RequiresDNSDelegation() {
+		return fmt.Errorf("alias %s requires the application %s to have a domain name", alias, app.Name)
+	}
+	appDomain := fmt.Sprintf("%s.%s", app.Name, app.Domain)
+	if alias != appDomain && !strings.HasSuffix(alias, "."+appDomain) {
+		return fmt.Errorf("alias %s must be %s or a subdomain of it", alias, appDomain)
+	}
+	return nil
+}
+
+func (o *deploySvcOpts) deploySvc(addonsURL string, envFiles map[string]string) error {
+	conf, err := o.stackConfiguration(addonsURL, envFiles)
+	if err != nil {
+		return err
+	}
+	tpl, err := conf.Template()
 	if err != nil {
+		return fmt.Errorf("generate template of service %s: %w", o.Name, err)
+	}
+	if err := o.checkEnvExports(tpl); err != nil {
 		return err
 	}
 	o.spinner.Start(
@@ -436,7 +590,256 @@ func (o *deploySvcOpts) deploySvc(addonsURL string) error {
 	return nil
 }
 
+// checkEnvExports returns an error if the environment stack doesn't export all the values that the service template imports.
+// Environments created by older versions of copilot don't export the values that newer services rely on,
+// and CloudFormation would otherwise fail the deployment with an error that doesn't mention the environment.
+func (o *deploySvcOpts) checkEnvExports(tpl string) error {
+	envStackName := stack.NameForEnv(o.AppName(), o.targetEnvironment.Name)
+	envStack, err := o.svcStack.Describe(envStackName)
+	if err != nil {
+		return fmt.Errorf("describe stack of environment %s: %w", o.targetEnvironment.Name, err)
+	}
+	exports := make(map[string]bool)
+	for _, output := range envStack.Outputs {
+		exports[aws.StringValue(output.ExportName)] = true
+	}
+	var missing []string
+	for _, name := range stack.ImportedEnvOutputs(tpl) {
+		if !exports[fmt.Sprintf("%s-%s", envStackName, name)] {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	return fmt.Errorf(`environment %s doesn't export %s required by service %s: it was created by an older version of copilot, recreate it with "copilot env delete --name %s" and "copilot env init --name %s"`,
+		o.targetEnvironment.Name, strings.Join(missing, ", "), o.Name, o.targetEnvironment.Name, o.targetEnvironment.Name)
+}
+
+// deployStaticSite deploys the stack of the static site, uploads the files of the site to the bucket of the stack,
+// and invalidates the caches of its distribution so that the new files are served right away.
+func (o *deploySvcOpts) deployStaticSite() error {
+	site, ok := o.targetSvcMft.(*manifest.StaticSite)
+	if !ok {
+		return fmt.Errorf("service %s is not a static site", o.Name)
+	}
+	site = site.ApplyEnv(o.targetEnvironment.Name)
+	files, err := o.ws.ListFiles(site.Path)
+	if err != nil {
+		return fmt.Errorf("list files of static site %s in %s: %w", o.Name, site.Path, err)
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("no files found in %s for static site %s", site.Path, o.Name)
+	}
+
+	if err := o.deploySvc("", nil); err != nil {
+		return err
+	}
+	outputs, err := o.svcDescriber.ServiceOutputs()
+	if err != nil {
+		return fmt.Errorf("get outputs of service %s: %w", o.Name, err)
+	}
+	if err := o.syncStaticSite(site.Path, files, outputs[stack.StaticSiteOutputBucketName]); err != nil {
+		return err
+	}
+	id, err := o.cdn.CreateInvalidation(outputs[stack.StaticSiteOutputDistributionID], []string{"/*"})
+	if err != nil {
+		return fmt.Errorf("invalidate the cache of static site %s: %w", o.Name, err)
+	}
+	log.Infof("Invalidating the cached files of %s with invalidation %s.\n", color.HighlightUserInput(o.Name), color.HighlightResource(id))
+
+	domain := outputs[stack.StaticSiteOutputDistributionDomainName]
+	if site.Alias != nil {
+		domain = *site.Alias
+	}
+	log.Successf("Deployed %s, you can access it at %s.\n", color.HighlightUserInput(o.Name), color.HighlightResource("https://"+domain))
+	return nil
+}
+
+// syncStaticSite uploads the files under the directory to the bucket, and then deletes the objects of files that don't exist anymore.
+func (o *deploySvcOpts) syncStaticSite(dir string, files []string, bucket string) error {
+	o.spinner.Start(fmt.Sprintf("Uploading %d files of %s to bucket %s.", len(files), color.HighlightUserInput(o.Name), color.HighlightResource(bucket)))
+	uploaded := make(map[string]bool, len(files))
+	for _, file := range files {
+		content, err := o.ws.ReadFile(filepath.Join(dir, filepath.FromSlash(file)))
+		if err != nil {
+			o.spinner.Stop("Error!")
+			return fmt.Errorf("read file %s of static site %s: %w", file, o.Name, err)
+		}
+		if err := o.siteBucket.PutObject(bucket, file, bytes.NewReader(content)); err != nil {
+			o.spinner.Stop("Error!")
+			return fmt.Errorf("upload file %s of static site %s: %w", file, o.Name, err)
+		}
+		uploaded[file] = true
+	}
+	keys, err := o.siteBucket.ListObjectKeys(bucket)
+	if err != nil {
+		o.spinner.Stop("Error!")
+		return fmt.Errorf("list files of static site %s: %w", o.Name, err)
+	}
+	var stale []string
+	for _, key := range keys {
+		if !uploaded[key] {
+			stale = append(stale, key)
+		}
+	}
+	if len(stale) > 0 {
+		if err := o.siteBucket.DeleteObjects(bucket, stale); err != nil {
+			o.spinner.Stop("Error!")
+			return fmt.Errorf("delete removed files of static site %s: %w", o.Name, err)
+		}
+	}
+	o.spinner.Stop("")
+	return nil
+}
+
+// waitForDeployment blocks until the ECS deployment of the service completes, and returns an error
+// if the deployment circuit breaker rolled the service back to the task definition of a previous deployment.
+func (o *deploySvcOpts) waitForDeployment() error {
+	if o.targetSvc.Type == manifest.ScheduledJobType {
+		// Jobs run their tasks on a schedule instead of as an ECS service.
+		return nil
+	}
+	taskDef, err := o.svcDescriber.TaskDefinitionArn()
+	if err != nil {
+		return fmt.Errorf("get task definition of service %s: %w", o.Name, err)
+	}
+	clusterName, serviceName, err := o.ecsService()
+	if err != nil {
+		return err
+	}
+
+	o.spinner.Start(fmt.Sprintf("Waiting for the deployment of %s to complete.", color.HighlightUserInput(o.Name)))
+	deployedTaskDef, err := o.ecs.WaitForSteadyDeployment(clusterName, serviceName)
+	if err != nil {
+		o.spinner.Stop("Error!")
+		return fmt.Errorf("wait for the ECS deployment of service %s: %w", o.Name, err)
+	}
+	if deployedTaskDef != taskDef {
+		o.spinner.Stop("Error!")
+		return &errDeploymentRolledBack{
+			svcName:         o.Name,
+			envName:         o.targetEnvironment.Name,
+			rolledBackToDef: deployedTaskDef,
+		}
+	}
+	o.spinner.Stop("")
+	return nil
+}
+
+// blueGreenManifest returns the manifest of the service with the overrides of the target environment
+// if the service is deployed by CodeDeploy, and nil otherwise.
+func (o *deploySvcOpts) blueGreenManifest() *manifest.LoadBalancedWebService {
+	lbMft, ok := o.targetSvcMft.(*manifest.LoadBalancedWebService)
+	if !ok {
+		return nil
+	}
+	envMft := lbMft.ApplyEnv(o.targetEnvironment.Name)
+	if !envMft.Deployment.IsBlueGreen() {
+		return nil
+	}
+	return envMft
+}
+
+// deployedTaskDefinition returns the task definition that the ECS service of a blue/green service keeps
+// while its stack is updated, since CloudFormation can't replace the task definition of a service deployed by CodeDeploy.
+// It returns the empty string if the stack doesn't exist yet.
+func (o *deploySvcOpts) deployedTaskDefinition() (string, error) {
+	if _, err := o.svcStack.Describe(stack.NameForService(o.AppName(), o.targetEnvironment.Name, o.Name)); err != nil {
+		var errNotFound *awscloudformation.ErrStackNotFound
+		if errors.As(err, &errNotFound) {
+			return "", nil
+		}
+		return "", fmt.Errorf("describe stack of service %s: %w", o.Name, err)
+	}
+	return o.serviceTaskDefinition()
+}
+
+// serviceTaskDefinition returns the task definition that the ECS service currently runs.
+// CodeDeploy deployments change it without updating the service stack.
+func (o *deploySvcOpts) serviceTaskDefinition() (string, error) {
+	clusterName, serviceName, err := o.ecsService()
+	if err != nil {
+		return "", err
+	}
+	svc, err := o.ecs.Service(clusterName, serviceName)
+	if err != nil {
+		return "", fmt.Errorf("get ECS service of service %s: %w", o.Name, err)
+	}
+	return aws.StringValue(svc.TaskDefinition), nil
+}
+
+// ecsService returns the names of the cluster and the ECS service of the service.
+func (o *deploySvcOpts) ecsService() (clusterName, serviceName string, err error) {
+	svcArn, err := o.svcDescriber.GetServiceArn()
+	if err != nil {
+		return "", "", fmt.Errorf("get ECS service of service %s: %w", o.Name, err)
+	}
+	clusterName, err = svcArn.ClusterName()
+	if err != nil {
+		return "", "", fmt.Errorf("get cluster name: %w", err)
+	}
+	serviceName, err = svcArn.ServiceName()
+	if err != nil {
+		return "", "", fmt.Errorf("get ECS service name: %w", err)
+	}
+	return clusterName, serviceName, nil
+}
+
+// deployBlueGreen shifts the traffic of the service to the task definition registered by the stack with a CodeDeploy deployment,
+// and returns an error if the deployment didn't succeed.
+func (o *deploySvcOpts) deployBlueGreen(mft *manifest.LoadBalancedWebService) error {
+	taskDef, err := o.svcDescriber.TaskDefinitionArn()
+	if err != nil {
+		return fmt.Errorf("get task definition of service %s: %w", o.Name, err)
+	}
+	deployedTaskDef, err := o.serviceTaskDefinition()
+	if err != nil {
+		return err
+	}
+	if deployedTaskDef == taskDef {
+		// The ECS service was just created with the task definition, or the task definition didn't change.
+		return nil
+	}
+
+	name := fmt.Sprintf("%s-%s-%s", o.AppName(), o.targetEnvironment.Name, o.Name)
+	id, err := o.codeDeploy.CreateECSDeployment(&codedeploy.ECSDeploymentInput{
+		ApplicationName:     name,
+		DeploymentGroupName: name,
+		TaskDefinition:      taskDef,
+		ContainerName:       o.Name,
+		ContainerPort:       mft.Image.Port,
+	})
+	if err != nil {
+		return fmt.Errorf("start the blue/green deployment of service %s: %w", o.Name, err)
+	}
+	o.spinner.Start(fmt.Sprintf("Shifting the traffic of %s to the new tasks with CodeDeploy deployment %s.",
+		color.HighlightUserInput(o.Name), color.HighlightResource(id)))
+	deployment, err := o.codeDeploy.WaitForDeployment(id)
+	if err != nil {
+		o.spinner.Stop("Error!")
+		return fmt.Errorf("wait for the blue/green deployment of service %s: %w", o.Name, err)
+	}
+	if !deployment.Succeeded() {
+		o.spinner.Stop("Error!")
+		return &errBlueGreenDeploymentFailed{
+			svcName:    o.Name,
+			envName:    o.targetEnvironment.Name,
+			deployment: deployment,
+		}
+	}
+	o.spinner.Stop("")
+	return nil
+}
+
 func (o *deploySvcOpts) showAppURI() error {
+	switch o.targetSvc.Type {
+	case manifest.WorkerServiceType, manifest.ScheduledJobType:
+		// Workers and jobs aren't reachable through an endpoint.
+		log.Successf("Deployed %s.\n", color.HighlightUserInput(o.Name))
+		return nil
+	}
+
 	type identifier interface {
 		URI(string) (string, error)
 	}
@@ -468,6 +871,35 @@ func (o *deploySvcOpts) showAppURI() error {
 	return nil
 }
 
+type errDeploymentRolledBack struct {
+	svcName         string
+	envName         string
+	rolledBackToDef string
+}
+
+func (e *errDeploymentRolledBack) Error() string {
+	return fmt.Sprintf("the deployment of service %s to environment %s failed and was rolled back to task definition %s",
+		e.svcName, e.envName, e.rolledBackToDef)
+}
+
+type errBlueGreenDeploymentFailed struct {
+	svcName    string
+	envName    string
+	deployment *codedeploy.Deployment
+}
+
+func (e *errBlueGreenDeploymentFailed) Error() string {
+	msg := fmt.Sprintf("the blue/green deployment %s of service %s to environment %s %s",
+		e.deployment.ID, e.svcName, e.envName, strings.ToLower(e.deployment.Status))
+	if e.deployment.RolledBack() {
+		msg += fmt.Sprintf(" and was rolled back by deployment %s", e.deployment.RollbackDeploymentID)
+	}
+	if e.deployment.ErrorMessage != "" {
+		msg += ": " + e.deployment.ErrorMessage
+	}
+	return msg
+}
+
 // BuildSvcDeployCmd builds the `svc deploy` subcommand.
 func BuildSvcDeployCmd() *cobra.Command {
 	vars := deploySvcVars{
@@ -4,13 +4,22 @@
 package cli
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
+	"path/filepath"
 	"testing"
 
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/addons"
+	awscloudformation "github.com/aws/amazon-ecs-cli-v2/internal/pkg/aws/cloudformation"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/aws/codedeploy"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/aws/ecs"
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/config"
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/deploy/cloudformation/stack"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/docker"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/manifest"
+	"github.com/aws/aws-sdk-go/aws"
+	sdkcloudformation "github.com/aws/aws-sdk-go/service/cloudformation"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
 
@@ -23,13 +32,13 @@ func TestSvcDeployOpts_Validate(t *testing.T) {
 		inEnvName string
 		inSvcName string
 
-		mockWs    func(m *mocks.MockwsSvcReader)
+		mockWs    func(m *mocks.MockwsSvcFileReader)
 		mockStore func(m *mocks.Mockstore)
 
 		wantedError error
 	}{
 		"no existing applications": {
-			mockWs:    func(m *mocks.MockwsSvcReader) {},
+			mockWs:    func(m *mocks.MockwsSvcFileReader) {},
 			mockStore: func(m *mocks.Mockstore) {},
 
 			wantedError: errNoAppInWorkspace,
@@ -37,7 +46,7 @@ func TestSvcDeployOpts_Validate(t *testing.T) {
 		"with workspace error": {
 			inAppName: "phonetool",
 			inSvcName: "frontend",
-			mockWs: func(m *mocks.MockwsSvcReader) {
+			mockWs: func(m *mocks.MockwsSvcFileReader) {
 				m.EXPECT().ServiceNames().Return(nil, errors.New("some error"))
 			},
 			mockStore: func(m *mocks.Mockstore) {},
@@ -47,7 +56,7 @@ func TestSvcDeployOpts_Validate(t *testing.T) {
 		"with service not in workspace": {
 			inAppName: "phonetool",
 			inSvcName: "frontend",
-			mockWs: func(m *mocks.MockwsSvcReader) {
+			mockWs: func(m *mocks.MockwsSvcFileReader) {
 				m.EXPECT().ServiceNames().Return([]string{}, nil)
 			},
 			mockStore: func(m *mocks.Mockstore) {},
@@ -57,7 +66,7 @@ func TestSvcDeployOpts_Validate(t *testing.T) {
 		"with unknown environment": {
 			inAppName: "phonetool",
 			inEnvName: "test",
-			mockWs:    func(m *mocks.MockwsSvcReader) {},
+			mockWs:    func(m *mocks.MockwsSvcFileReader) {},
 			mockStore: func(m *mocks.Mockstore) {
 				m.EXPECT().GetEnvironment("phonetool", "test").
 					Return(nil, errors.New("unknown env"))
@@ -69,7 +78,7 @@ func TestSvcDeployOpts_Validate(t *testing.T) {
 			inAppName: "phonetool",
 			inSvcName: "frontend",
 			inEnvName: "test",
-			mockWs: func(m *mocks.MockwsSvcReader) {
+			mockWs: func(m *mocks.MockwsSvcFileReader) {
 				m.EXPECT().ServiceNames().Return([]string{"frontend"}, nil)
 			},
 			mockStore: func(m *mocks.Mockstore) {
@@ -84,7 +93,7 @@ func TestSvcDeployOpts_Validate(t *testing.T) {
 			// GIVEN
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
-			mockWs := mocks.NewMockwsSvcReader(ctrl)
+			mockWs := mocks.NewMockwsSvcFileReader(ctrl)
 			mockStore := mocks.NewMockstore(ctrl)
 			tc.mockWs(mockWs)
 			tc.mockStore(mockStore)
@@ -191,65 +200,227 @@ func TestSvcDeployOpts_Ask(t *testing.T) {
 	}
 }
 
-func TestSvcDeployOpts_getDockerfilePath(t *testing.T) {
-	var mockWorkspace *mocks.MockwsSvcReader
-
+func TestSvcDeployOpts_manifest(t *testing.T) {
 	mockError := errors.New("mockError")
-	mockManifest := []byte(`name: serviceA
-type: 'Load Balanced Web Service'
-image:
-  build: serviceA/Dockerfile
-`)
 
-	tests := map[string]struct {
-		inputSvc   string
-		setupMocks func(controller *gomock.Controller)
+	testCases := map[string]struct {
+		inManifest string
+		mockWsErr  error
 
-		wantPath string
-		wantErr  error
+		wantedErr error
 	}{
 		"should return error if ws ReadFile returns error": {
-			inputSvc: "serviceA",
-			setupMocks: func(controller *gomock.Controller) {
-				mockWorkspace = mocks.NewMockwsSvcReader(controller)
+			mockWsErr: mockError,
+			wantedErr: fmt.Errorf("read service %s manifest from workspace: %w", "serviceA", mockError),
+		},
+		"should locate the problems of an invalid manifest with its path": {
+			inManifest: `name: serviceA
+type: 'Backend Service'
+image:
+  build: serviceA/Dockerfile
+varibles:
+  LOG_LEVEL: "WARN"
+`,
+			wantedErr: errors.New(`unmarshal service serviceA manifest: copilot/serviceA/manifest.yml:5:1: unknown field "varibles", did you mean "variables"?`),
+		},
+		"should unmarshal the manifest": {
+			inManifest: `name: serviceA
+type: 'Backend Service'
+image:
+  build: serviceA/Dockerfile
+`,
+		},
+	}
 
-				gomock.InOrder(
-					mockWorkspace.EXPECT().ReadServiceManifest("serviceA").Times(1).Return(nil, mockError),
-				)
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockWorkspace := mocks.NewMockwsSvcFileReader(ctrl)
+			mockWorkspace.EXPECT().ReadServiceManifest("serviceA").Return([]byte(tc.inManifest), tc.mockWsErr)
+			opts := deploySvcOpts{
+				deploySvcVars: deploySvcVars{
+					Name: "serviceA",
+				},
+				ws: mockWorkspace,
+			}
+
+			// WHEN
+			mft, err := opts.manifest()
+
+			// THEN
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+				return
+			}
+			require.NoError(t, err)
+			require.IsType(t, &manifest.BackendService{}, mft)
+		})
+	}
+}
+
+func TestSvcDeployOpts_buildArgs(t *testing.T) {
+	testCases := map[string]struct {
+		inManifest string
+		inEnvName  string
+
+		wantedArgs *docker.BuildArguments
+		wantedErr  error
+	}{
+		"should use the Dockerfile's directory as the build context for the string form": {
+			inManifest: `name: serviceA
+type: 'Load Balanced Web Service'
+image:
+  build: serviceA/Dockerfile
+`,
+			inEnvName: "test",
+			wantedArgs: &docker.BuildArguments{
+				URI:        "mockURI",
+				ImageTag:   "mockTag",
+				Dockerfile: "serviceA/Dockerfile",
+				Context:    "serviceA",
 			},
-			wantPath: "",
-			wantErr:  fmt.Errorf("read manifest file %s: %w", "serviceA", mockError),
 		},
-		"should trim the manifest DockerfilePath if it contains /Dockerfile": {
-			inputSvc: "serviceA",
-			setupMocks: func(controller *gomock.Controller) {
-				mockWorkspace = mocks.NewMockwsSvcReader(controller)
-
-				gomock.InOrder(
-					mockWorkspace.EXPECT().ReadServiceManifest("serviceA").Times(1).Return(mockManifest, nil),
-				)
+		"should pass through all the build options for the map form": {
+			inManifest: `name: serviceA
+type: 'Backend Service'
+image:
+  build:
+    dockerfile: serviceA/Dockerfile.prod
+    context: .
+    target: runtime
+    args:
+      GO_VERSION: "1.14"
+    cache_from:
+      - serviceA:latest
+  port: 80
+`,
+			inEnvName: "test",
+			wantedArgs: &docker.BuildArguments{
+				URI:        "mockURI",
+				ImageTag:   "mockTag",
+				Dockerfile: "serviceA/Dockerfile.prod",
+				Context:    ".",
+				Target:     "runtime",
+				Args: map[string]string{
+					"GO_VERSION": "1.14",
+				},
+				CacheFrom: []string{"serviceA:latest"},
+			},
+		},
+		"should apply the environment's build options": {
+			inManifest: `name: serviceA
+type: 'Worker Service'
+image:
+  build: serviceA/Dockerfile
+environments:
+  prod:
+    image:
+      build:
+        context: serviceA
+        target: prod
+`,
+			inEnvName: "prod",
+			wantedArgs: &docker.BuildArguments{
+				URI:        "mockURI",
+				ImageTag:   "mockTag",
+				Dockerfile: "serviceA/Dockerfile",
+				Context:    "serviceA",
+				Target:     "prod",
 			},
-			wantPath: "serviceA",
-			wantErr:  nil,
 		},
 	}
 
-	for name, test := range tests {
+	for name, tc := range testCases {
 		t.Run(name, func(t *testing.T) {
+			// GIVEN
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
-			test.setupMocks(ctrl)
+			mft, err := unmarshalSvcManifest("serviceA", []byte(tc.inManifest))
+			require.NoError(t, err)
 			opts := deploySvcOpts{
 				deploySvcVars: deploySvcVars{
-					Name: test.inputSvc,
+					Name:     "serviceA",
+					ImageTag: "mockTag",
 				},
-				ws: mockWorkspace,
+				targetEnvironment: &config.Environment{Name: tc.inEnvName},
+				targetSvcMft:      mft,
 			}
 
-			gotPath, gotErr := opts.getDockerfilePath()
+			// WHEN
+			args, err := opts.buildArgs("mockURI")
 
-			require.Equal(t, test.wantPath, gotPath)
-			require.Equal(t, test.wantErr, gotErr)
+			// THEN
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.wantedArgs, args)
+		})
+	}
+}
+
+func TestSvcDeployOpts_imageLocation(t *testing.T) {
+	testCases := map[string]struct {
+		inManifest string
+		inEnvName  string
+
+		wantedLocation string
+	}{
+		"returns empty location if the image is built": {
+			inManifest: `name: serviceA
+type: 'Load Balanced Web Service'
+image:
+  build: serviceA/Dockerfile
+`,
+			inEnvName:      "test",
+			wantedLocation: "",
+		},
+		"returns the pre-built image location": {
+			inManifest: `name: serviceA
+type: 'Backend Service'
+image:
+  location: public.ecr.aws/nginx/nginx:latest
+  port: 80
+`,
+			inEnvName:      "test",
+			wantedLocation: "public.ecr.aws/nginx/nginx:latest",
+		},
+		"returns the environment's pre-built image location": {
+			inManifest: `name: serviceA
+type: 'Load Balanced Web Service'
+image:
+  build: serviceA/Dockerfile
+environments:
+  prod:
+    image:
+      location: 123456789012.dkr.ecr.us-west-2.amazonaws.com/serviceA:v1.0.0
+`,
+			inEnvName:      "prod",
+			wantedLocation: "123456789012.dkr.ecr.us-west-2.amazonaws.com/serviceA:v1.0.0",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			mft, err := unmarshalSvcManifest("serviceA", []byte(tc.inManifest))
+			require.NoError(t, err)
+			opts := deploySvcOpts{
+				deploySvcVars: deploySvcVars{
+					Name: "serviceA",
+				},
+				targetEnvironment: &config.Environment{Name: tc.inEnvName},
+				targetSvcMft:      mft,
+			}
+
+			// WHEN
+			location := opts.imageLocation()
+
+			// THEN
+			require.Equal(t, tc.wantedLocation, location)
 		})
 	}
 }
@@ -406,3 +577,687 @@ func TestSvcDeployOpts_pushAddonsTemplateToS3Bucket(t *testing.T) {
 		})
 	}
 }
+
+func TestSvcDeployOpts_pushEnvFilesToS3Bucket(t *testing.T) {
+	const mftWithEnvFiles = `name: api
+type: Backend Service
+image:
+  build: api/Dockerfile
+  port: 8080
+env_file: api/.env
+sidecars:
+  nginx:
+    image: nginx
+environments:
+  test:
+    sidecars:
+      nginx:
+        env_file: nginx/test.env
+`
+	mockError := errors.New("some error")
+	tests := map[string]struct {
+		inManifest string
+
+		mockWs                 func(m *mocks.MockwsSvcFileReader)
+		mockAppResourcesGetter func(m *mocks.MockappResourcesGetter)
+		mockS3Svc              func(m *mocks.MockartifactUploader)
+
+		wantedObjects map[string]string
+		wantedErr     error
+	}{
+		"should return nil if no container has an environment file": {
+			inManifest: `name: api
+type: Backend Service
+image:
+  build: api/Dockerfile
+  port: 8080
+`,
+			mockWs: func(m *mocks.MockwsSvcFileReader) {},
+			mockAppResourcesGetter: func(m *mocks.MockappResourcesGetter) {
+				m.EXPECT().GetAppResourcesByRegion(gomock.Any(), gomock.Any()).Times(0)
+			},
+			mockS3Svc: func(m *mocks.MockartifactUploader) {},
+		},
+		"should push the environment files of the containers to S3 bucket": {
+			inManifest: mftWithEnvFiles,
+			mockWs: func(m *mocks.MockwsSvcFileReader) {
+				m.EXPECT().ReadFile("api/.env").Return([]byte("LOG_LEVEL=info"), nil)
+				m.EXPECT().ReadFile("nginx/test.env").Return([]byte("# Sidecar\nWORKERS=2"), nil)
+			},
+			mockAppResourcesGetter: func(m *mocks.MockappResourcesGetter) {
+				m.EXPECT().GetAppResourcesByRegion(&config.Application{
+					Name: "mockApp",
+				}, "us-west-2").Return(&stack.AppRegionalResources{
+					S3Bucket: "mockBucket",
+				}, nil)
+			},
+			mockS3Svc: func(m *mocks.MockartifactUploader) {
+				m.EXPECT().PutArtifact("mockBucket", "api.api.env", gomock.Any()).Return("https://mockBucket.s3.us-west-2.amazonaws.com/manual/1600000000/api.api.env", nil)
+				m.EXPECT().PutArtifact("mockBucket", "api.nginx.env", gomock.Any()).Return("https://mockBucket.s3.us-west-2.amazonaws.com/manual/1600000000/api.nginx.env", nil)
+			},
+			wantedObjects: map[string]string{
+				"api":   "mockBucket/manual/1600000000/api.api.env",
+				"nginx": "mockBucket/manual/1600000000/api.nginx.env",
+			},
+		},
+		"should return error if an environment file is invalid": {
+			inManifest: mftWithEnvFiles,
+			mockWs: func(m *mocks.MockwsSvcFileReader) {
+				m.EXPECT().ReadFile("api/.env").Return([]byte("LOG_LEVEL=info"), nil).AnyTimes()
+				m.EXPECT().ReadFile("nginx/test.env").Return([]byte("WORKERS"), nil).AnyTimes()
+			},
+			mockAppResourcesGetter: func(m *mocks.MockappResourcesGetter) {
+				m.EXPECT().GetAppResourcesByRegion(gomock.Any(), gomock.Any()).Return(&stack.AppRegionalResources{
+					S3Bucket: "mockBucket",
+				}, nil)
+			},
+			mockS3Svc: func(m *mocks.MockartifactUploader) {
+				m.EXPECT().PutArtifact("mockBucket", "api.api.env", gomock.Any()).Return("https://mockBucket.s3.us-west-2.amazonaws.com/manual/1600000000/api.api.env", nil).AnyTimes()
+			},
+			wantedErr: errors.New("parse environment file nginx/test.env: line 1: expected VARIABLE=VALUE"),
+		},
+		"should return error if fail to upload to S3 bucket": {
+			inManifest: mftWithEnvFiles,
+			mockWs: func(m *mocks.MockwsSvcFileReader) {
+				m.EXPECT().ReadFile("api/.env").Return([]byte("LOG_LEVEL=info"), nil).AnyTimes()
+				m.EXPECT().ReadFile("nginx/test.env").Return([]byte("WORKERS=2"), nil).AnyTimes()
+			},
+			mockAppResourcesGetter: func(m *mocks.MockappResourcesGetter) {
+				m.EXPECT().GetAppResourcesByRegion(gomock.Any(), gomock.Any()).Return(&stack.AppRegionalResources{
+					S3Bucket: "mockBucket",
+				}, nil)
+			},
+			mockS3Svc: func(m *mocks.MockartifactUploader) {
+				m.EXPECT().PutArtifact("mockBucket", "api.api.env", gomock.Any()).Return("", mockError).AnyTimes()
+				m.EXPECT().PutArtifact("mockBucket", "api.nginx.env", gomock.Any()).Return("", mockError).AnyTimes()
+			},
+			wantedErr: errors.New("put environment file .+ to bucket mockBucket: some error"),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mft, err := unmarshalSvcManifest("api", []byte(tc.inManifest))
+			require.NoError(t, err)
+			mockWs := mocks.NewMockwsSvcFileReader(ctrl)
+			mockAppResourcesGetter := mocks.NewMockappResourcesGetter(ctrl)
+			mockS3Svc := mocks.NewMockartifactUploader(ctrl)
+			tc.mockWs(mockWs)
+			tc.mockAppResourcesGetter(mockAppResourcesGetter)
+			tc.mockS3Svc(mockS3Svc)
+			opts := deploySvcOpts{
+				deploySvcVars: deploySvcVars{
+					Name: "api",
+				},
+				ws:                mockWs,
+				appCFN:            mockAppResourcesGetter,
+				s3:                mockS3Svc,
+				targetEnvironment: &config.Environment{Name: "test", Region: "us-west-2"},
+				targetApp:         &config.Application{Name: "mockApp"},
+				targetSvcMft:      mft,
+			}
+
+			// WHEN
+			objects, err := opts.pushEnvFilesToS3Bucket()
+
+			// THEN
+			if tc.wantedErr != nil {
+				require.Regexp(t, tc.wantedErr.Error(), err.Error())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.wantedObjects, objects)
+		})
+	}
+}
+
+func TestSvcDeployOpts_waitForDeployment(t *testing.T) {
+	const (
+		mockTaskDef = "arn:aws:ecs:us-west-2:1234567890:task-definition/phonetool-test-api:2"
+		mockSvcArn  = "arn:aws:ecs:us-west-2:1234567890:service/phonetool-test-Cluster-9F7Y0RLP60R7/phonetool-test-api-JSOH5GYBFAIB"
+	)
+	testCases := map[string]struct {
+		inSvcType string
+
+		mockDescriber func(m *mocks.MockserviceDeploymentDescriber)
+		mockECS       func(m *mocks.MockecsDeploymentWaiter)
+
+		wantedErr error
+	}{
+		"skips scheduled jobs": {
+			inSvcType:     manifest.ScheduledJobType,
+			mockDescriber: func(m *mocks.MockserviceDeploymentDescriber) {},
+			mockECS:       func(m *mocks.MockecsDeploymentWaiter) {},
+		},
+		"returns an error if the deployment was rolled back": {
+			inSvcType: manifest.BackendServiceType,
+			mockDescriber: func(m *mocks.MockserviceDeploymentDescriber) {
+				svcArn := ecs.ServiceArn(mockSvcArn)
+				m.EXPECT().TaskDefinitionArn().Return(mockTaskDef, nil)
+				m.EXPECT().GetServiceArn().Return(&svcArn, nil)
+			},
+			mockECS: func(m *mocks.MockecsDeploymentWaiter) {
+				m.EXPECT().WaitForSteadyDeployment("phonetool-test-Cluster-9F7Y0RLP60R7", "phonetool-test-api-JSOH5GYBFAIB").
+					Return("arn:aws:ecs:us-west-2:1234567890:task-definition/phonetool-test-api:1", nil)
+			},
+			wantedErr: errors.New("the deployment of service api to environment test failed and was rolled back to task definition arn:aws:ecs:us-west-2:1234567890:task-definition/phonetool-test-api:1"),
+		},
+		"returns a wrapped error if the service doesn't reach a steady state": {
+			inSvcType: manifest.BackendServiceType,
+			mockDescriber: func(m *mocks.MockserviceDeploymentDescriber) {
+				svcArn := ecs.ServiceArn(mockSvcArn)
+				m.EXPECT().TaskDefinitionArn().Return(mockTaskDef, nil)
+				m.EXPECT().GetServiceArn().Return(&svcArn, nil)
+			},
+			mockECS: func(m *mocks.MockecsDeploymentWaiter) {
+				m.EXPECT().WaitForSteadyDeployment(gomock.Any(), gomock.Any()).Return("", errors.New("some error"))
+			},
+			wantedErr: errors.New("wait for the ECS deployment of service api: some error"),
+		},
+		"succeeds once the service runs the deployed task definition": {
+			inSvcType: manifest.LoadBalancedWebServiceType,
+			mockDescriber: func(m *mocks.MockserviceDeploymentDescriber) {
+				svcArn := ecs.ServiceArn(mockSvcArn)
+				m.EXPECT().TaskDefinitionArn().Return(mockTaskDef, nil)
+				m.EXPECT().GetServiceArn().Return(&svcArn, nil)
+			},
+			mockECS: func(m *mocks.MockecsDeploymentWaiter) {
+				m.EXPECT().WaitForSteadyDeployment(gomock.Any(), gomock.Any()).Return(mockTaskDef, nil)
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockDescriber := mocks.NewMockserviceDeploymentDescriber(ctrl)
+			mockECS := mocks.NewMockecsDeploymentWaiter(ctrl)
+			mockSpinner := mocks.NewMockprogress(ctrl)
+			mockSpinner.EXPECT().Start(gomock.Any()).AnyTimes()
+			mockSpinner.EXPECT().Stop(gomock.Any()).AnyTimes()
+			tc.mockDescriber(mockDescriber)
+			tc.mockECS(mockECS)
+
+			opts := deploySvcOpts{
+				deploySvcVars: deploySvcVars{
+					Name: "api",
+				},
+				svcDescriber: mockDescriber,
+				ecs:          mockECS,
+				spinner:      mockSpinner,
+				targetSvc: &config.Service{
+					Name: "api",
+					Type: tc.inSvcType,
+				},
+				targetEnvironment: &config.Environment{
+					Name: "test",
+				},
+			}
+
+			// WHEN
+			err := opts.waitForDeployment()
+
+			// THEN
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSvcDeployOpts_syncStaticSite(t *testing.T) {
+	testCases := map[string]struct {
+		mockWs     func(m *mocks.MockwsSvcFileReader)
+		mockBucket func(m *mocks.MockbucketSyncer)
+
+		wantedErr error
+	}{
+		"returns a wrapped error if a file can't be read": {
+			mockWs: func(m *mocks.MockwsSvcFileReader) {
+				m.EXPECT().ReadFile(filepath.Join("website", "dist", "index.html")).Return(nil, errors.New("some error"))
+			},
+			mockBucket: func(m *mocks.MockbucketSyncer) {},
+			wantedErr:  errors.New("read file index.html of static site website: some error"),
+		},
+		"returns a wrapped error if a file can't be uploaded": {
+			mockWs: func(m *mocks.MockwsSvcFileReader) {
+				m.EXPECT().ReadFile(gomock.Any()).Return([]byte("<html></html>"), nil)
+			},
+			mockBucket: func(m *mocks.MockbucketSyncer) {
+				m.EXPECT().PutObject("site-bucket", "index.html", gomock.Any()).Return(errors.New("some error"))
+			},
+			wantedErr: errors.New("upload file index.html of static site website: some error"),
+		},
+		"uploads the files and deletes the objects of removed files": {
+			mockWs: func(m *mocks.MockwsSvcFileReader) {
+				m.EXPECT().ReadFile(filepath.Join("website", "dist", "index.html")).Return([]byte("<html></html>"), nil)
+				m.EXPECT().ReadFile(filepath.Join("website", "dist", "css", "main.css")).Return([]byte("body {}"), nil)
+			},
+			mockBucket: func(m *mocks.MockbucketSyncer) {
+				m.EXPECT().PutObject("site-bucket", "index.html", bytes.NewReader([]byte("<html></html>"))).Return(nil)
+				m.EXPECT().PutObject("site-bucket", "css/main.css", bytes.NewReader([]byte("body {}"))).Return(nil)
+				m.EXPECT().ListObjectKeys("site-bucket").Return([]string{"index.html", "css/main.css", "css/old.css"}, nil)
+				m.EXPECT().DeleteObjects("site-bucket", []string{"css/old.css"}).Return(nil)
+			},
+		},
+		"doesn't delete any object if every file still exists": {
+			mockWs: func(m *mocks.MockwsSvcFileReader) {
+				m.EXPECT().ReadFile(gomock.Any()).Return([]byte("content"), nil).Times(2)
+			},
+			mockBucket: func(m *mocks.MockbucketSyncer) {
+				m.EXPECT().PutObject(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(2)
+				m.EXPECT().ListObjectKeys("site-bucket").Return([]string{"index.html", "css/main.css"}, nil)
+				m.EXPECT().DeleteObjects(gomock.Any(), gomock.Any()).Times(0)
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockWs := mocks.NewMockwsSvcFileReader(ctrl)
+			mockBucket := mocks.NewMockbucketSyncer(ctrl)
+			mockSpinner := mocks.NewMockprogress(ctrl)
+			mockSpinner.EXPECT().Start(gomock.Any()).AnyTimes()
+			mockSpinner.EXPECT().Stop(gomock.Any()).AnyTimes()
+			tc.mockWs(mockWs)
+			tc.mockBucket(mockBucket)
+
+			opts := deploySvcOpts{
+				deploySvcVars: deploySvcVars{
+					Name: "website",
+				},
+				ws:         mockWs,
+				siteBucket: mockBucket,
+				spinner:    mockSpinner,
+			}
+
+			// WHEN
+			err := opts.syncStaticSite("website/dist", []string{"index.html", "css/main.css"}, "site-bucket")
+
+			// THEN
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSvcDeployOpts_deployedTaskDefinition(t *testing.T) {
+	const (
+		mockTaskDef = "arn:aws:ecs:us-west-2:1234567890:task-definition/phonetool-test-payments:2"
+		mockSvcArn  = "arn:aws:ecs:us-west-2:1234567890:service/phonetool-test-Cluster-9F7Y0RLP60R7/phonetool-test-payments-JSOH5GYBFAIB"
+	)
+	testCases := map[string]struct {
+		mockStack     func(m *mocks.MockstackDescriber)
+		mockDescriber func(m *mocks.MockserviceDeploymentDescriber)
+		mockECS       func(m *mocks.MockecsDeploymentWaiter)
+
+		wanted    string
+		wantedErr error
+	}{
+		"returns the empty string if the stack doesn't exist": {
+			mockStack: func(m *mocks.MockstackDescriber) {
+				m.EXPECT().Describe("phonetool-test-payments").Return(nil, &awscloudformation.ErrStackNotFound{})
+			},
+			mockDescriber: func(m *mocks.MockserviceDeploymentDescriber) {},
+			mockECS:       func(m *mocks.MockecsDeploymentWaiter) {},
+		},
+		"returns a wrapped error if the stack can't be described": {
+			mockStack: func(m *mocks.MockstackDescriber) {
+				m.EXPECT().Describe("phonetool-test-payments").Return(nil, errors.New("some error"))
+			},
+			mockDescriber: func(m *mocks.MockserviceDeploymentDescriber) {},
+			mockECS:       func(m *mocks.MockecsDeploymentWaiter) {},
+			wantedErr:     errors.New("describe stack of service payments: some error"),
+		},
+		"returns a wrapped error if the ECS service can't be described": {
+			mockStack: func(m *mocks.MockstackDescriber) {
+				m.EXPECT().Describe("phonetool-test-payments").Return(&awscloudformation.StackDescription{}, nil)
+			},
+			mockDescriber: func(m *mocks.MockserviceDeploymentDescriber) {
+				svcArn := ecs.ServiceArn(mockSvcArn)
+				m.EXPECT().GetServiceArn().Return(&svcArn, nil)
+			},
+			mockECS: func(m *mocks.MockecsDeploymentWaiter) {
+				m.EXPECT().Service("phonetool-test-Cluster-9F7Y0RLP60R7", "phonetool-test-payments-JSOH5GYBFAIB").
+					Return(nil, errors.New("some error"))
+			},
+			wantedErr: errors.New("get ECS service of service payments: some error"),
+		},
+		"returns the task definition that the ECS service runs": {
+			mockStack: func(m *mocks.MockstackDescriber) {
+				m.EXPECT().Describe("phonetool-test-payments").Return(&awscloudformation.StackDescription{
+					Parameters: []*sdkcloudformation.Parameter{
+						{
+							ParameterKey:   aws.String(stack.LBWebServiceDeployedTaskDefParamKey),
+							ParameterValue: aws.String("arn:aws:ecs:us-west-2:1234567890:task-definition/phonetool-test-payments:1"),
+						},
+					},
+				}, nil)
+			},
+			mockDescriber: func(m *mocks.MockserviceDeploymentDescriber) {
+				svcArn := ecs.ServiceArn(mockSvcArn)
+				m.EXPECT().GetServiceArn().Return(&svcArn, nil)
+			},
+			mockECS: func(m *mocks.MockecsDeploymentWaiter) {
+				m.EXPECT().Service("phonetool-test-Cluster-9F7Y0RLP60R7", "phonetool-test-payments-JSOH5GYBFAIB").
+					Return(&ecs.Service{TaskDefinition: aws.String(mockTaskDef)}, nil)
+			},
+			wanted: mockTaskDef,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockStack := mocks.NewMockstackDescriber(ctrl)
+			mockDescriber := mocks.NewMockserviceDeploymentDescriber(ctrl)
+			mockECS := mocks.NewMockecsDeploymentWaiter(ctrl)
+			tc.mockStack(mockStack)
+			tc.mockDescriber(mockDescriber)
+			tc.mockECS(mockECS)
+
+			opts := deploySvcOpts{
+				deploySvcVars: deploySvcVars{
+					GlobalOpts: &GlobalOpts{
+						appName: "phonetool",
+					},
+					Name: "payments",
+				},
+				svcStack:     mockStack,
+				svcDescriber: mockDescriber,
+				ecs:          mockECS,
+				targetEnvironment: &config.Environment{
+					Name: "test",
+				},
+			}
+
+			// WHEN
+			got, err := opts.deployedTaskDefinition()
+
+			// THEN
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.wanted, got)
+			}
+		})
+	}
+}
+
+func TestSvcDeployOpts_checkEnvExports(t *testing.T) {
+	const mockTemplate = `Resources:
+  Service:
+    Properties:
+      Cluster:
+        Fn::ImportValue:
+          !Sub '${AppName}-${EnvName}-ClusterId'
+  Ingress:
+    Properties:
+      CidrIp:
+        Fn::ImportValue:
+          !Sub '${AppName}-${EnvName}-VpcCIDR'`
+	testCases := map[string]struct {
+		mockStack func(m *mocks.MockstackDescriber)
+
+		wantedErr error
+	}{
+		"returns a wrapped error if the environment stack can't be described": {
+			mockStack: func(m *mocks.MockstackDescriber) {
+				m.EXPECT().Describe("phonetool-test").Return(nil, errors.New("some error"))
+			},
+			wantedErr: errors.New("describe stack of environment test: some error"),
+		},
+		"returns an error if the environment stack doesn't export the imported values": {
+			mockStack: func(m *mocks.MockstackDescriber) {
+				m.EXPECT().Describe("phonetool-test").Return(&awscloudformation.StackDescription{
+					Outputs: []*sdkcloudformation.Output{
+						{
+							OutputKey:  aws.String("ClusterId"),
+							ExportName: aws.String("phonetool-test-ClusterId"),
+						},
+					},
+				}, nil)
+			},
+			wantedErr: errors.New(`environment test doesn't export VpcCIDR required by service payments: it was created by an older version of copilot, recreate it with "copilot env delete --name test" and "copilot env init --name test"`),
+		},
+		"success": {
+			mockStack: func(m *mocks.MockstackDescriber) {
+				m.EXPECT().Describe("phonetool-test").Return(&awscloudformation.StackDescription{
+					Outputs: []*sdkcloudformation.Output{
+						{
+							OutputKey:  aws.String("ClusterId"),
+							ExportName: aws.String("phonetool-test-ClusterId"),
+						},
+						{
+							OutputKey:  aws.String("VpcCIDR"),
+							ExportName: aws.String("phonetool-test-VpcCIDR"),
+						},
+					},
+				}, nil)
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockStack := mocks.NewMockstackDescriber(ctrl)
+			tc.mockStack(mockStack)
+
+			opts := deploySvcOpts{
+				deploySvcVars: deploySvcVars{
+					GlobalOpts: &GlobalOpts{
+						appName: "phonetool",
+					},
+					Name: "payments",
+				},
+				svcStack: mockStack,
+				targetEnvironment: &config.Environment{
+					Name: "test",
+				},
+			}
+
+			// WHEN
+			err := opts.checkEnvExports(mockTemplate)
+
+			// THEN
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSvcDeployOpts_deployBlueGreen(t *testing.T) {
+	const (
+		mockDeployedTaskDef = "arn:aws:ecs:us-west-2:1234567890:task-definition/phonetool-test-payments:1"
+		mockTaskDef         = "arn:aws:ecs:us-west-2:1234567890:task-definition/phonetool-test-payments:2"
+		mockSvcArn          = "arn:aws:ecs:us-west-2:1234567890:service/phonetool-test-Cluster-9F7Y0RLP60R7/phonetool-test-payments-JSOH5GYBFAIB"
+	)
+	wantedInput := &codedeploy.ECSDeploymentInput{
+		ApplicationName:     "phonetool-test-payments",
+		DeploymentGroupName: "phonetool-test-payments",
+		TaskDefinition:      mockTaskDef,
+		ContainerName:       "payments",
+		ContainerPort:       8080,
+	}
+	testCases := map[string]struct {
+		inDeployedTaskDef string
+
+		mockCodeDeploy func(m *mocks.MockecsBlueGreenDeployer)
+
+		wantedErr error
+	}{
+		"skips the deployment if the ECS service already runs the task definition": {
+			inDeployedTaskDef: mockTaskDef,
+			mockCodeDeploy:    func(m *mocks.MockecsBlueGreenDeployer) {},
+		},
+		"returns a wrapped error if the deployment can't be created": {
+			inDeployedTaskDef: mockDeployedTaskDef,
+			mockCodeDeploy: func(m *mocks.MockecsBlueGreenDeployer) {
+				m.EXPECT().CreateECSDeployment(wantedInput).Return("", errors.New("some error"))
+			},
+			wantedErr: errors.New("start the blue/green deployment of service payments: some error"),
+		},
+		"returns an error if the deployment was rolled back": {
+			inDeployedTaskDef: mockDeployedTaskDef,
+			mockCodeDeploy: func(m *mocks.MockecsBlueGreenDeployer) {
+				m.EXPECT().CreateECSDeployment(wantedInput).Return("d-1234", nil)
+				m.EXPECT().WaitForDeployment("d-1234").Return(&codedeploy.Deployment{
+					ID:                   "d-1234",
+					Status:               "Stopped",
+					ErrorMessage:         "One or more alarms have been activated",
+					RollbackDeploymentID: "d-5678",
+				}, nil)
+			},
+			wantedErr: errors.New("the blue/green deployment d-1234 of service payments to environment test stopped and was rolled back by deployment d-5678: One or more alarms have been activated"),
+		},
+		"succeeds once the traffic is shifted": {
+			inDeployedTaskDef: mockDeployedTaskDef,
+			mockCodeDeploy: func(m *mocks.MockecsBlueGreenDeployer) {
+				m.EXPECT().CreateECSDeployment(wantedInput).Return("d-1234", nil)
+				m.EXPECT().WaitForDeployment("d-1234").Return(&codedeploy.Deployment{
+					ID:     "d-1234",
+					Status: "Succeeded",
+				}, nil)
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockDescriber := mocks.NewMockserviceDeploymentDescriber(ctrl)
+			mockECS := mocks.NewMockecsDeploymentWaiter(ctrl)
+			mockCodeDeploy := mocks.NewMockecsBlueGreenDeployer(ctrl)
+			mockSpinner := mocks.NewMockprogress(ctrl)
+			mockSpinner.EXPECT().Start(gomock.Any()).AnyTimes()
+			mockSpinner.EXPECT().Stop(gomock.Any()).AnyTimes()
+			svcArn := ecs.ServiceArn(mockSvcArn)
+			mockDescriber.EXPECT().TaskDefinitionArn().Return(mockTaskDef, nil)
+			mockDescriber.EXPECT().GetServiceArn().Return(&svcArn, nil)
+			mockECS.EXPECT().Service("phonetool-test-Cluster-9F7Y0RLP60R7", "phonetool-test-payments-JSOH5GYBFAIB").
+				Return(&ecs.Service{TaskDefinition: aws.String(tc.inDeployedTaskDef)}, nil)
+			tc.mockCodeDeploy(mockCodeDeploy)
+
+			opts := deploySvcOpts{
+				deploySvcVars: deploySvcVars{
+					GlobalOpts: &GlobalOpts{
+						appName: "phonetool",
+					},
+					Name: "payments",
+				},
+				svcDescriber: mockDescriber,
+				ecs:          mockECS,
+				codeDeploy:   mockCodeDeploy,
+				spinner:      mockSpinner,
+				targetEnvironment: &config.Environment{
+					Name: "test",
+				},
+			}
+			mft := &manifest.LoadBalancedWebService{}
+			mft.Image.Port = 8080
+
+			// WHEN
+			err := opts.deployBlueGreen(mft)
+
+			// THEN
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateAlias(t *testing.T) {
+	testCases := map[string]struct {
+		inAlias string
+		inApp   *config.Application
+
+		wantedErr error
+	}{
+		"error if the application doesn't have a domain": {
+			inAlias: "api.phonetool.example.com",
+			inApp: &config.Application{
+				Name: "phonetool",
+			},
+
+			wantedErr: errors.New("alias api.phonetool.example.com requires the application phonetool to have a domain name"),
+		},
+		"error if the alias is outside of the hosted zone of the application": {
+			inAlias: "api.example.com",
+			inApp: &config.Application{
+				Name:   "phonetool",
+				Domain: "example.com",
+			},
+
+			wantedErr: errors.New("alias api.example.com must be phonetool.example.com or a subdomain of it"),
+		},
+		"error if the alias only shares a suffix with the application domain": {
+			inAlias: "myphonetool.example.com",
+			inApp: &config.Application{
+				Name:   "phonetool",
+				Domain: "example.com",
+			},
+
+			wantedErr: errors.New("alias myphonetool.example.com must be phonetool.example.com or a subdomain of it"),
+		},
+		"valid subdomain": {
+			inAlias: "api.phonetool.example.com",
+			inApp: &config.Application{
+				Name:   "phonetool",
+				Domain: "example.com",
+			},
+		},
+		"valid application domain": {
+			inAlias: "phonetool.example.com",
+			inApp: &config.Application{
+				Name:   "phonetool",
+				Domain: "example.com",
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// WHEN
+			err := validateAlias(tc.inAlias, tc.inApp)
+
+			// THEN
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -29,7 +29,13 @@ var (
 To learn more see: https://git.io/JfIpv
 
 A %s is a private, non internet-facing service.
-To learn more see: https://git.io/JfIpT`
+To learn more see: https://git.io/JfIpT
+
+A %s is a private service that processes messages from a queue.
+
+A %s is a task that runs to completion on a fixed schedule.
+
+A %s serves the files of a local directory, such as a front-end bundle, from S3 through CloudFront.`
 
 	fmtSvcInitSvcNamePrompt     = "What do you want to " + color.Emphasize("name") + " this %s?"
 	fmtSvcInitSvcNameHelpPrompt = `The name will uniquely identify this service within your app %s.
@@ -41,6 +47,15 @@ Deployed resources (such as your service, logs) will contain this service's name
 	svcInitSvcPortPrompt     = "Which port do you want customer traffic sent to?"
 	svcInitSvcPortHelpPrompt = `The port will be used by the load balancer to route incoming traffic to this service.
 You should set this to the port which your Dockerfile uses to communicate with the internet.`
+
+	svcInitSitePathPrompt     = "Which " + color.Emphasize("directory") + " holds the files of your site?"
+	svcInitSitePathHelpPrompt = `The directory relative to the root of your workspace, such as the output of your front-end build.
+Its files are uploaded to an S3 bucket every time the site is deployed.`
+
+	svcInitSchedulePrompt     = "How often do you want this job to be " + color.Emphasize("triggered") + "?"
+	svcInitScheduleHelpPrompt = `The schedule on which your job is invoked. For example:
+"@daily", "rate(30 minutes)", or "cron(0 9 ? * MON-FRI *)".
+Predefined schedules are @hourly, @daily, @weekly, @monthly, and @yearly.`
 )
 
 const (
@@ -66,6 +81,8 @@ type initSvcVars struct {
 	Name           string
 	DockerfilePath string
 	Port           uint16
+	Schedule       string
+	SitePath       string
 }
 
 type initSvcOpts struct {
@@ -143,6 +160,16 @@ func (o *initSvcOpts) Validate() error {
 			return err
 		}
 	}
+	if o.Schedule != "" {
+		if err := validateSchedule(o.Schedule); err != nil {
+			return err
+		}
+	}
+	if o.SitePath != "" {
+		if _, err := o.fs.Stat(o.SitePath); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -154,9 +181,20 @@ func (o *initSvcOpts) Ask() error {
 	if err := o.askSvcName(); err != nil {
 		return err
 	}
+	if o.ServiceType == manifest.StaticSiteType {
+		// A static site serves files from a bucket instead of running a container image.
+		return o.askSitePath()
+	}
 	if err := o.askDockerfile(); err != nil {
 		return err
 	}
+	switch o.ServiceType {
+	case manifest.ScheduledJobType:
+		return o.askSchedule()
+	case manifest.WorkerServiceType:
+		// A worker service consumes messages from its queue and doesn't receive traffic on a port.
+		return nil
+	}
 	if err := o.askSvcPort(); err != nil {
 		return err
 	}
@@ -232,6 +270,15 @@ func (o *initSvcOpts) newManifest() (encoding.BinaryMarshaler, error) {
 		return o.newLoadBalancedWebServiceManifest()
 	case manifest.BackendServiceType:
 		return o.newBackendServiceManifest()
+	case manifest.WorkerServiceType:
+		return o.newWorkerServiceManifest()
+	case manifest.ScheduledJobType:
+		return o.newScheduledJobManifest()
+	case manifest.StaticSiteType:
+		return manifest.NewStaticSite(manifest.StaticSiteProps{
+			Name: o.Name,
+			Path: o.SitePath,
+		}), nil
 	default:
 		return nil, fmt.Errorf("service type %s doesn't have a manifest", o.ServiceType)
 	}
@@ -271,6 +318,25 @@ func (o *initSvcOpts) newBackendServiceManifest() (*manifest.BackendService, err
 	}), nil
 }
 
+func (o *initSvcOpts) newWorkerServiceManifest() (*manifest.WorkerService, error) {
+	return manifest.NewWorkerService(manifest.WorkerServiceProps{
+		ServiceProps: manifest.ServiceProps{
+			Name:       o.Name,
+			Dockerfile: o.DockerfilePath,
+		},
+	}), nil
+}
+
+func (o *initSvcOpts) newScheduledJobManifest() (*manifest.ScheduledJob, error) {
+	return manifest.NewScheduledJob(&manifest.ScheduledJobProps{
+		ServiceProps: &manifest.ServiceProps{
+			Name:       o.Name,
+			Dockerfile: o.DockerfilePath,
+		},
+		Schedule: o.Schedule,
+	}), nil
+}
+
 func (o *initSvcOpts) askSvcType() error {
 	if o.ServiceType != "" {
 		return nil
@@ -279,6 +345,9 @@ func (o *initSvcOpts) askSvcType() error {
 	help := fmt.Sprintf(fmtSvcInitSvcTypeHelpPrompt,
 		manifest.LoadBalancedWebServiceType,
 		manifest.BackendServiceType,
+		manifest.WorkerServiceType,
+		manifest.ScheduledJobType,
+		manifest.StaticSiteType,
 	)
 	t, err := o.prompt.SelectOne(svcInitSvcTypePrompt, help, manifest.ServiceTypes)
 	if err != nil {
@@ -385,6 +454,42 @@ func (o *initSvcOpts) askSvcPort() error {
 	return nil
 }
 
+func (o *initSvcOpts) askSchedule() error {
+	if o.Schedule != "" {
+		return nil
+	}
+
+	schedule, err := o.prompt.Get(
+		svcInitSchedulePrompt,
+		svcInitScheduleHelpPrompt,
+		validateSchedule,
+		prompt.WithDefaultInput("@daily"),
+	)
+	if err != nil {
+		return fmt.Errorf("get schedule: %w", err)
+	}
+	o.Schedule = schedule
+	return nil
+}
+
+func (o *initSvcOpts) askSitePath() error {
+	if o.SitePath != "" {
+		return nil
+	}
+
+	sitePath, err := o.prompt.Get(
+		svcInitSitePathPrompt,
+		svcInitSitePathHelpPrompt,
+		validateSitePath,
+		prompt.WithDefaultInput("dist"),
+	)
+	if err != nil {
+		return fmt.Errorf("get site directory: %w", err)
+	}
+	o.SitePath = sitePath
+	return nil
+}
+
 // RecommendedActions returns follow-up actions the user can take after successfully executing the command.
 func (o *initSvcOpts) RecommendedActions() []string {
 	return []string{
@@ -410,7 +515,16 @@ This command is also run as part of "copilot init".`,
   /code $ copilot svc init --name frontend --svc-type "Load Balanced Web Service" --dockerfile ./frontend/Dockerfile
 
   Create a "subscribers" backend service.
-  /code $ copilot svc init --name subscribers --svc-type "Backend Service"`,
+  /code $ copilot svc init --name subscribers --svc-type "Backend Service"
+
+  Create a "processor" worker service that consumes messages from a queue.
+  /code $ copilot svc init --name processor --svc-type "Worker Service"
+
+  Create a "report" scheduled job that runs every day.
+  /code $ copilot svc init --name report --svc-type "Scheduled Job" --schedule "@daily"
+
+  Create a "website" static site that serves the files of the "frontend/dist" directory.
+  /code $ copilot svc init --name website --svc-type "Static Site" --path frontend/dist`,
 		RunE: runCmdE(func(cmd *cobra.Command, args []string) error {
 			opts, err := newInitSvcOpts(vars)
 			if err != nil {
@@ -437,6 +551,8 @@ This command is also run as part of "copilot init".`,
 	cmd.Flags().StringVarP(&vars.ServiceType, svcTypeFlag, svcTypeFlagShort, "", svcTypeFlagDescription)
 	cmd.Flags().StringVarP(&vars.DockerfilePath, dockerFileFlag, dockerFileFlagShort, "", dockerFileFlagDescription)
 	cmd.Flags().Uint16Var(&vars.Port, svcPortFlag, 0, svcPortFlagDescription)
+	cmd.Flags().StringVar(&vars.Schedule, scheduleFlag, "", scheduleFlagDescription)
+	cmd.Flags().StringVar(&vars.SitePath, sitePathFlag, "", sitePathFlagDescription)
 
 	// Bucket flags by service type.
 	requiredFlags := pflag.NewFlagSet("Required Flags", pflag.ContinueOnError)
@@ -450,12 +566,27 @@ This command is also run as part of "copilot init".`,
 	backendSvcFlags := pflag.NewFlagSet(manifest.BackendServiceType, pflag.ContinueOnError)
 	backendSvcFlags.AddFlag(cmd.Flags().Lookup(svcPortFlag))
 
+	scheduledJobFlags := pflag.NewFlagSet(manifest.ScheduledJobType, pflag.ContinueOnError)
+	scheduledJobFlags.AddFlag(cmd.Flags().Lookup(scheduleFlag))
+
+	staticSiteFlags := pflag.NewFlagSet(manifest.StaticSiteType, pflag.ContinueOnError)
+	staticSiteFlags.AddFlag(cmd.Flags().Lookup(sitePathFlag))
+
 	cmd.Annotations = map[string]string{
 		// The order of the sections we want to display.
-		"sections":                          fmt.Sprintf(`Required,%s`, strings.Join(manifest.ServiceTypes, ",")),
+		// Worker services don't have any type-specific flags.
+		"sections": strings.Join([]string{
+			"Required",
+			manifest.LoadBalancedWebServiceType,
+			manifest.BackendServiceType,
+			manifest.ScheduledJobType,
+			manifest.StaticSiteType,
+		}, ","),
 		"Required":                          requiredFlags.FlagUsages(),
 		manifest.LoadBalancedWebServiceType: lbWebSvcFlags.FlagUsages(),
-		manifest.BackendServiceType:         lbWebSvcFlags.FlagUsages(),
+		manifest.BackendServiceType:         backendSvcFlags.FlagUsages(),
+		manifest.ScheduledJobType:           scheduledJobFlags.FlagUsages(),
+		manifest.StaticSiteType:             staticSiteFlags.FlagUsages(),
 	}
 	cmd.SetUsageTemplate(`{{h1 "Usage"}}{{if .Runnable}}
   {{.UseLine}}{{end}}{{$annotations := .Annotations}}{{$sections := split .Annotations.sections ","}}{{if gt (len $sections) 0}}
@@ -23,6 +23,7 @@ func TestSvcInitOpts_Validate(t *testing.T) {
 		inDockerfilePath string
 		inAppName        string
 		inSvcPort        uint16
+		inSchedule       string
 
 		mockFileSystem func(mockFS afero.Fs)
 		wantedErr      error
@@ -30,7 +31,7 @@ func TestSvcInitOpts_Validate(t *testing.T) {
 		"invalid service type": {
 			inAppName: "phonetool",
 			inSvcType: "TestSvcType",
-			wantedErr: errors.New(`invalid service type TestSvcType: must be one of "Load Balanced Web Service", "Backend Service"`),
+			wantedErr: errors.New(`invalid service type TestSvcType: must be one of "Load Balanced Web Service", "Backend Service", "Worker Service", "Scheduled Job", "Static Site"`),
 		},
 		"invalid service name": {
 			inAppName: "phonetool",
@@ -46,6 +47,12 @@ func TestSvcInitOpts_Validate(t *testing.T) {
 			inAppName: "",
 			wantedErr: errNoAppInWorkspace,
 		},
+		"invalid schedule": {
+			inAppName:  "phonetool",
+			inSvcType:  manifest.ScheduledJobType,
+			inSchedule: "every day",
+			wantedErr:  fmt.Errorf("schedule every day is invalid: %s", errScheduleBadFormat),
+		},
 		"valid flags": {
 			inSvcName:        "frontend",
 			inSvcType:        "Load Balanced Web Service",
@@ -68,6 +75,7 @@ func TestSvcInitOpts_Validate(t *testing.T) {
 					Name:           tc.inSvcName,
 					DockerfilePath: tc.inDockerfilePath,
 					Port:           tc.inSvcPort,
+					Schedule:       tc.inSchedule,
 					GlobalOpts:     &GlobalOpts{appName: tc.inAppName},
 				},
 				fs: &afero.Afero{Fs: afero.NewMemMapFs()},
@@ -343,6 +351,141 @@ func TestSvcInitOpts_Ask(t *testing.T) {
 	}
 }
 
+func TestSvcInitOpts_Ask_ScheduledJob(t *testing.T) {
+	testCases := map[string]struct {
+		inSchedule string
+
+		mockPrompt func(m *mocks.Mockprompter)
+
+		wantedSchedule string
+		wantedErr      error
+	}{
+		"asks for schedule instead of port": {
+			mockPrompt: func(m *mocks.Mockprompter) {
+				m.EXPECT().Get(gomock.Eq(svcInitSchedulePrompt), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return("@daily", nil)
+			},
+			wantedSchedule: "@daily",
+		},
+		"errors if failed to get schedule": {
+			mockPrompt: func(m *mocks.Mockprompter) {
+				m.EXPECT().Get(gomock.Eq(svcInitSchedulePrompt), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return("", errors.New("some error"))
+			},
+			wantedErr: fmt.Errorf("get schedule: some error"),
+		},
+		"don't ask for schedule if flag specified": {
+			inSchedule:     "rate(1 hour)",
+			mockPrompt:     func(m *mocks.Mockprompter) {},
+			wantedSchedule: "rate(1 hour)",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockPrompt := mocks.NewMockprompter(ctrl)
+			opts := &initSvcOpts{
+				initSvcVars: initSvcVars{
+					ServiceType:    manifest.ScheduledJobType,
+					Name:           "report",
+					DockerfilePath: "report/Dockerfile",
+					Schedule:       tc.inSchedule,
+					GlobalOpts: &GlobalOpts{
+						prompt: mockPrompt,
+					},
+				},
+				fs:          &afero.Afero{Fs: afero.NewMemMapFs()},
+				setupParser: func(o *initSvcOpts) {},
+				df:          mocks.NewMockdockerfileParser(ctrl),
+			}
+			tc.mockPrompt(mockPrompt)
+
+			// WHEN
+			err := opts.Ask()
+
+			// THEN
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.wantedSchedule, opts.Schedule)
+				require.Equal(t, uint16(0), opts.Port)
+			}
+		})
+	}
+}
+
+func TestSvcInitOpts_Ask_StaticSite(t *testing.T) {
+	testCases := map[string]struct {
+		inSitePath string
+
+		mockPrompt func(m *mocks.Mockprompter)
+
+		wantedSitePath string
+		wantedErr      error
+	}{
+		"asks for the directory of the site instead of a Dockerfile": {
+			mockPrompt: func(m *mocks.Mockprompter) {
+				m.EXPECT().Get(gomock.Eq(svcInitSitePathPrompt), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return("frontend/dist", nil)
+			},
+			wantedSitePath: "frontend/dist",
+		},
+		"errors if failed to get the directory": {
+			mockPrompt: func(m *mocks.Mockprompter) {
+				m.EXPECT().Get(gomock.Eq(svcInitSitePathPrompt), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return("", errors.New("some error"))
+			},
+			wantedErr: fmt.Errorf("get site directory: some error"),
+		},
+		"don't ask for the directory if flag specified": {
+			inSitePath:     "frontend/build",
+			mockPrompt:     func(m *mocks.Mockprompter) {},
+			wantedSitePath: "frontend/build",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockPrompt := mocks.NewMockprompter(ctrl)
+			opts := &initSvcOpts{
+				initSvcVars: initSvcVars{
+					ServiceType: manifest.StaticSiteType,
+					Name:        "website",
+					SitePath:    tc.inSitePath,
+					GlobalOpts: &GlobalOpts{
+						prompt: mockPrompt,
+					},
+				},
+				fs:          &afero.Afero{Fs: afero.NewMemMapFs()},
+				setupParser: func(o *initSvcOpts) {},
+				df:          mocks.NewMockdockerfileParser(ctrl),
+			}
+			tc.mockPrompt(mockPrompt)
+
+			// WHEN
+			err := opts.Ask()
+
+			// THEN
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.wantedSitePath, opts.SitePath)
+				require.Empty(t, opts.DockerfilePath)
+			}
+		})
+	}
+}
+
 func TestAppInitOpts_Execute(t *testing.T) {
 	testCases := map[string]struct {
 		inSvcPort        uint16
@@ -640,7 +783,7 @@ func TestAppInitOpts_createLoadBalancedAppManifest(t *testing.T) {
 				require.Nil(t, err)
 				require.Equal(t, tc.inSvcName, manifest.Service.Name)
 				require.Equal(t, tc.inSvcPort, manifest.Image.Port)
-				require.Equal(t, tc.inDockerfilePath, manifest.Image.ServiceImage.Build)
+				require.Equal(t, tc.inDockerfilePath, manifest.DockerfilePath())
 				require.Equal(t, tc.wantedPath, manifest.Path)
 			} else {
 				require.EqualError(t, err, tc.wantedErr.Error())
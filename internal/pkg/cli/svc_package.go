@@ -112,6 +112,16 @@ func newPackageSvcOpts(vars packageSvcVars) (*packageSvcOpts, error) {
 			if err != nil {
 				return nil, fmt.Errorf("init backend service stack serializer: %w", err)
 			}
+		case *manifest.WorkerService:
+			serializer, err = stack.NewWorkerService(v, env.Name, app.Name, rc)
+			if err != nil {
+				return nil, fmt.Errorf("init worker service stack serializer: %w", err)
+			}
+		case *manifest.ScheduledJob:
+			serializer, err = stack.NewScheduledJob(v, env.Name, app.Name, rc)
+			if err != nil {
+				return nil, fmt.Errorf("init scheduled job stack serializer: %w", err)
+			}
 		default:
 			return nil, fmt.Errorf("create stack serializer for manifest of type %T", v)
 		}
@@ -259,7 +269,7 @@ func (o *packageSvcOpts) getSvcTemplates(env *config.Environment) (*svcCfnTempla
 	if err != nil {
 		return nil, err
 	}
-	mft, err := manifest.UnmarshalService(raw)
+	mft, err := unmarshalSvcManifest(o.Name, raw)
 	if err != nil {
 		return nil, err
 	}
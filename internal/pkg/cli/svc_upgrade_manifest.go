@@ -0,0 +1,168 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/manifest"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/term/log"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/term/prompt"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/workspace"
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/spf13/cobra"
+)
+
+const (
+	fmtSvcUpgradeManifestConfirmPrompt = "Are you sure you want to upgrade the manifest of service %s?"
+	svcUpgradeManifestConfirmHelp      = "The manifest is rewritten with the changes above. Comments are kept."
+)
+
+type upgradeManifestSvcVars struct {
+	Name             string
+	SkipConfirmation bool
+}
+
+type upgradeManifestSvcOpts struct {
+	upgradeManifestSvcVars
+
+	// Interfaces to dependencies.
+	ws     wsSvcManifestUpgrader
+	prompt prompter
+	w      io.Writer
+}
+
+func newUpgradeManifestSvcOpts(vars upgradeManifestSvcVars) (*upgradeManifestSvcOpts, error) {
+	ws, err := workspace.New()
+	if err != nil {
+		return nil, fmt.Errorf("new workspace: %w", err)
+	}
+	return &upgradeManifestSvcOpts{
+		upgradeManifestSvcVars: vars,
+		ws:                     ws,
+		prompt:                 prompt.New(),
+		w:                      os.Stderr,
+	}, nil
+}
+
+// Validate returns an error if the values provided by the user are invalid.
+func (o *upgradeManifestSvcOpts) Validate() error {
+	if o.Name == "" {
+		return nil
+	}
+	names, err := o.ws.ServiceNames()
+	if err != nil {
+		return fmt.Errorf("list services in the workspace: %w", err)
+	}
+	if !contains(o.Name, names) {
+		return fmt.Errorf("service '%s' does not exist in the workspace", o.Name)
+	}
+	return nil
+}
+
+// Execute upgrades the manifest of the service, or of every service in the workspace if no service is specified,
+// to the latest schema version. The changes to each manifest are shown before it's rewritten.
+func (o *upgradeManifestSvcOpts) Execute() error {
+	names := []string{o.Name}
+	if o.Name == "" {
+		wsNames, err := o.ws.ServiceNames()
+		if err != nil {
+			return fmt.Errorf("list services in the workspace: %w", err)
+		}
+		names = wsNames
+	}
+
+	for _, name := range names {
+		if err := o.upgradeManifest(name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (o *upgradeManifestSvcOpts) upgradeManifest(name string) error {
+	raw, err := o.ws.ReadServiceManifest(name)
+	if err != nil {
+		return fmt.Errorf("read manifest for service %s: %w", name, err)
+	}
+	upgraded, err := manifest.UpgradeServiceManifest(raw)
+	if err != nil {
+		return fmt.Errorf("upgrade manifest for service %s: %w", name, err)
+	}
+	version := manifest.LatestServiceManifestVersion()
+	if bytes.Equal(raw, upgraded) {
+		log.Infof("Manifest for service %s is already at version %d.\n", name, version)
+		return nil
+	}
+
+	path := filepath.Join(workspace.CopilotDirName, name, workspace.ManifestFileName)
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        manifestLines(raw),
+		B:        manifestLines(upgraded),
+		FromFile: path,
+		ToFile:   path,
+		Context:  3,
+	})
+	if err != nil {
+		return fmt.Errorf("compare upgraded manifest for service %s: %w", name, err)
+	}
+	fmt.Fprint(o.w, diff)
+
+	if !o.SkipConfirmation {
+		confirmed, err := o.prompt.Confirm(fmt.Sprintf(fmtSvcUpgradeManifestConfirmPrompt, name), svcUpgradeManifestConfirmHelp)
+		if err != nil {
+			return fmt.Errorf("svc upgrade-manifest confirmation prompt: %w", err)
+		}
+		if !confirmed {
+			log.Infof("Skipped the manifest for service %s.\n", name)
+			return nil
+		}
+	}
+	if _, err := o.ws.OverwriteServiceManifest(upgraded, name); err != nil {
+		return fmt.Errorf("write manifest for service %s: %w", name, err)
+	}
+	log.Successf("Upgraded the manifest for service %s to version %d.\n", name, version)
+	return nil
+}
+
+// manifestLines splits the manifest into lines for the diff, without the empty line that follows the final newline.
+func manifestLines(mft []byte) []string {
+	return difflib.SplitLines(strings.TrimSuffix(string(mft), "\n"))
+}
+
+// BuildSvcUpgradeManifestCmd builds the command for upgrading service manifests to the latest schema version.
+func BuildSvcUpgradeManifestCmd() *cobra.Command {
+	vars := upgradeManifestSvcVars{}
+	cmd := &cobra.Command{
+		Use:   "upgrade-manifest",
+		Short: "Upgrades the manifests of services in the workspace to the latest version.",
+		Long: `Upgrades the manifests of services in the workspace to the latest schema version.
+Manifests written by older releases are rewritten so that they keep their behavior when defaults change.
+The changes to each manifest are shown before it's rewritten, and comments are kept.`,
+		Example: `
+  Upgrade the manifests of all the services in the workspace.
+  /code $ copilot svc upgrade-manifest
+
+  Upgrade the manifest of the "frontend" service without confirmation.
+  /code $ copilot svc upgrade-manifest -n frontend --yes`,
+		RunE: runCmdE(func(cmd *cobra.Command, args []string) error {
+			opts, err := newUpgradeManifestSvcOpts(vars)
+			if err != nil {
+				return err
+			}
+			if err := opts.Validate(); err != nil {
+				return err
+			}
+			return opts.Execute()
+		}),
+	}
+	cmd.Flags().StringVarP(&vars.Name, nameFlag, nameFlagShort, "", svcFlagDescription)
+	cmd.Flags().BoolVar(&vars.SkipConfirmation, yesFlag, false, yesFlagDescription)
+	return cmd
+}
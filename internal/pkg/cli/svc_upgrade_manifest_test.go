@@ -0,0 +1,178 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/cli/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpgradeManifestSvcOpts_Validate(t *testing.T) {
+	testCases := map[string]struct {
+		inSvcName string
+
+		setupMocks func(m *mocks.MockwsSvcManifestUpgrader)
+
+		wantedErr error
+	}{
+		"skip checking the workspace if no service is specified": {
+			setupMocks: func(m *mocks.MockwsSvcManifestUpgrader) {
+				m.EXPECT().ServiceNames().Times(0)
+			},
+		},
+		"error when service not in workspace": {
+			inSvcName: "frontend",
+			setupMocks: func(m *mocks.MockwsSvcManifestUpgrader) {
+				m.EXPECT().ServiceNames().Return([]string{"backend"}, nil)
+			},
+			wantedErr: errors.New("service 'frontend' does not exist in the workspace"),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockWs := mocks.NewMockwsSvcManifestUpgrader(ctrl)
+			tc.setupMocks(mockWs)
+			opts := &upgradeManifestSvcOpts{
+				upgradeManifestSvcVars: upgradeManifestSvcVars{
+					Name: tc.inSvcName,
+				},
+				ws: mockWs,
+			}
+
+			// WHEN
+			err := opts.Validate()
+
+			// THEN
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestUpgradeManifestSvcOpts_Execute(t *testing.T) {
+	const (
+		legacyManifest = `name: frontend
+type: Load Balanced Web Service
+
+http:
+  path: '/'
+`
+		upgradedManifest = `name: frontend
+type: Load Balanced Web Service
+version: 1
+
+http:
+  path: '/'
+`
+		wantedDiff = "--- copilot/frontend/manifest.yml\n" +
+			"+++ copilot/frontend/manifest.yml\n" +
+			"@@ -1,5 +1,6 @@\n" +
+			" name: frontend\n" +
+			" type: Load Balanced Web Service\n" +
+			"+version: 1\n" +
+			" \n" +
+			" http:\n" +
+			"   path: '/'\n"
+	)
+	testCases := map[string]struct {
+		inSvcName          string
+		inSkipConfirmation bool
+
+		setupMocks func(ws *mocks.MockwsSvcManifestUpgrader, prompt *mocks.Mockprompter)
+
+		wantedOutput string
+		wantedErr    error
+	}{
+		"upgrades every service in the workspace after confirmation": {
+			setupMocks: func(ws *mocks.MockwsSvcManifestUpgrader, prompt *mocks.Mockprompter) {
+				ws.EXPECT().ServiceNames().Return([]string{"frontend", "backend"}, nil)
+				ws.EXPECT().ReadServiceManifest("frontend").Return([]byte(legacyManifest), nil)
+				prompt.EXPECT().Confirm("Are you sure you want to upgrade the manifest of service frontend?", gomock.Any()).Return(true, nil)
+				ws.EXPECT().OverwriteServiceManifest([]byte(upgradedManifest), "frontend").Return("", nil)
+				ws.EXPECT().ReadServiceManifest("backend").Return([]byte(upgradedManifest), nil)
+			},
+			wantedOutput: wantedDiff,
+		},
+		"skips the confirmation": {
+			inSvcName:          "frontend",
+			inSkipConfirmation: true,
+			setupMocks: func(ws *mocks.MockwsSvcManifestUpgrader, prompt *mocks.Mockprompter) {
+				ws.EXPECT().ReadServiceManifest("frontend").Return([]byte(legacyManifest), nil)
+				prompt.EXPECT().Confirm(gomock.Any(), gomock.Any()).Times(0)
+				ws.EXPECT().OverwriteServiceManifest([]byte(upgradedManifest), "frontend").Return("", nil)
+			},
+			wantedOutput: wantedDiff,
+		},
+		"leaves the manifest untouched if the upgrade isn't confirmed": {
+			inSvcName: "frontend",
+			setupMocks: func(ws *mocks.MockwsSvcManifestUpgrader, prompt *mocks.Mockprompter) {
+				ws.EXPECT().ReadServiceManifest("frontend").Return([]byte(legacyManifest), nil)
+				prompt.EXPECT().Confirm(gomock.Any(), gomock.Any()).Return(false, nil)
+				ws.EXPECT().OverwriteServiceManifest(gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantedOutput: wantedDiff,
+		},
+		"error if the manifest is newer than the release": {
+			inSvcName: "frontend",
+			setupMocks: func(ws *mocks.MockwsSvcManifestUpgrader, prompt *mocks.Mockprompter) {
+				ws.EXPECT().ReadServiceManifest("frontend").Return([]byte("name: frontend\nversion: 3\n"), nil)
+			},
+			wantedErr: errors.New("upgrade manifest for service frontend: manifest version 3 is newer than the latest version 1 supported by this release, upgrade copilot to use this manifest"),
+		},
+		"error while writing the manifest": {
+			inSvcName:          "frontend",
+			inSkipConfirmation: true,
+			setupMocks: func(ws *mocks.MockwsSvcManifestUpgrader, prompt *mocks.Mockprompter) {
+				ws.EXPECT().ReadServiceManifest("frontend").Return([]byte(legacyManifest), nil)
+				ws.EXPECT().OverwriteServiceManifest(gomock.Any(), "frontend").Return("", errors.New("some error"))
+			},
+			wantedOutput: wantedDiff,
+			wantedErr:    errors.New("write manifest for service frontend: some error"),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockWs := mocks.NewMockwsSvcManifestUpgrader(ctrl)
+			mockPrompt := mocks.NewMockprompter(ctrl)
+			tc.setupMocks(mockWs, mockPrompt)
+			b := &bytes.Buffer{}
+			opts := &upgradeManifestSvcOpts{
+				upgradeManifestSvcVars: upgradeManifestSvcVars{
+					Name:             tc.inSvcName,
+					SkipConfirmation: tc.inSkipConfirmation,
+				},
+				ws:     mockWs,
+				prompt: mockPrompt,
+				w:      b,
+			}
+
+			// WHEN
+			err := opts.Execute()
+
+			// THEN
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tc.wantedOutput, b.String())
+		})
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/term/log"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/workspace"
+	"github.com/spf13/cobra"
+)
+
+type validateSvcVars struct {
+	Name string
+}
+
+type validateSvcOpts struct {
+	validateSvcVars
+
+	// Interfaces to dependencies.
+	ws wsSvcReader
+	w  io.Writer
+}
+
+func newValidateSvcOpts(vars validateSvcVars) (*validateSvcOpts, error) {
+	ws, err := workspace.New()
+	if err != nil {
+		return nil, fmt.Errorf("new workspace: %w", err)
+	}
+	return &validateSvcOpts{
+		validateSvcVars: vars,
+		ws:              ws,
+		w:               os.Stderr,
+	}, nil
+}
+
+// Validate returns an error if the values provided by the user are invalid.
+func (o *validateSvcOpts) Validate() error {
+	if o.Name == "" {
+		return nil
+	}
+	names, err := o.ws.ServiceNames()
+	if err != nil {
+		return fmt.Errorf("list services in the workspace: %w", err)
+	}
+	if !contains(o.Name, names) {
+		return fmt.Errorf("service '%s' does not exist in the workspace", o.Name)
+	}
+	return nil
+}
+
+// Execute validates the manifest of the service, or of every service in the workspace if no service is specified.
+// Every problem is written as "path:line:column: reason".
+func (o *validateSvcOpts) Execute() error {
+	names := []string{o.Name}
+	if o.Name == "" {
+		wsNames, err := o.ws.ServiceNames()
+		if err != nil {
+			return fmt.Errorf("list services in the workspace: %w", err)
+		}
+		names = wsNames
+	}
+
+	var numInvalid int
+	for _, name := range names {
+		raw, err := o.ws.ReadServiceManifest(name)
+		if err != nil {
+			return fmt.Errorf("read manifest for service %s: %w", name, err)
+		}
+		if err := o.validateManifest(name, raw); err != nil {
+			numInvalid++
+			continue
+		}
+		log.Successf("Manifest for service %s is valid.\n", name)
+	}
+	if numInvalid > 0 {
+		return fmt.Errorf("%d of %d service manifest(s) are invalid", numInvalid, len(names))
+	}
+	return nil
+}
+
+// validateManifest writes every problem in the manifest and returns an error if there is any.
+func (o *validateSvcOpts) validateManifest(name string, raw []byte) error {
+	_, err := unmarshalSvcManifest(name, raw)
+	if err == nil {
+		return nil
+	}
+	fmt.Fprintln(o.w, err)
+	return err
+}
+
+// BuildSvcValidateCmd builds the command for validating service manifests.
+func BuildSvcValidateCmd() *cobra.Command {
+	vars := validateSvcVars{}
+	cmd := &cobra.Command{
+		Use:   "validate",
+		Short: "Validates the manifests of services in the workspace.",
+		Long: `Validates the manifests of services in the workspace without calling AWS.
+Reports unknown fields, invalid values and invalid Fargate task sizes with their line and column.`,
+		Example: `
+  Validate the manifests of all the services in the workspace.
+  /code $ copilot svc validate
+
+  Validate the manifest of the "frontend" service.
+  /code $ copilot svc validate -n frontend`,
+		RunE: runCmdE(func(cmd *cobra.Command, args []string) error {
+			opts, err := newValidateSvcOpts(vars)
+			if err != nil {
+				return err
+			}
+			if err := opts.Validate(); err != nil {
+				return err
+			}
+			return opts.Execute()
+		}),
+	}
+	cmd.Flags().StringVarP(&vars.Name, nameFlag, nameFlagShort, "", svcFlagDescription)
+	return cmd
+}
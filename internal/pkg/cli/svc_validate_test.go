@@ -0,0 +1,156 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/cli/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateSvcOpts_Validate(t *testing.T) {
+	testCases := map[string]struct {
+		inSvcName string
+
+		setupMocks func(m *mocks.MockwsSvcReader)
+
+		wantedErr error
+	}{
+		"skip checking the workspace if no service is specified": {
+			setupMocks: func(m *mocks.MockwsSvcReader) {
+				m.EXPECT().ServiceNames().Times(0)
+			},
+		},
+		"error while listing services": {
+			inSvcName: "frontend",
+			setupMocks: func(m *mocks.MockwsSvcReader) {
+				m.EXPECT().ServiceNames().Return(nil, errors.New("some error"))
+			},
+			wantedErr: errors.New("list services in the workspace: some error"),
+		},
+		"error when service not in workspace": {
+			inSvcName: "frontend",
+			setupMocks: func(m *mocks.MockwsSvcReader) {
+				m.EXPECT().ServiceNames().Return([]string{"backend"}, nil)
+			},
+			wantedErr: errors.New("service 'frontend' does not exist in the workspace"),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockWs := mocks.NewMockwsSvcReader(ctrl)
+			tc.setupMocks(mockWs)
+			opts := &validateSvcOpts{
+				validateSvcVars: validateSvcVars{
+					Name: tc.inSvcName,
+				},
+				ws: mockWs,
+			}
+
+			// WHEN
+			err := opts.Validate()
+
+			// THEN
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateSvcOpts_Execute(t *testing.T) {
+	const (
+		validManifest = `name: frontend
+type: Load Balanced Web Service
+image:
+  build: frontend/Dockerfile
+  port: 80
+http:
+  path: '/'
+`
+		invalidManifest = `name: api
+type: Backend Service
+image:
+  build: api/Dockerfile
+  port: 8080
+cpu: 1024
+varibles:
+  LOG_LEVEL: info
+`
+	)
+	testCases := map[string]struct {
+		inSvcName string
+
+		setupMocks func(m *mocks.MockwsSvcReader)
+
+		wantedOutput string
+		wantedErr    error
+	}{
+		"error while reading the manifest": {
+			inSvcName: "frontend",
+			setupMocks: func(m *mocks.MockwsSvcReader) {
+				m.EXPECT().ReadServiceManifest("frontend").Return(nil, errors.New("some error"))
+			},
+			wantedErr: errors.New("read manifest for service frontend: some error"),
+		},
+		"valid manifest": {
+			inSvcName: "frontend",
+			setupMocks: func(m *mocks.MockwsSvcReader) {
+				m.EXPECT().ReadServiceManifest("frontend").Return([]byte(validManifest), nil)
+			},
+		},
+		"reports every problem of every service in the workspace": {
+			setupMocks: func(m *mocks.MockwsSvcReader) {
+				m.EXPECT().ServiceNames().Return([]string{"frontend", "api", "cow"}, nil)
+				m.EXPECT().ReadServiceManifest("frontend").Return([]byte(validManifest), nil)
+				m.EXPECT().ReadServiceManifest("api").Return([]byte(invalidManifest), nil)
+				m.EXPECT().ReadServiceManifest("cow").Return([]byte("name: cow\ntype: Cow Service\n"), nil)
+			},
+			wantedOutput: `copilot/api/manifest.yml:6:6: invalid Fargate task size: "memory" must be between 2048 and 8192 in increments of 1024 for 1024 CPU units, but got 512
+copilot/api/manifest.yml:7:1: unknown field "varibles", did you mean "variables"?
+copilot/cow/manifest.yml: invalid manifest type: Cow Service
+`,
+			wantedErr: errors.New("2 of 3 service manifest(s) are invalid"),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockWs := mocks.NewMockwsSvcReader(ctrl)
+			tc.setupMocks(mockWs)
+			b := &bytes.Buffer{}
+			opts := &validateSvcOpts{
+				validateSvcVars: validateSvcVars{
+					Name: tc.inSvcName,
+				},
+				ws: mockWs,
+				w:  b,
+			}
+
+			// WHEN
+			err := opts.Execute()
+
+			// THEN
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tc.wantedOutput, b.String())
+		})
+	}
+}
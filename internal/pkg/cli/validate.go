@@ -6,6 +6,7 @@ package cli
 import (
 	"errors"
 	"fmt"
+	"path/filepath"
 	"regexp"
 	"strconv"
 	"strings"
@@ -26,6 +27,8 @@ var (
 	errValueBadFormatWithPeriod           = errors.New("value must contain only alphanumeric characters and .-")
 	errDDBValueBadSize                    = errors.New("value must be between 3 and 255 characters in length")
 	errValueBadFormatWithPeriodUnderscore = errors.New("value must contain only alphanumeric characters and ._-")
+	errScheduleBadFormat                  = errors.New(`value must be a "rate()" or "cron()" expression, or one of @hourly, @daily, @weekly, @monthly, @yearly`)
+	errSitePathAbsolute                   = errors.New("value must be a path relative to the root of the workspace")
 )
 
 var fmtErrInvalidStorageType = "invalid storage type %s: must be one of %s"
@@ -81,6 +84,31 @@ func validateSvcPort(val interface{}) error {
 	return nil
 }
 
+func validateSchedule(val interface{}) error {
+	schedule, ok := val.(string)
+	if !ok {
+		return errValueNotAString
+	}
+	if _, err := (manifest.JobTriggerConfig{Schedule: schedule}).ScheduleExpression(); err != nil {
+		return fmt.Errorf("schedule %s is invalid: %w", schedule, errScheduleBadFormat)
+	}
+	return nil
+}
+
+func validateSitePath(val interface{}) error {
+	sitePath, ok := val.(string)
+	if !ok {
+		return errValueNotAString
+	}
+	if sitePath == "" {
+		return errValueEmpty
+	}
+	if filepath.IsAbs(sitePath) {
+		return errSitePathAbsolute
+	}
+	return nil
+}
+
 func validateSvcType(val interface{}) error {
 	svcType, ok := val.(string)
 	if !ok {
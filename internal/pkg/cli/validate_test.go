@@ -218,6 +218,80 @@ func TestValidateStorageType(t *testing.T) {
 
 }
 
+func TestValidateSchedule(t *testing.T) {
+	testCases := map[string]struct {
+		input interface{}
+		want  error
+	}{
+		"predefined schedule": {
+			input: "@hourly",
+			want:  nil,
+		},
+		"rate expression": {
+			input: "rate(5 minutes)",
+			want:  nil,
+		},
+		"cron expression": {
+			input: "cron(0 12 * * ? *)",
+			want:  nil,
+		},
+		"bad schedule": {
+			input: "every minute",
+			want:  errScheduleBadFormat,
+		},
+		"not a string": {
+			input: 123,
+			want:  errValueNotAString,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got := validateSchedule(tc.input)
+
+			if tc.want == nil {
+				require.NoError(t, got)
+			} else {
+				require.True(t, errors.Is(got, tc.want))
+			}
+		})
+	}
+}
+
+func TestValidateSitePath(t *testing.T) {
+	testCases := map[string]struct {
+		input interface{}
+		want  error
+	}{
+		"relative path": {
+			input: "frontend/dist",
+			want:  nil,
+		},
+		"absolute path": {
+			input: "/home/user/frontend/dist",
+			want:  errSitePathAbsolute,
+		},
+		"empty path": {
+			input: "",
+			want:  errValueEmpty,
+		},
+		"not a string": {
+			input: 123,
+			want:  errValueNotAString,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got := validateSitePath(tc.input)
+
+			if tc.want == nil {
+				require.NoError(t, got)
+			} else {
+				require.True(t, errors.Is(got, tc.want))
+			}
+		})
+	}
+}
+
 func TestIsCorrectFormat(t *testing.T) {
 	testCases := map[string]struct {
 		input   string
@@ -4,6 +4,7 @@
 package stack
 
 import (
+	"errors"
 	"fmt"
 	"strconv"
 
@@ -19,6 +20,10 @@ const (
 	BackendServiceContainerPortParamKey = "ContainerPort"
 )
 
+var (
+	errRequestsAutoscalingWithoutLB = errors.New(`"requests" autoscaling is only supported by services behind a load balancer`)
+)
+
 type backendSvcReadParser interface {
 	template.ReadParser
 	ParseBackendService(template.ServiceOpts) (*template.Content, error)
@@ -46,6 +51,7 @@ func NewBackendService(mft *manifest.BackendService, env, app string, rc Runtime
 			env:    env,
 			app:    app,
 			tc:     envManifest.TaskConfig,
+			image:  envManifest.Image.ServiceImage,
 			rc:     rc,
 			parser: parser,
 			addons: addons,
@@ -62,11 +68,63 @@ func (s *BackendService) Template() (string, error) {
 	if err != nil {
 		return "", err
 	}
+	if s.manifest.Count.Autoscaling.Requests != nil {
+		return "", errRequestsAutoscalingWithoutLB
+	}
+	if s.manifest.Count.Autoscaling.QueueDepth != nil {
+		return "", errQueueDepthAutoscalingWithoutQueue
+	}
+	autoscaling, err := autoscalingOpts(s.manifest.Count.Autoscaling)
+	if err != nil {
+		return "", err
+	}
+	if err := validateDependsOn(s.name, s.manifest.Image.HealthCheck != nil, s.manifest.Sidecars); err != nil {
+		return "", err
+	}
+	sidecars, err := convertSidecar(s.manifest.Sidecars, s.manifest.Storage.Volumes, s.rc.EnvFiles)
+	if err != nil {
+		return "", err
+	}
+	secrets, err := convertSecrets(s.manifest.Secrets)
+	if err != nil {
+		return "", err
+	}
+	storage, err := convertStorageOpts(s.manifest.Storage, s.manifest.Sidecars)
+	if err != nil {
+		return "", err
+	}
+	logConfig, err := logConfigOpts(s.manifest.Logging)
+	if err != nil {
+		return "", err
+	}
+	capacityProviders, err := capacityProviderStrategy(s.manifest.Platform)
+	if err != nil {
+		return "", err
+	}
+	if s.manifest.Deployment.IsBlueGreen() {
+		return "", errBlueGreenWithoutLoadBalancer
+	}
+	deploymentConfig, err := deploymentConfigurationOpts(s.manifest.Deployment)
+	if err != nil {
+		return "", err
+	}
 	content, err := s.parser.ParseBackendService(template.ServiceOpts{
-		Variables:   s.manifest.Variables,
-		Secrets:     s.manifest.Secrets,
-		NestedStack: outputs,
-		HealthCheck: s.manifest.Image.HealthCheckOpts(),
+		Variables:               s.manifest.Variables,
+		EnvFileARN:              envFileARN(s.rc.EnvFiles, s.name),
+		EnvFilesPolicy:          envFilesPolicyOpts(s.rc.EnvFiles),
+		Secrets:                 secrets,
+		SecretsPolicy:           secretsPolicyOpts(s.manifest.Secrets, s.manifest.Sidecars),
+		NestedStack:             outputs,
+		Autoscaling:             autoscaling,
+		Sidecars:                sidecars,
+		Storage:                 storage,
+		LogConfig:               logConfig,
+		CapacityProviders:       capacityProviders,
+		Network:                 networkOpts(s.manifest.Network),
+		DeploymentConfiguration: deploymentConfig,
+		EntryPoint:              stringSliceOpts(s.manifest.EntryPoint),
+		Command:                 stringSliceOpts(s.manifest.Command),
+		HealthCheck:             s.manifest.Image.HealthCheckOpts(),
 	})
 	if err != nil {
 		return "", fmt.Errorf("parse backend service template: %w", err)
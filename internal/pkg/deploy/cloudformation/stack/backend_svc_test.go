@@ -7,6 +7,7 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -14,11 +15,13 @@ import (
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/deploy/cloudformation/stack/mocks"
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/manifest"
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/template"
+	"github.com/aws/amazon-ecs-cli-v2/templates"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/cloudformation"
 	"github.com/aws/aws-sdk-go/service/ecs"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
 )
 
 // Test settings for container healthchecks in the backend service manifest.
@@ -126,6 +129,73 @@ func TestBackendService_Template(t *testing.T) {
 	}
 }
 
+// useRepoTemplates reads the templates of the repository while testing, and returns a function that restores the box.
+// Outside of a packed binary, the templates are read from the directory the box resolves to, which is the working directory.
+func useRepoTemplates() func() {
+	box := templates.Box()
+	resolutionDir := box.ResolutionDir
+	box.ResolutionDir = filepath.Join("..", "..", "..", "..", "..", "templates")
+	return func() { box.ResolutionDir = resolutionDir }
+}
+
+func TestBackendService_TemplateWithSecretsManagerSecrets(t *testing.T) {
+	// GIVEN
+	mft, err := manifest.UnmarshalService([]byte(`
+name: frontend
+type: Backend Service
+image:
+  build: ./frontend/Dockerfile
+  port: 8080
+secrets:
+  DB_PASSWORD:
+    from: arn:aws:secretsmanager:us-west-2:123456789012:secret:db-AbCdEf
+    json_key: password
+sidecars:
+  nginx:
+    image: nginx
+    secrets:
+      DB_USER:
+        from: arn:aws:secretsmanager:us-west-2:123456789012:secret:db-AbCdEf
+        version_stage: AWSPREVIOUS
+`))
+	require.NoError(t, err)
+	defer useRepoTemplates()()
+	conf, err := NewBackendService(mft.(*manifest.BackendService), testEnvName, testAppName, RuntimeConfig{})
+	require.NoError(t, err)
+	conf.addons = mockTemplater{err: &addons.ErrDirNotExist{}}
+
+	// WHEN
+	tpl, err := conf.Template()
+
+	// THEN
+	require.NoError(t, err)
+	var parsed struct {
+		Resources struct {
+			TaskDefinition struct {
+				Properties struct {
+					ContainerDefinitions []struct {
+						Secrets []struct {
+							Name      string `yaml:"Name"`
+							ValueFrom string `yaml:"ValueFrom"`
+						} `yaml:"Secrets"`
+					} `yaml:"ContainerDefinitions"`
+				} `yaml:"Properties"`
+			} `yaml:"TaskDefinition"`
+		} `yaml:"Resources"`
+	}
+	require.NoError(t, yaml.Unmarshal([]byte(tpl), &parsed), "the template should be a valid YAML document")
+	var valueFroms []string
+	for _, container := range parsed.Resources.TaskDefinition.Properties.ContainerDefinitions {
+		for _, secret := range container.Secrets {
+			valueFroms = append(valueFroms, secret.ValueFrom)
+		}
+	}
+	require.ElementsMatch(t, []string{
+		"arn:aws:secretsmanager:us-west-2:123456789012:secret:db-AbCdEf:password::",
+		"arn:aws:secretsmanager:us-west-2:123456789012:secret:db-AbCdEf::AWSPREVIOUS:",
+	}, valueFroms)
+}
+
 func TestBackendService_Parameters(t *testing.T) {
 	// GIVEN
 	conf := &BackendService{
@@ -3,6 +3,7 @@ package stack
 import (
 	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/config"
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/deploy"
@@ -25,6 +26,8 @@ const (
 	acmValidationTemplatePath  = "custom-resources/dns-cert-validator.js"
 	dnsDelegationTemplatePath  = "custom-resources/dns-delegation.js"
 	enableLongARNsTemplatePath = "custom-resources/enable-long-arns.js"
+
+	defaultLBIdleTimeout = 60 * time.Second // Default idle timeout of the connections to an application load balancer.
 )
 
 // Parameter keys.
@@ -35,6 +38,8 @@ const (
 	envParamToolsAccountPrincipalKey = "ToolsAccountPrincipalARN"
 	envParamAppDNSKey                = "AppDNSName"
 	envParamAppDNSDelegationRoleKey  = "AppDNSDelegationRole"
+	envParamLBIdleTimeoutKey         = "PublicLoadBalancerIdleTimeout"
+	envParamIncludeNATGatewaysKey    = "IncludeNATGateways"
 )
 
 // Output keys.
@@ -73,10 +78,12 @@ func (e *EnvStackConfig) Template() (string, error) {
 		DNSDelegationLambda       string
 		ACMValidationLambda       string
 		EnableLongARNFormatLambda string
+		ImportVPC                 *deploy.ImportVPCConfig
 	}{
 		dnsLambda.String(),
 		acmLambda.String(),
 		enableLongARNsLambda.String(),
+		e.ImportVPC,
 	})
 	if err != nil {
 		return "", err
@@ -112,7 +119,22 @@ func (e *EnvStackConfig) Parameters() []*cloudformation.Parameter {
 			ParameterKey:   aws.String(envParamAppDNSDelegationRoleKey),
 			ParameterValue: aws.String(e.dnsDelegationRole()),
 		},
+		{
+			ParameterKey:   aws.String(envParamLBIdleTimeoutKey),
+			ParameterValue: aws.String(strconv.Itoa(int(e.lbIdleTimeout().Seconds()))),
+		},
+		{
+			ParameterKey:   aws.String(envParamIncludeNATGatewaysKey),
+			ParameterValue: aws.String(strconv.FormatBool(e.NATGateways)),
+		},
+	}
+}
+
+func (e *EnvStackConfig) lbIdleTimeout() time.Duration {
+	if e.LBIdleTimeout == 0 {
+		return defaultLBIdleTimeout
 	}
+	return e.LBIdleTimeout
 }
 
 // Tags returns the tags that should be applied to the environment CloudFormation stack.
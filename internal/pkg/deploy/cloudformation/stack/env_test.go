@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/config"
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/deploy"
@@ -44,10 +45,44 @@ func TestEnvTemplate(t *testing.T) {
 					DNSDelegationLambda       string
 					ACMValidationLambda       string
 					EnableLongARNFormatLambda string
+					ImportVPC                 *deploy.ImportVPCConfig
 				}{
 					"customresources",
 					"customresources",
 					"customresources",
+					nil,
+				}).Return(&template.Content{Buffer: bytes.NewBufferString("mockTemplate")}, nil)
+				e.parser = m
+			},
+			expectedOutput: mockTemplate,
+		},
+		"should render the template with the imported VPC": {
+			mockDependencies: func(ctrl *gomock.Controller, e *EnvStackConfig) {
+				e.ImportVPC = &deploy.ImportVPCConfig{
+					ID:               "vpc-0123",
+					CIDR:             "10.0.0.0/16",
+					PublicSubnetIDs:  []string{"subnet-0a", "subnet-0b"},
+					PrivateSubnetIDs: []string{"subnet-1a", "subnet-1b"},
+				}
+				m := mocks.NewMockReadParser(ctrl)
+				m.EXPECT().Read(dnsDelegationTemplatePath).Return(&template.Content{Buffer: bytes.NewBufferString("customresources")}, nil)
+				m.EXPECT().Read(acmValidationTemplatePath).Return(&template.Content{Buffer: bytes.NewBufferString("customresources")}, nil)
+				m.EXPECT().Read(enableLongARNsTemplatePath).Return(&template.Content{Buffer: bytes.NewBufferString("customresources")}, nil)
+				m.EXPECT().Parse(EnvTemplatePath, struct {
+					DNSDelegationLambda       string
+					ACMValidationLambda       string
+					EnableLongARNFormatLambda string
+					ImportVPC                 *deploy.ImportVPCConfig
+				}{
+					"customresources",
+					"customresources",
+					"customresources",
+					&deploy.ImportVPCConfig{
+						ID:               "vpc-0123",
+						CIDR:             "10.0.0.0/16",
+						PublicSubnetIDs:  []string{"subnet-0a", "subnet-0b"},
+						PrivateSubnetIDs: []string{"subnet-1a", "subnet-1b"},
+					},
 				}).Return(&template.Content{Buffer: bytes.NewBufferString("mockTemplate")}, nil)
 				e.parser = m
 			},
@@ -83,6 +118,9 @@ func TestEnvParameters(t *testing.T) {
 	deploymentInput := mockDeployEnvironmentInput()
 	deploymentInputWithDNS := mockDeployEnvironmentInput()
 	deploymentInputWithDNS.AppDNSName = "ecs.aws"
+	deploymentInputWithOptions := mockDeployEnvironmentInput()
+	deploymentInputWithOptions.LBIdleTimeout = 5 * time.Minute
+	deploymentInputWithOptions.NATGateways = true
 	testCases := map[string]struct {
 		input *deploy.CreateEnvironmentInput
 		want  []*cloudformation.Parameter
@@ -114,6 +152,14 @@ func TestEnvParameters(t *testing.T) {
 					ParameterKey:   aws.String(envParamAppDNSDelegationRoleKey),
 					ParameterValue: aws.String(""),
 				},
+				{
+					ParameterKey:   aws.String(envParamLBIdleTimeoutKey),
+					ParameterValue: aws.String("60"),
+				},
+				{
+					ParameterKey:   aws.String(envParamIncludeNATGatewaysKey),
+					ParameterValue: aws.String("false"),
+				},
 			},
 		},
 		"with DNS": {
@@ -143,6 +189,51 @@ func TestEnvParameters(t *testing.T) {
 					ParameterKey:   aws.String(envParamAppDNSDelegationRoleKey),
 					ParameterValue: aws.String("arn:aws:iam::000000000:role/project-DNSDelegationRole"),
 				},
+				{
+					ParameterKey:   aws.String(envParamLBIdleTimeoutKey),
+					ParameterValue: aws.String("60"),
+				},
+				{
+					ParameterKey:   aws.String(envParamIncludeNATGatewaysKey),
+					ParameterValue: aws.String("false"),
+				},
+			},
+		},
+		"with the idle timeout of the load balancer and NAT gateways": {
+			input: deploymentInputWithOptions,
+			want: []*cloudformation.Parameter{
+				{
+					ParameterKey:   aws.String(envParamIncludeLBKey),
+					ParameterValue: aws.String(strconv.FormatBool(deploymentInputWithOptions.PublicLoadBalancer)),
+				},
+				{
+					ParameterKey:   aws.String(envParamAppNameKey),
+					ParameterValue: aws.String(deploymentInputWithOptions.AppName),
+				},
+				{
+					ParameterKey:   aws.String(envParamEnvNameKey),
+					ParameterValue: aws.String(deploymentInputWithOptions.Name),
+				},
+				{
+					ParameterKey:   aws.String(envParamToolsAccountPrincipalKey),
+					ParameterValue: aws.String(deploymentInputWithOptions.ToolsAccountPrincipalARN),
+				},
+				{
+					ParameterKey:   aws.String(envParamAppDNSKey),
+					ParameterValue: aws.String(""),
+				},
+				{
+					ParameterKey:   aws.String(envParamAppDNSDelegationRoleKey),
+					ParameterValue: aws.String(""),
+				},
+				{
+					ParameterKey:   aws.String(envParamLBIdleTimeoutKey),
+					ParameterValue: aws.String("300"),
+				},
+				{
+					ParameterKey:   aws.String(envParamIncludeNATGatewaysKey),
+					ParameterValue: aws.String("true"),
+				},
 			},
 		},
 	}
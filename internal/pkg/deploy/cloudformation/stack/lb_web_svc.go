@@ -4,6 +4,7 @@
 package stack
 
 import (
+	"errors"
 	"fmt"
 	"strconv"
 
@@ -17,6 +18,15 @@ import (
 // Template rendering configuration.
 const (
 	lbWebSvcRulePriorityGeneratorPath = "custom-resources/alb-rule-priority-generator.js"
+	lbWebSvcAliasRecordPath           = "custom-resources/alias-record.js"
+)
+
+var (
+	errAliasWithoutHTTPS            = errors.New(`"alias" requires the application to have a domain name`)
+	errBlueGreenWithoutLoadBalancer = errors.New(`blue/green deployments are only supported by load balanced web services`)
+	errNLBAliasWithoutHTTPS         = errors.New(`"nlb.alias" requires the application to have a domain name`)
+	errBlueGreenWithNLB             = errors.New(`blue/green deployments aren't supported by services with a network load balancer`)
+	errBlueGreenWithRequests        = errors.New(`"requests" autoscaling isn't supported by blue/green deployments, since the traffic moves between two target groups`)
 )
 
 // Parameter logical IDs for a load balanced web service.
@@ -25,6 +35,12 @@ const (
 	LBWebServiceContainerPortParamKey   = "ContainerPort"
 	LBWebServiceRulePathParamKey        = "RulePath"
 	LBWebServiceHealthCheckPathParamKey = "HealthCheckPath"
+	LBWebServiceDeployedTaskDefParamKey = "DeployedTaskDefinition"
+)
+
+// Output keys of a load balanced web service.
+const (
+	LBWebServiceOutputNLBEndpoints = "NLBEndpoints" // Comma-separated "host:port/protocol" listeners of the network load balancer, if any.
 )
 
 type loadBalancedWebSvcReadParser interface {
@@ -55,6 +71,7 @@ func NewLoadBalancedWebService(mft *manifest.LoadBalancedWebService, env, app st
 			env:    env,
 			app:    app,
 			tc:     envManifest.TaskConfig,
+			image:  envManifest.Image.ServiceImage,
 			rc:     rc,
 			parser: parser,
 			addons: addons,
@@ -84,15 +101,113 @@ func (s *LoadBalancedWebService) Template() (string, error) {
 	if err != nil {
 		return "", err
 	}
+	var acmValidationLambda, aliasRecordLambda string
+	if s.manifest.Alias != nil {
+		if !s.httpsEnabled {
+			return "", errAliasWithoutHTTPS
+		}
+		acmLambda, err := s.parser.Read(acmValidationTemplatePath)
+		if err != nil {
+			return "", err
+		}
+		acmValidationLambda = acmLambda.String()
+	}
+	if s.manifest.NLB.Alias != nil && !s.httpsEnabled {
+		return "", errNLBAliasWithoutHTTPS
+	}
+	if s.manifest.Alias != nil || s.manifest.NLB.Alias != nil {
+		aliasLambda, err := s.parser.Read(lbWebSvcAliasRecordPath)
+		if err != nil {
+			return "", err
+		}
+		aliasRecordLambda = aliasLambda.String()
+	}
 	outputs, err := s.addonsOutputs()
 	if err != nil {
 		return "", err
 	}
+	if s.manifest.Count.Autoscaling.QueueDepth != nil {
+		return "", errQueueDepthAutoscalingWithoutQueue
+	}
+	autoscaling, err := autoscalingOpts(s.manifest.Count.Autoscaling)
+	if err != nil {
+		return "", err
+	}
+	if err := validateDependsOn(s.name, false, s.manifest.Sidecars); err != nil {
+		return "", err
+	}
+	sidecars, err := convertSidecar(s.manifest.Sidecars, s.manifest.Storage.Volumes, s.rc.EnvFiles)
+	if err != nil {
+		return "", err
+	}
+	secrets, err := convertSecrets(s.manifest.Secrets)
+	if err != nil {
+		return "", err
+	}
+	storage, err := convertStorageOpts(s.manifest.Storage, s.manifest.Sidecars)
+	if err != nil {
+		return "", err
+	}
+	logConfig, err := logConfigOpts(s.manifest.Logging)
+	if err != nil {
+		return "", err
+	}
+	capacityProviders, err := capacityProviderStrategy(s.manifest.Platform)
+	if err != nil {
+		return "", err
+	}
+	deploymentConfig, err := deploymentConfigurationOpts(s.manifest.Deployment)
+	if err != nil {
+		return "", err
+	}
+	blueGreen, err := blueGreenDeploymentOpts(s.manifest.Deployment)
+	if err != nil {
+		return "", err
+	}
+	nlb, err := nlbOpts(s.manifest.NLB, s.name, s.manifest.Image.Port, s.manifest.Sidecars)
+	if err != nil {
+		return "", err
+	}
+	if blueGreen != nil && nlb != nil {
+		return "", errBlueGreenWithNLB
+	}
+	if blueGreen != nil && s.manifest.Count.Autoscaling.Requests != nil {
+		return "", errBlueGreenWithRequests
+	}
+	healthCheck, err := httpHealthCheckOpts(s.manifest.HealthCheck)
+	if err != nil {
+		return "", err
+	}
+	deregistrationDelay, err := deregistrationDelaySeconds(s.manifest.DeregistrationDelay)
+	if err != nil {
+		return "", err
+	}
 	content, err := s.parser.ParseLoadBalancedWebService(template.ServiceOpts{
-		Variables:          s.manifest.Variables,
-		Secrets:            s.manifest.Secrets,
-		NestedStack:        outputs,
-		RulePriorityLambda: rulePriorityLambda.String(),
+		Variables:               s.manifest.Variables,
+		EnvFileARN:              envFileARN(s.rc.EnvFiles, s.name),
+		EnvFilesPolicy:          envFilesPolicyOpts(s.rc.EnvFiles),
+		Secrets:                 secrets,
+		SecretsPolicy:           secretsPolicyOpts(s.manifest.Secrets, s.manifest.Sidecars),
+		NestedStack:             outputs,
+		Autoscaling:             autoscaling,
+		Sidecars:                sidecars,
+		Storage:                 storage,
+		LogConfig:               logConfig,
+		CapacityProviders:       capacityProviders,
+		Network:                 networkOpts(s.manifest.Network),
+		DeploymentConfiguration: deploymentConfig,
+		EntryPoint:              stringSliceOpts(s.manifest.EntryPoint),
+		Command:                 stringSliceOpts(s.manifest.Command),
+		RulePriorityLambda:      rulePriorityLambda.String(),
+		Alias:                   s.manifest.Alias,
+		ACMValidationLambda:     acmValidationLambda,
+		AliasRecordLambda:       aliasRecordLambda,
+		HTTPHealthCheck:         healthCheck,
+		DeregistrationDelay:     deregistrationDelay,
+		Stickiness:              aws.BoolValue(s.manifest.Stickiness),
+		ProtocolVersion:         aws.StringValue(s.manifest.ProtocolVersion),
+		BlueGreen:               blueGreen,
+		NLB:                     nlb,
 	})
 	if err != nil {
 		return "", err
@@ -102,7 +217,7 @@ func (s *LoadBalancedWebService) Template() (string, error) {
 
 // Parameters returns the list of CloudFormation parameters used by the template.
 func (s *LoadBalancedWebService) Parameters() []*cloudformation.Parameter {
-	return append(s.svc.Parameters(), []*cloudformation.Parameter{
+	params := append(s.svc.Parameters(), []*cloudformation.Parameter{
 		{
 			ParameterKey:   aws.String(LBWebServiceContainerPortParamKey),
 			ParameterValue: aws.String(strconv.FormatUint(uint64(s.manifest.Image.Port), 10)),
@@ -113,13 +228,20 @@ func (s *LoadBalancedWebService) Parameters() []*cloudformation.Parameter {
 		},
 		{
 			ParameterKey:   aws.String(LBWebServiceHealthCheckPathParamKey),
-			ParameterValue: aws.String(s.manifest.HealthCheckPath),
+			ParameterValue: aws.String(s.manifest.HealthCheck.Path()),
 		},
 		{
 			ParameterKey:   aws.String(LBWebServiceHTTPSParamKey),
 			ParameterValue: aws.String(strconv.FormatBool(s.httpsEnabled)),
 		},
 	}...)
+	if s.manifest.Deployment.IsBlueGreen() {
+		params = append(params, &cloudformation.Parameter{
+			ParameterKey:   aws.String(LBWebServiceDeployedTaskDefParamKey),
+			ParameterValue: aws.String(s.rc.DeployedTaskDefinition),
+		})
+	}
+	return params
 }
 
 // SerializedParameters returns the CloudFormation stack's parameters serialized
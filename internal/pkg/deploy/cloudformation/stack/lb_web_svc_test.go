@@ -135,6 +135,111 @@ func TestLoadBalancedWebService_Template(t *testing.T) {
 			wantedTemplate: "",
 			wantedError:    errors.New("some error"),
 		},
+		"alias without a domain name": {
+			mockDependencies: func(t *testing.T, ctrl *gomock.Controller, c *LoadBalancedWebService) {
+				m := mocks.NewMockloadBalancedWebSvcReadParser(ctrl)
+				m.EXPECT().Read(lbWebSvcRulePriorityGeneratorPath).Return(&template.Content{Buffer: bytes.NewBufferString("lambda")}, nil)
+				mft := *testLBWebServiceManifest
+				mft.Alias = aws.String("api.phonetool.example.com")
+				c.manifest = &mft
+				c.parser = m
+			},
+			wantedTemplate: "",
+			wantedError:    errAliasWithoutHTTPS,
+		},
+		"render template with alias": {
+			mockDependencies: func(t *testing.T, ctrl *gomock.Controller, c *LoadBalancedWebService) {
+				m := mocks.NewMockloadBalancedWebSvcReadParser(ctrl)
+				m.EXPECT().Read(lbWebSvcRulePriorityGeneratorPath).Return(&template.Content{Buffer: bytes.NewBufferString("lambda")}, nil)
+				m.EXPECT().Read(acmValidationTemplatePath).Return(&template.Content{Buffer: bytes.NewBufferString("acm")}, nil)
+				m.EXPECT().Read(lbWebSvcAliasRecordPath).Return(&template.Content{Buffer: bytes.NewBufferString("alias")}, nil)
+				m.EXPECT().ParseLoadBalancedWebService(template.ServiceOpts{
+					RulePriorityLambda:  "lambda",
+					Alias:               aws.String("api.phonetool.example.com"),
+					ACMValidationLambda: "acm",
+					AliasRecordLambda:   "alias",
+				}).Return(&template.Content{Buffer: bytes.NewBufferString("template")}, nil)
+
+				mft := *testLBWebServiceManifest
+				mft.Alias = aws.String("api.phonetool.example.com")
+				c.manifest = &mft
+				c.httpsEnabled = true
+				c.parser = m
+				c.svc.addons = mockTemplater{err: &addons.ErrDirNotExist{}}
+			},
+
+			wantedTemplate: "template",
+		},
+		"network load balancer alias without a domain name": {
+			mockDependencies: func(t *testing.T, ctrl *gomock.Controller, c *LoadBalancedWebService) {
+				m := mocks.NewMockloadBalancedWebSvcReadParser(ctrl)
+				m.EXPECT().Read(lbWebSvcRulePriorityGeneratorPath).Return(&template.Content{Buffer: bytes.NewBufferString("lambda")}, nil)
+				mft := *testLBWebServiceManifest
+				mft.NLB = manifest.NetworkLoadBalancerConfiguration{
+					Listeners: []manifest.NetworkLoadBalancerListener{{Port: "1883/tcp"}},
+					Alias:     aws.String("mqtt.phonetool.example.com"),
+				}
+				c.manifest = &mft
+				c.parser = m
+			},
+			wantedTemplate: "",
+			wantedError:    errNLBAliasWithoutHTTPS,
+		},
+		"render template with a network load balancer": {
+			mockDependencies: func(t *testing.T, ctrl *gomock.Controller, c *LoadBalancedWebService) {
+				m := mocks.NewMockloadBalancedWebSvcReadParser(ctrl)
+				m.EXPECT().Read(lbWebSvcRulePriorityGeneratorPath).Return(&template.Content{Buffer: bytes.NewBufferString("lambda")}, nil)
+				m.EXPECT().Read(lbWebSvcAliasRecordPath).Return(&template.Content{Buffer: bytes.NewBufferString("alias")}, nil)
+				m.EXPECT().ParseLoadBalancedWebService(template.ServiceOpts{
+					RulePriorityLambda: "lambda",
+					AliasRecordLambda:  "alias",
+					NLB: &template.NetworkLoadBalancerOpts{
+						Listeners: []*template.NetworkLoadBalancerListenerOpts{
+							{
+								Port:           1883,
+								Protocol:       "TCP",
+								TargetPort:     1883,
+								TargetProtocol: "TCP",
+							},
+						},
+						PortMappings: []*template.PortMappingOpts{{Port: 1883, Protocol: "tcp"}},
+						Ingress:      []*template.NLBIngressOpts{{Port: 1883, Protocol: "tcp"}},
+						Alias:        aws.String("mqtt.phonetool.example.com"),
+					},
+				}).Return(&template.Content{Buffer: bytes.NewBufferString("template")}, nil)
+
+				mft := *testLBWebServiceManifest
+				mft.NLB = manifest.NetworkLoadBalancerConfiguration{
+					Listeners: []manifest.NetworkLoadBalancerListener{{Port: "1883/tcp"}},
+					Alias:     aws.String("mqtt.phonetool.example.com"),
+				}
+				c.manifest = &mft
+				c.httpsEnabled = true
+				c.parser = m
+				c.svc.addons = mockTemplater{err: &addons.ErrDirNotExist{}}
+			},
+
+			wantedTemplate: "template",
+		},
+		"blue/green deployments with requests autoscaling": {
+			mockDependencies: func(t *testing.T, ctrl *gomock.Controller, c *LoadBalancedWebService) {
+				m := mocks.NewMockloadBalancedWebSvcReadParser(ctrl)
+				m.EXPECT().Read(lbWebSvcRulePriorityGeneratorPath).Return(&template.Content{Buffer: bytes.NewBufferString("lambda")}, nil)
+				mft := *testLBWebServiceManifest
+				mft.Count = manifest.Count{
+					Autoscaling: manifest.Autoscaling{
+						Range:    "1-10",
+						Requests: aws.Int(1000),
+					},
+				}
+				mft.Deployment.Strategy = aws.String(manifest.BlueGreenDeploymentStrategy)
+				c.manifest = &mft
+				c.parser = m
+				c.svc.addons = mockTemplater{err: &addons.ErrDirNotExist{}}
+			},
+			wantedTemplate: "",
+			wantedError:    errBlueGreenWithRequests,
+		},
 		"render template without addons": {
 			mockDependencies: func(t *testing.T, ctrl *gomock.Controller, c *LoadBalancedWebService) {
 				m := mocks.NewMockloadBalancedWebSvcReadParser(ctrl)
@@ -322,6 +427,35 @@ func TestLoadBalancedWebService_Parameters(t *testing.T) {
 	}
 }
 
+func TestLoadBalancedWebService_Parameters_BlueGreen(t *testing.T) {
+	// GIVEN
+	mft := *testLBWebServiceManifest
+	mft.Deployment.Strategy = aws.String(manifest.BlueGreenDeploymentStrategy)
+	conf := &LoadBalancedWebService{
+		svc: &svc{
+			name: mft.Name,
+			env:  testEnvName,
+			app:  testAppName,
+			tc:   mft.TaskConfig,
+			rc: RuntimeConfig{
+				ImageRepoURL:           testImageRepoURL,
+				ImageTag:               testImageTag,
+				DeployedTaskDefinition: "arn:aws:ecs:us-west-2:123456789012:task-definition/phonetool-test-frontend:1",
+			},
+		},
+		manifest: &mft,
+	}
+
+	// WHEN
+	params := conf.Parameters()
+
+	// THEN
+	require.Contains(t, params, &cloudformation.Parameter{
+		ParameterKey:   aws.String(LBWebServiceDeployedTaskDefParamKey),
+		ParameterValue: aws.String("arn:aws:ecs:us-west-2:123456789012:task-definition/phonetool-test-frontend:1"),
+	})
+}
+
 func TestLoadBalancedWebService_SerializedParameters(t *testing.T) {
 	testCases := map[string]struct {
 		mockDependencies func(ctrl *gomock.Controller, c *LoadBalancedWebService)
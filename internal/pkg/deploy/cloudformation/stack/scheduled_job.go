@@ -0,0 +1,116 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package stack
+
+import (
+	"fmt"
+
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/addons"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/manifest"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/template"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudformation"
+)
+
+// Parameter logical IDs for a scheduled job.
+const (
+	ScheduledJobScheduleParamKey = "Schedule"
+)
+
+type scheduledJobReadParser interface {
+	template.ReadParser
+	ParseScheduledJob(template.ServiceOpts) (*template.Content, error)
+}
+
+// ScheduledJob represents the configuration needed to create a CloudFormation stack from a scheduled job manifest.
+type ScheduledJob struct {
+	*svc
+	manifest *manifest.ScheduledJob
+
+	parser scheduledJobReadParser
+}
+
+// NewScheduledJob creates a new ScheduledJob stack from a manifest file.
+func NewScheduledJob(mft *manifest.ScheduledJob, env, app string, rc RuntimeConfig) (*ScheduledJob, error) {
+	parser := template.New()
+	addons, err := addons.New(mft.Name)
+	if err != nil {
+		return nil, fmt.Errorf("new addons: %w", err)
+	}
+	envManifest := mft.ApplyEnv(env) // Apply environment overrides to the manifest values.
+	return &ScheduledJob{
+		svc: &svc{
+			name:   mft.Name,
+			env:    env,
+			app:    app,
+			tc:     envManifest.TaskConfig,
+			image:  envManifest.Image,
+			rc:     rc,
+			parser: parser,
+			addons: addons,
+		},
+		manifest: envManifest,
+
+		parser: parser,
+	}, nil
+}
+
+// Template returns the CloudFormation template for the scheduled job.
+func (j *ScheduledJob) Template() (string, error) {
+	if _, err := j.manifest.On.ScheduleExpression(); err != nil {
+		return "", err
+	}
+	outputs, err := j.addonsOutputs()
+	if err != nil {
+		return "", err
+	}
+	storage, err := convertStorageOpts(j.manifest.Storage, nil)
+	if err != nil {
+		return "", err
+	}
+	secrets, err := convertSecrets(j.manifest.Secrets)
+	if err != nil {
+		return "", err
+	}
+	content, err := j.parser.ParseScheduledJob(template.ServiceOpts{
+		Variables:      j.manifest.Variables,
+		EnvFileARN:     envFileARN(j.rc.EnvFiles, j.name),
+		EnvFilesPolicy: envFilesPolicyOpts(j.rc.EnvFiles),
+		Secrets:        secrets,
+		SecretsPolicy:  secretsPolicyOpts(j.manifest.Secrets, nil),
+		NestedStack:    outputs,
+		Storage:        storage,
+		Network:        networkOpts(j.manifest.Network),
+		EntryPoint:     stringSliceOpts(j.manifest.EntryPoint),
+		Command:        stringSliceOpts(j.manifest.Command),
+		StateMachine:   stateMachineOpts(j.manifest.JobFailureHandlerConfig),
+	})
+	if err != nil {
+		return "", fmt.Errorf("parse scheduled job template: %w", err)
+	}
+	return content.String(), nil
+}
+
+// Parameters returns the list of CloudFormation parameters used by the template.
+func (j *ScheduledJob) Parameters() []*cloudformation.Parameter {
+	var params []*cloudformation.Parameter
+	for _, param := range j.svc.Parameters() {
+		if aws.StringValue(param.ParameterKey) == ServiceTaskCountParamKey {
+			// A job runs a single task every time it's triggered.
+			continue
+		}
+		params = append(params, param)
+	}
+	schedule, _ := j.manifest.On.ScheduleExpression() // The schedule is validated while rendering the template.
+	return append(params, &cloudformation.Parameter{
+		ParameterKey:   aws.String(ScheduledJobScheduleParamKey),
+		ParameterValue: aws.String(schedule),
+	})
+}
+
+// SerializedParameters returns the CloudFormation stack's parameters serialized
+// to a YAML document annotated with comments for readability to users.
+func (j *ScheduledJob) SerializedParameters() (string, error) {
+	return j.svc.templateConfiguration(j)
+}
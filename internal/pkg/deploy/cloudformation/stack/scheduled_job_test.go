@@ -0,0 +1,199 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package stack
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/addons"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/deploy/cloudformation/stack/mocks"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/manifest"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/template"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func testScheduledJobManifest(schedule string) *manifest.ScheduledJob {
+	timeout := 90 * time.Minute
+	return manifest.NewScheduledJob(&manifest.ScheduledJobProps{
+		ServiceProps: &manifest.ServiceProps{
+			Name:       "report",
+			Dockerfile: "./report/Dockerfile",
+		},
+		Schedule: schedule,
+		Timeout:  &timeout,
+		Retries:  aws.Int(3),
+	})
+}
+
+func TestScheduledJob_Template(t *testing.T) {
+	testCases := map[string]struct {
+		inSchedule       string
+		mockDependencies func(t *testing.T, ctrl *gomock.Controller, job *ScheduledJob)
+
+		wantedTemplate string
+		wantedErr      error
+	}{
+		"invalid schedule": {
+			inSchedule:       "every day",
+			mockDependencies: func(t *testing.T, ctrl *gomock.Controller, job *ScheduledJob) {},
+			wantedErr:        errors.New(`schedule every day must be a "rate()" or "cron()" expression, or one of @hourly, @daily, @weekly, @monthly, @yearly`),
+		},
+		"unexpected addons parsing error": {
+			inSchedule: "@daily",
+			mockDependencies: func(t *testing.T, ctrl *gomock.Controller, job *ScheduledJob) {
+				job.addons = mockTemplater{err: errors.New("some error")}
+			},
+			wantedErr: fmt.Errorf("generate addons template for service %s: %w", "report", errors.New("some error")),
+		},
+		"failed parsing job template": {
+			inSchedule: "@daily",
+			mockDependencies: func(t *testing.T, ctrl *gomock.Controller, job *ScheduledJob) {
+				m := mocks.NewMockscheduledJobReadParser(ctrl)
+				m.EXPECT().ParseScheduledJob(gomock.Any()).Return(nil, errors.New("some error"))
+				job.parser = m
+				job.addons = mockTemplater{err: &addons.ErrDirNotExist{}}
+			},
+			wantedErr: fmt.Errorf("parse scheduled job template: %w", errors.New("some error")),
+		},
+		"render template": {
+			inSchedule: "rate(1 hour)",
+			mockDependencies: func(t *testing.T, ctrl *gomock.Controller, job *ScheduledJob) {
+				m := mocks.NewMockscheduledJobReadParser(ctrl)
+				m.EXPECT().ParseScheduledJob(template.ServiceOpts{
+					StateMachine: &template.StateMachineOpts{
+						Timeout: aws.Int(5400),
+						Retries: aws.Int(3),
+					},
+				}).Return(&template.Content{Buffer: bytes.NewBufferString("template")}, nil)
+				job.parser = m
+				job.addons = mockTemplater{err: &addons.ErrDirNotExist{}}
+			},
+			wantedTemplate: "template",
+		},
+		"render template with the tasks in the private subnets": {
+			inSchedule: "rate(1 hour)",
+			mockDependencies: func(t *testing.T, ctrl *gomock.Controller, job *ScheduledJob) {
+				job.manifest.Network = manifest.NetworkConfig{
+					VPC: manifest.VPCConfig{
+						Placement:      aws.String(manifest.PrivateSubnetPlacement),
+						SecurityGroups: []string{"sg-1234"},
+					},
+				}
+				m := mocks.NewMockscheduledJobReadParser(ctrl)
+				m.EXPECT().ParseScheduledJob(template.ServiceOpts{
+					Network: &template.NetworkOpts{
+						AssignPublicIP: template.DisablePublicIP,
+						SubnetsType:    template.PrivateSubnetsPlacement,
+						SecurityGroups: []string{"sg-1234"},
+					},
+					StateMachine: &template.StateMachineOpts{
+						Timeout: aws.Int(5400),
+						Retries: aws.Int(3),
+					},
+				}).Return(&template.Content{Buffer: bytes.NewBufferString("template")}, nil)
+				job.parser = m
+				job.addons = mockTemplater{err: &addons.ErrDirNotExist{}}
+			},
+			wantedTemplate: "template",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mft := testScheduledJobManifest(tc.inSchedule)
+			conf := &ScheduledJob{
+				svc: &svc{
+					name: mft.Name,
+					env:  testEnvName,
+					app:  testAppName,
+					tc:   mft.TaskConfig,
+				},
+				manifest: mft,
+			}
+			tc.mockDependencies(t, ctrl, conf)
+
+			// WHEN
+			template, err := conf.Template()
+
+			// THEN
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.wantedTemplate, template)
+			}
+		})
+	}
+}
+
+func TestScheduledJob_Parameters(t *testing.T) {
+	// GIVEN
+	mft := testScheduledJobManifest("@daily")
+	conf := &ScheduledJob{
+		svc: &svc{
+			name: mft.Name,
+			env:  testEnvName,
+			app:  testAppName,
+			tc:   mft.TaskConfig,
+			rc: RuntimeConfig{
+				ImageRepoURL: testImageRepoURL,
+				ImageTag:     testImageTag,
+			},
+		},
+		manifest: mft,
+	}
+
+	// WHEN
+	params := conf.Parameters()
+
+	// THEN
+	require.ElementsMatch(t, []*cloudformation.Parameter{
+		{
+			ParameterKey:   aws.String(ServiceAppNameParamKey),
+			ParameterValue: aws.String("phonetool"),
+		},
+		{
+			ParameterKey:   aws.String(ServiceEnvNameParamKey),
+			ParameterValue: aws.String("test"),
+		},
+		{
+			ParameterKey:   aws.String(ServiceNameParamKey),
+			ParameterValue: aws.String("report"),
+		},
+		{
+			ParameterKey:   aws.String(ServiceContainerImageParamKey),
+			ParameterValue: aws.String("12345.dkr.ecr.us-west-2.amazonaws.com/phonetool/frontend:manual-bf3678c"),
+		},
+		{
+			ParameterKey:   aws.String(ServiceTaskCPUParamKey),
+			ParameterValue: aws.String("256"),
+		},
+		{
+			ParameterKey:   aws.String(ServiceTaskMemoryParamKey),
+			ParameterValue: aws.String("512"),
+		},
+		{
+			ParameterKey:   aws.String(ServiceLogRetentionParamKey),
+			ParameterValue: aws.String("30"),
+		},
+		{
+			ParameterKey:   aws.String(ServiceAddonsTemplateURLParamKey),
+			ParameterValue: aws.String(""),
+		},
+		{
+			ParameterKey:   aws.String(ScheduledJobScheduleParamKey),
+			ParameterValue: aws.String("rate(1 day)"),
+		},
+	}, params)
+}
@@ -0,0 +1,101 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package stack
+
+import (
+	"fmt"
+
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/manifest"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/template"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudformation"
+)
+
+// Output keys of a static site.
+const (
+	StaticSiteOutputBucketName             = "BucketName"
+	StaticSiteOutputDistributionID         = "DistributionId"
+	StaticSiteOutputDistributionDomainName = "DistributionDomainName"
+)
+
+type staticSiteReadParser interface {
+	template.ReadParser
+	ParseStaticSite(template.ServiceOpts) (*template.Content, error)
+}
+
+// StaticSite represents the configuration needed to create a CloudFormation stack from a static site manifest.
+type StaticSite struct {
+	*svc
+	manifest *manifest.StaticSite
+
+	parser staticSiteReadParser
+}
+
+// NewStaticSite creates a new StaticSite stack from a manifest file.
+func NewStaticSite(mft *manifest.StaticSite, env, app string, rc RuntimeConfig) (*StaticSite, error) {
+	parser := template.New()
+	return &StaticSite{
+		svc: &svc{
+			name:   mft.Name,
+			env:    env,
+			app:    app,
+			rc:     rc,
+			parser: parser,
+		},
+		manifest: mft.ApplyEnv(env), // Apply environment overrides to the manifest values.
+
+		parser: parser,
+	}, nil
+}
+
+// Template returns the CloudFormation template for the static site.
+func (s *StaticSite) Template() (string, error) {
+	var acmValidationLambda, aliasRecordLambda string
+	if s.manifest.Alias != nil {
+		acmLambda, err := s.parser.Read(acmValidationTemplatePath)
+		if err != nil {
+			return "", err
+		}
+		acmValidationLambda = acmLambda.String()
+		aliasLambda, err := s.parser.Read(lbWebSvcAliasRecordPath)
+		if err != nil {
+			return "", err
+		}
+		aliasRecordLambda = aliasLambda.String()
+	}
+	content, err := s.parser.ParseStaticSite(template.ServiceOpts{
+		Alias:               s.manifest.Alias,
+		ACMValidationLambda: acmValidationLambda,
+		AliasRecordLambda:   aliasRecordLambda,
+	})
+	if err != nil {
+		return "", fmt.Errorf("parse static site template: %w", err)
+	}
+	return content.String(), nil
+}
+
+// Parameters returns the list of CloudFormation parameters used by the template.
+// A static site doesn't run any container, so only the parameters that identify the service are kept.
+func (s *StaticSite) Parameters() []*cloudformation.Parameter {
+	return []*cloudformation.Parameter{
+		{
+			ParameterKey:   aws.String(ServiceAppNameParamKey),
+			ParameterValue: aws.String(s.app),
+		},
+		{
+			ParameterKey:   aws.String(ServiceEnvNameParamKey),
+			ParameterValue: aws.String(s.env),
+		},
+		{
+			ParameterKey:   aws.String(ServiceNameParamKey),
+			ParameterValue: aws.String(s.name),
+		},
+	}
+}
+
+// SerializedParameters returns the CloudFormation stack's parameters serialized
+// to a YAML document annotated with comments for readability to users.
+func (s *StaticSite) SerializedParameters() (string, error) {
+	return s.svc.templateConfiguration(s)
+}
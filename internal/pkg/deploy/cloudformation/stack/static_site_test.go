@@ -0,0 +1,127 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package stack
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/deploy/cloudformation/stack/mocks"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/manifest"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/template"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func testStaticSiteManifest() *manifest.StaticSite {
+	return manifest.NewStaticSite(manifest.StaticSiteProps{
+		Name: "website",
+		Path: "website/dist",
+	})
+}
+
+func TestStaticSite_Template(t *testing.T) {
+	testCases := map[string]struct {
+		mockManifest     func(mft *manifest.StaticSite)
+		mockDependencies func(ctrl *gomock.Controller, site *StaticSite)
+
+		wantedTemplate string
+		wantedErr      error
+	}{
+		"failed parsing template": {
+			mockDependencies: func(ctrl *gomock.Controller, site *StaticSite) {
+				m := mocks.NewMockstaticSiteReadParser(ctrl)
+				m.EXPECT().ParseStaticSite(gomock.Any()).Return(nil, errors.New("some error"))
+				site.parser = m
+			},
+			wantedErr: fmt.Errorf("parse static site template: %w", errors.New("some error")),
+		},
+		"render template without an alias": {
+			mockDependencies: func(ctrl *gomock.Controller, site *StaticSite) {
+				m := mocks.NewMockstaticSiteReadParser(ctrl)
+				m.EXPECT().ParseStaticSite(template.ServiceOpts{}).Return(&template.Content{Buffer: bytes.NewBufferString("template")}, nil)
+				site.parser = m
+			},
+			wantedTemplate: "template",
+		},
+		"render template with an alias": {
+			mockManifest: func(mft *manifest.StaticSite) {
+				mft.Alias = aws.String("www.phonetool.example.com")
+			},
+			mockDependencies: func(ctrl *gomock.Controller, site *StaticSite) {
+				m := mocks.NewMockstaticSiteReadParser(ctrl)
+				m.EXPECT().Read(acmValidationTemplatePath).Return(&template.Content{Buffer: bytes.NewBufferString("acm")}, nil)
+				m.EXPECT().Read(lbWebSvcAliasRecordPath).Return(&template.Content{Buffer: bytes.NewBufferString("alias")}, nil)
+				m.EXPECT().ParseStaticSite(template.ServiceOpts{
+					Alias:               aws.String("www.phonetool.example.com"),
+					ACMValidationLambda: "acm",
+					AliasRecordLambda:   "alias",
+				}).Return(&template.Content{Buffer: bytes.NewBufferString("template")}, nil)
+				site.parser = m
+			},
+			wantedTemplate: "template",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mft := testStaticSiteManifest()
+			if tc.mockManifest != nil {
+				tc.mockManifest(mft)
+			}
+			conf := &StaticSite{
+				svc: &svc{
+					name: mft.Name,
+					env:  testEnvName,
+					app:  testAppName,
+				},
+				manifest: mft,
+			}
+			tc.mockDependencies(ctrl, conf)
+
+			// WHEN
+			template, err := conf.Template()
+
+			// THEN
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.wantedTemplate, template)
+			}
+		})
+	}
+}
+
+func TestStaticSite_Parameters(t *testing.T) {
+	// GIVEN
+	site, err := NewStaticSite(testStaticSiteManifest(), testEnvName, testAppName, RuntimeConfig{})
+	require.NoError(t, err)
+
+	// WHEN
+	params := site.Parameters()
+
+	// THEN
+	require.Equal(t, []*cloudformation.Parameter{
+		{
+			ParameterKey:   aws.String(ServiceAppNameParamKey),
+			ParameterValue: aws.String(testAppName),
+		},
+		{
+			ParameterKey:   aws.String(ServiceEnvNameParamKey),
+			ParameterValue: aws.String(testEnvName),
+		},
+		{
+			ParameterKey:   aws.String(ServiceNameParamKey),
+			ParameterValue: aws.String("website"),
+		},
+	}, params)
+}
@@ -6,6 +6,7 @@ package stack
 import (
 	"errors"
 	"fmt"
+	"regexp"
 	"strconv"
 
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/addons"
@@ -33,6 +34,29 @@ const (
 	ServiceAddonsTemplateURLParamKey = "AddonsTemplateURL"
 )
 
+// Output keys common across services.
+const (
+	ServiceOutputEnvFileARN = "EnvFileARN" // Only exists if the main container has an environment file.
+)
+
+// envImportPattern matches the values exported by the environment stack and imported by a service template,
+// such as "Fn::ImportValue: !Sub '${AppName}-${EnvName}-VpcId'".
+var envImportPattern = regexp.MustCompile(`ImportValue:\s*!Sub\s*['"]\$\{AppName\}-\$\{EnvName\}-(\w+)['"]`)
+
+// ImportedEnvOutputs returns the names of the outputs of the environment stack that the service template imports,
+// such as "VpcId", in the order of their first import. The exports are named "{app}-{env}-{output}".
+func ImportedEnvOutputs(tpl string) []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, match := range envImportPattern.FindAllStringSubmatch(tpl, -1) {
+		if name := match[1]; !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
 // RuntimeConfig represents configuration that's defined outside of the manifest file
 // that is needed to create a CloudFormation stack.
 type RuntimeConfig struct {
@@ -40,6 +64,9 @@ type RuntimeConfig struct {
 	ImageTag          string            // ImageTag is the container image's unique tag.
 	AddonsTemplateURL string            // Optional. S3 object URL for the addons template.
 	AdditionalTags    map[string]string // AdditionalTags are labels applied to resources in the service stack.
+	EnvFiles          map[string]string // Optional. S3 objects of the uploaded environment files as "bucket/key", keyed by the container name.
+	// Optional. Task definition that the ECS service of a blue/green service keeps, since only CodeDeploy can replace it.
+	DeployedTaskDefinition string
 }
 
 type templater interface {
@@ -47,11 +74,12 @@ type templater interface {
 }
 
 type svc struct {
-	name string
-	env  string
-	app  string
-	tc   manifest.TaskConfig
-	rc   RuntimeConfig
+	name  string
+	env   string
+	app   string
+	tc    manifest.TaskConfig
+	image manifest.ServiceImage
+	rc    RuntimeConfig
 
 	parser template.Parser
 	addons templater
@@ -79,7 +107,7 @@ func (s *svc) Parameters() []*cloudformation.Parameter {
 		},
 		{
 			ParameterKey:   aws.String(ServiceContainerImageParamKey),
-			ParameterValue: aws.String(fmt.Sprintf("%s:%s", s.rc.ImageRepoURL, s.rc.ImageTag)),
+			ParameterValue: aws.String(s.containerImage()),
 		},
 		{
 			ParameterKey:   aws.String(ServiceTaskCPUParamKey),
@@ -91,7 +119,7 @@ func (s *svc) Parameters() []*cloudformation.Parameter {
 		},
 		{
 			ParameterKey:   aws.String(ServiceTaskCountParamKey),
-			ParameterValue: aws.String(strconv.Itoa(*s.tc.Count)),
+			ParameterValue: aws.String(strconv.Itoa(desiredCount(s.tc.Count))),
 		},
 		{
 			ParameterKey:   aws.String(ServiceLogRetentionParamKey),
@@ -104,6 +132,15 @@ func (s *svc) Parameters() []*cloudformation.Parameter {
 	}
 }
 
+// containerImage returns the URI of the container image to deploy.
+// A pre-built image takes precedence over the image pushed to the ECR repository.
+func (s *svc) containerImage() string {
+	if s.image.Location != "" {
+		return s.image.Location
+	}
+	return fmt.Sprintf("%s:%s", s.rc.ImageRepoURL, s.rc.ImageTag)
+}
+
 // Tags returns the list of tags to apply to the CloudFormation stack.
 func (s *svc) Tags() []*cloudformation.Tag {
 	return mergeAndFlattenTags(s.rc.AdditionalTags, map[string]string{
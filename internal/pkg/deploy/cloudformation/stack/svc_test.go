@@ -0,0 +1,59 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package stack
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportedEnvOutputs(t *testing.T) {
+	testCases := map[string]struct {
+		inTemplate string
+
+		wanted []string
+	}{
+		"without imports": {
+			inTemplate: `Resources:
+  LogGroup:
+    Type: AWS::Logs::LogGroup
+    Properties:
+      LogGroupName: !Sub '/copilot/${AppName}-${EnvName}-${ServiceName}'`,
+		},
+		"returns each imported output once": {
+			inTemplate: `Resources:
+  Service:
+    Properties:
+      Cluster:
+        Fn::ImportValue:
+          !Sub '${AppName}-${EnvName}-ClusterId'
+      NetworkConfiguration:
+        AwsvpcConfiguration:
+          Subnets:
+            - Fn::Select:
+              - 0
+              - Fn::Split:
+                - ','
+                - Fn::ImportValue: !Sub '${AppName}-${EnvName}-PublicSubnets'
+            - Fn::Select:
+              - 1
+              - Fn::Split:
+                - ','
+                - Fn::ImportValue: !Sub '${AppName}-${EnvName}-PublicSubnets'
+  Ingress:
+    Properties:
+      CidrIp:
+        Fn::ImportValue:
+          !Sub "${AppName}-${EnvName}-VpcCIDR"`,
+			wanted: []string{"ClusterId", "PublicSubnets", "VpcCIDR"},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.wanted, ImportedEnvOutputs(tc.inTemplate))
+		})
+	}
+}
@@ -0,0 +1,959 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package stack
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/manifest"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/template"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ecs"
+)
+
+var (
+	errAutoscalingRangeRequired            = errors.New(`"range" is required when autoscaling the number of tasks`)
+	errSidecarHealthCheckCommandRequired   = errors.New(`"command" is required for the healthcheck`)
+	errMultipleLogDestinations             = errors.New(`only one of "firehose", "cloudwatch" and "elasticsearch" can be specified as the log destination`)
+	errLogDestinationRequired              = errors.New(`either "destination" or "config_file" is required to route logs`)
+	errCapacityProviderNameRequired        = errors.New(`"name" is required for every capacity provider`)
+	errMultipleCapacityProviderBases       = errors.New(`only one capacity provider can have a "base"`)
+	errCapacityProviderWeightRequired      = errors.New(`at least one capacity provider must have a "weight" greater than 0`)
+	errDeploymentCannotProgress            = errors.New(`"maximum_percent" must be greater than "minimum_healthy_percent" for the deployment to replace tasks`)
+	errCircuitBreakerRollbackWithoutEnable = errors.New(`"rollback" requires the circuit breaker to be enabled with "enable"`)
+	errCircuitBreakerWithBlueGreen         = errors.New(`"circuit_breaker" can't be used with blue/green deployments`)
+	errBlueGreenWithoutStrategy            = errors.New(`"blue_green" requires the deployment "strategy" to be "blue_green"`)
+	errTrafficShiftingStepsWithAllAtOnce   = errors.New(`"percentage" and "interval" require canary or linear traffic shifting`)
+	errNLBAliasWithoutListeners            = errors.New(`"nlb.alias" requires the network load balancer to have listeners`)
+)
+
+// Defaults of the FireLens sidecar that routes the logs of the main container.
+const (
+	firelensContainerName     = "firelens_log_router"
+	defaultFluentbitImage     = "amazon/aws-for-fluent-bit:latest"
+	defaultLogStreamPrefix    = "copilot/"
+	defaultElasticsearchPort  = 443
+	defaultFirelensLogsRegion = "!Ref AWS::Region"
+)
+
+// Conditions of a container dependency that restrict the container it depends on.
+const (
+	dependsOnComplete = "COMPLETE"
+	dependsOnSuccess  = "SUCCESS"
+	dependsOnHealthy  = "HEALTHY"
+)
+
+// Defaults of the rolling deployments of a service.
+const (
+	defaultMinHealthyPercent = 100
+	defaultMaxPercent        = 200
+)
+
+// Defaults of the blue/green deployments of a load balanced web service.
+const (
+	defaultTrafficShiftingPercentage = 10
+	defaultCanaryInterval            = 5 * time.Minute
+	defaultLinearInterval            = time.Minute
+)
+
+// Limits of the target group's health check and attributes.
+// See https://docs.aws.amazon.com/AWSCloudFormation/latest/UserGuide/aws-resource-elasticloadbalancingv2-targetgroup.html
+const (
+	minHealthCheckInterval     = 5 * time.Second
+	maxHealthCheckInterval     = 300 * time.Second
+	minHealthCheckTimeout      = 2 * time.Second
+	maxHealthCheckTimeout      = 120 * time.Second
+	maxDeregistrationDelay     = time.Hour
+	defaultHealthCheckInterval = 10 * time.Second
+	defaultHealthCheckTimeout  = 5 * time.Second
+)
+
+// Intervals of the health checks of a network load balancer's target group.
+// See https://docs.aws.amazon.com/elasticloadbalancing/latest/network/target-group-health-checks.html
+var nlbHealthCheckIntervals = []time.Duration{10 * time.Second, 30 * time.Second}
+
+// Limits of the queue attributes.
+// See https://docs.aws.amazon.com/AWSCloudFormation/latest/UserGuide/aws-properties-sqs-queues.html
+const (
+	minQueueRetention  = 60 * time.Second
+	maxQueueRetention  = 14 * 24 * time.Hour
+	maxQueueDelay      = 15 * time.Minute
+	maxQueueTimeout    = 12 * time.Hour
+	maxDeadLetterTries = 1000
+)
+
+// ARNs of the secrets referenced by name, which are in the account and region of the task.
+const (
+	fmtSSMParameterARN = "arn:${AWS::Partition}:ssm:${AWS::Region}:${AWS::AccountId}:parameter/%s"
+	fmtSecretARN       = "arn:${AWS::Partition}:secretsmanager:${AWS::Region}:${AWS::AccountId}:secret:%s-??????" // Secrets Manager appends 6 random characters to the name.
+)
+
+// secretSuffixRegExp matches the 6 random characters that Secrets Manager appends to the name of a secret in its ARN.
+var secretSuffixRegExp = regexp.MustCompile(`-[a-zA-Z0-9]{6}$`)
+
+// ARN of the S3 bucket, or of the S3 object as "bucket/key", of an environment file.
+const (
+	fmtS3ARN = "arn:${AWS::Partition}:s3:::%s"
+)
+
+// autoscalingOpts converts the manifest's autoscaling configuration into a format parsable by the templates pkg.
+// If autoscaling is not configured, it returns nil.
+func autoscalingOpts(a manifest.Autoscaling) (*template.AutoscalingOpts, error) {
+	if a.IsEmpty() {
+		return nil, nil
+	}
+	if a.Range == "" {
+		return nil, errAutoscalingRangeRequired
+	}
+	min, max, err := a.Range.Parse()
+	if err != nil {
+		return nil, fmt.Errorf("parse autoscaling range: %w", err)
+	}
+	opts := &template.AutoscalingOpts{
+		MinCapacity: &min,
+		MaxCapacity: &max,
+	}
+	if a.CPU != nil {
+		opts.CPU = aws.Float64(float64(*a.CPU))
+	}
+	if a.Memory != nil {
+		opts.Memory = aws.Float64(float64(*a.Memory))
+	}
+	if a.Requests != nil {
+		opts.Requests = aws.Float64(float64(*a.Requests))
+	}
+	if a.QueueDepth != nil {
+		opts.QueueDepth = aws.Float64(float64(*a.QueueDepth))
+	}
+	return opts, nil
+}
+
+// desiredCount returns the number of tasks the service should start with.
+// If the service is autoscaled, then it's the minimum of the autoscaling range.
+func desiredCount(c manifest.Count) int {
+	if c.Value != nil {
+		return *c.Value
+	}
+	min, _, err := c.Autoscaling.Range.Parse()
+	if err != nil {
+		// The range is validated while rendering the template, fallback to a single task.
+		return 1
+	}
+	return min
+}
+
+// capacityProviderStrategy converts the manifest's capacity provider strategy into a format parsable by the templates pkg.
+// If the strategy is not configured, it returns nil and the tasks are launched on FARGATE.
+func capacityProviderStrategy(p manifest.Platform) ([]*template.CapacityProviderStrategy, error) {
+	if p.IsEmpty() {
+		return nil, nil
+	}
+	var strategy []*template.CapacityProviderStrategy
+	seen := make(map[string]bool)
+	var hasBase, hasWeight bool
+	for _, cp := range p.CapacityProviders {
+		if cp.Name == nil {
+			return nil, errCapacityProviderNameRequired
+		}
+		if seen[*cp.Name] {
+			return nil, fmt.Errorf("capacity provider %s is listed more than once", *cp.Name)
+		}
+		seen[*cp.Name] = true
+		if cp.Base != nil {
+			if hasBase {
+				return nil, errMultipleCapacityProviderBases
+			}
+			hasBase = true
+		}
+		if aws.IntValue(cp.Weight) > 0 {
+			hasWeight = true
+		}
+		strategy = append(strategy, &template.CapacityProviderStrategy{
+			CapacityProvider: *cp.Name,
+			Base:             cp.Base,
+			Weight:           cp.Weight,
+		})
+	}
+	if !hasWeight {
+		return nil, errCapacityProviderWeightRequired
+	}
+	return strategy, nil
+}
+
+// networkOpts converts the manifest's network configuration into a format parsable by the templates pkg.
+// If the network is not configured, it returns nil and the tasks are placed in the public subnets.
+func networkOpts(n manifest.NetworkConfig) *template.NetworkOpts {
+	if n.IsEmpty() {
+		return nil
+	}
+	opts := &template.NetworkOpts{
+		AssignPublicIP:  template.EnablePublicIP,
+		SubnetsType:     template.PublicSubnetsPlacement,
+		SecurityGroups:  n.VPC.SecurityGroups,
+		AllowedServices: n.VPC.Ingress.Services,
+	}
+	if aws.StringValue(n.VPC.Placement) == manifest.PrivateSubnetPlacement {
+		opts.AssignPublicIP = template.DisablePublicIP
+		opts.SubnetsType = template.PrivateSubnetsPlacement
+	}
+	return opts
+}
+
+// deploymentConfigurationOpts converts the manifest's deployment configuration into a format parsable by the templates pkg.
+func deploymentConfigurationOpts(d manifest.DeploymentConfig) (template.DeploymentConfigurationOpts, error) {
+	min, max := defaultMinHealthyPercent, defaultMaxPercent
+	if d.MinHealthyPercent != nil {
+		min = *d.MinHealthyPercent
+	}
+	if d.MaxPercent != nil {
+		max = *d.MaxPercent
+	}
+	if max <= min {
+		return template.DeploymentConfigurationOpts{}, errDeploymentCannotProgress
+	}
+	if !d.IsBlueGreen() && !d.BlueGreen.IsEmpty() {
+		return template.DeploymentConfigurationOpts{}, errBlueGreenWithoutStrategy
+	}
+	opts := template.DeploymentConfigurationOpts{
+		MinHealthyPercent: d.MinHealthyPercent,
+		MaxPercent:        d.MaxPercent,
+	}
+	if d.CircuitBreaker.IsEmpty() {
+		return opts, nil
+	}
+	if d.IsBlueGreen() {
+		return template.DeploymentConfigurationOpts{}, errCircuitBreakerWithBlueGreen
+	}
+	enable, rollback := aws.BoolValue(d.CircuitBreaker.Enable), aws.BoolValue(d.CircuitBreaker.Rollback)
+	if rollback && !enable {
+		return template.DeploymentConfigurationOpts{}, errCircuitBreakerRollbackWithoutEnable
+	}
+	opts.CircuitBreaker = &template.CircuitBreakerOpts{
+		Enable:   enable,
+		Rollback: rollback,
+	}
+	return opts, nil
+}
+
+// blueGreenDeploymentOpts converts the manifest's blue/green deployment configuration into a format parsable by the templates pkg.
+// If the service isn't deployed with the blue/green strategy, it returns nil.
+func blueGreenDeploymentOpts(d manifest.DeploymentConfig) (*template.BlueGreenDeploymentOpts, error) {
+	if !d.IsBlueGreen() {
+		return nil, nil
+	}
+	bg := d.BlueGreen
+	opts := &template.BlueGreenDeploymentOpts{
+		TrafficRouting: template.AllAtOnceTrafficRouting,
+		Alarms:         bg.Alarms,
+		TestPort:       aws.IntValue(bg.TestPort), // The load balancer is shared, by default the template picks a port that no other service listens on.
+	}
+	var interval time.Duration
+	switch aws.StringValue(bg.TrafficShifting) {
+	case manifest.CanaryTrafficShifting:
+		opts.TrafficRouting, interval = template.CanaryTrafficRouting, defaultCanaryInterval
+	case manifest.LinearTrafficShifting:
+		opts.TrafficRouting, interval = template.LinearTrafficRouting, defaultLinearInterval
+	default:
+		if bg.Percentage != nil || bg.Interval != nil {
+			return nil, errTrafficShiftingStepsWithAllAtOnce
+		}
+		return opts, nil
+	}
+	if bg.Interval != nil {
+		interval = *bg.Interval
+	}
+	if interval < time.Minute || interval%time.Minute != 0 {
+		return nil, fmt.Errorf("blue/green deployment interval %s must be a whole number of minutes", interval)
+	}
+	opts.IntervalMinutes = int(interval / time.Minute)
+	opts.Percentage = defaultTrafficShiftingPercentage
+	if bg.Percentage != nil {
+		opts.Percentage = *bg.Percentage
+	}
+	return opts, nil
+}
+
+// httpHealthCheckOpts converts the manifest's target group health check into a format parsable by the templates pkg.
+func httpHealthCheckOpts(hc manifest.HealthCheckArgsOrString) (template.HTTPHealthCheckOpts, error) {
+	args := hc.HealthCheckArgs
+	opts := template.HTTPHealthCheckOpts{
+		HealthyThreshold:   args.HealthyThreshold,
+		UnhealthyThreshold: args.UnhealthyThreshold,
+		SuccessCodes:       args.SuccessCodes,
+	}
+	interval, timeout := defaultHealthCheckInterval, defaultHealthCheckTimeout
+	if args.Interval != nil {
+		if *args.Interval < minHealthCheckInterval || *args.Interval > maxHealthCheckInterval {
+			return template.HTTPHealthCheckOpts{}, fmt.Errorf("health check interval %s must be between %s and %s", *args.Interval, minHealthCheckInterval, maxHealthCheckInterval)
+		}
+		interval = *args.Interval
+		opts.Interval = aws.Int(int(interval.Seconds()))
+	}
+	if args.Timeout != nil {
+		if *args.Timeout < minHealthCheckTimeout || *args.Timeout > maxHealthCheckTimeout {
+			return template.HTTPHealthCheckOpts{}, fmt.Errorf("health check timeout %s must be between %s and %s", *args.Timeout, minHealthCheckTimeout, maxHealthCheckTimeout)
+		}
+		timeout = *args.Timeout
+		opts.Timeout = aws.Int(int(timeout.Seconds()))
+	}
+	if timeout >= interval {
+		return template.HTTPHealthCheckOpts{}, fmt.Errorf("health check timeout %s must be less than the interval %s", timeout, interval)
+	}
+	return opts, nil
+}
+
+// deregistrationDelaySeconds returns the number of seconds the load balancer waits before deregistering a stopped task.
+// If the delay is not configured, it returns nil.
+func deregistrationDelaySeconds(d *time.Duration) (*int, error) {
+	if d == nil {
+		return nil, nil
+	}
+	if *d < 0 || *d > maxDeregistrationDelay {
+		return nil, fmt.Errorf("deregistration delay %s must be between 0s and %s", *d, maxDeregistrationDelay)
+	}
+	return aws.Int(int(d.Seconds())), nil
+}
+
+// nlbOpts converts the manifest's network load balancer configuration into a format parsable by the templates pkg.
+// The listeners target the main container unless they name one of the sidecars, which must expose the target port.
+// If the service doesn't have a network load balancer, it returns nil.
+func nlbOpts(nlb manifest.NetworkLoadBalancerConfiguration, mainContainer string, mainPort uint16, sidecars map[string]manifest.SidecarConfig) (*template.NetworkLoadBalancerOpts, error) {
+	if len(nlb.Listeners) == 0 {
+		if nlb.Alias != nil {
+			return nil, errNLBAliasWithoutListeners
+		}
+		return nil, nil
+	}
+	opts := &template.NetworkLoadBalancerOpts{
+		Alias: nlb.Alias,
+	}
+	listenerPorts := make(map[uint16]bool)
+	mainMappings := map[template.PortMappingOpts]bool{
+		{Port: int(mainPort), Protocol: "tcp"}: true, // The HTTP container port is always mapped.
+	}
+	ingress := make(map[template.PortMappingOpts]*template.NLBIngressOpts)
+	allowIngress := func(port int, protocol string, fromAnywhere bool) {
+		key := template.PortMappingOpts{Port: port, Protocol: protocol}
+		if rule, ok := ingress[key]; ok {
+			rule.FromAnywhere = rule.FromAnywhere || fromAnywhere
+			return
+		}
+		rule := &template.NLBIngressOpts{Port: port, Protocol: protocol, FromAnywhere: fromAnywhere}
+		ingress[key] = rule
+		opts.Ingress = append(opts.Ingress, rule)
+	}
+	for _, l := range nlb.Listeners {
+		port, protocol, err := l.PortAndProtocol()
+		if err != nil {
+			return nil, err
+		}
+		if listenerPorts[port] {
+			return nil, fmt.Errorf("network load balancer listener port %d is defined more than once", port)
+		}
+		listenerPorts[port] = true
+		if protocol == manifest.TLSProtocol && l.Certificate == nil {
+			return nil, fmt.Errorf(`listener %s requires a "certificate"`, l.Port)
+		}
+		if protocol != manifest.TLSProtocol && l.Certificate != nil {
+			return nil, fmt.Errorf(`listener %s must use the "tls" protocol to have a "certificate"`, l.Port)
+		}
+		targetPort, _ := l.TargetPortOrDefault()
+		targetProtocol := protocol
+		if protocol == manifest.TLSProtocol {
+			targetProtocol = manifest.TCPProtocol
+		}
+		if targetProtocol == manifest.UDPProtocol && l.HealthCheck.Port == nil {
+			return nil, fmt.Errorf(`listener %s requires a "healthcheck.port", since the targets are health checked over TCP`, l.Port)
+		}
+		containerProtocols := []string{targetProtocol}
+		if targetProtocol == manifest.TCPUDPProtocol {
+			containerProtocols = []string{manifest.TCPProtocol, manifest.UDPProtocol}
+		}
+
+		targetContainer := l.TargetContainer
+		if targetContainer != nil && *targetContainer == mainContainer {
+			targetContainer = nil
+		}
+		if targetContainer == nil {
+			for _, p := range containerProtocols {
+				mapping := template.PortMappingOpts{Port: int(targetPort), Protocol: p}
+				if !mainMappings[mapping] {
+					mainMappings[mapping] = true
+					opts.PortMappings = append(opts.PortMappings, &mapping)
+				}
+			}
+		} else {
+			sidecar, ok := sidecars[*targetContainer]
+			if !ok {
+				return nil, fmt.Errorf("target container %s of listener %s doesn't exist", *targetContainer, l.Port)
+			}
+			sidecarPort, sidecarProtocol, err := parsePortMapping(sidecar.Port)
+			if err != nil {
+				return nil, err
+			}
+			exposedProtocol := manifest.TCPProtocol
+			if sidecarProtocol != nil {
+				exposedProtocol = *sidecarProtocol
+			}
+			if aws.StringValue(sidecarPort) != strconv.Itoa(int(targetPort)) || exposedProtocol != targetProtocol {
+				return nil, fmt.Errorf("sidecar %s must expose port %d/%s to be the target of listener %s", *targetContainer, targetPort, targetProtocol, l.Port)
+			}
+		}
+		// The targets of TCP and TLS listeners receive the traffic from the load balancer's addresses in the VPC.
+		fromAnywhere := targetProtocol != manifest.TCPProtocol
+		for _, p := range containerProtocols {
+			allowIngress(int(targetPort), p, fromAnywhere)
+		}
+		// The health checks are always sent over TCP from the load balancer's addresses in the VPC.
+		healthCheckPort := int(targetPort)
+		if l.HealthCheck.Port != nil {
+			healthCheckPort = int(*l.HealthCheck.Port)
+		}
+		allowIngress(healthCheckPort, manifest.TCPProtocol, false)
+
+		healthCheck, err := nlbHealthCheckOpts(l.HealthCheck)
+		if err != nil {
+			return nil, fmt.Errorf("listener %s: %w", l.Port, err)
+		}
+		opts.Listeners = append(opts.Listeners, &template.NetworkLoadBalancerListenerOpts{
+			Port:            int(port),
+			Protocol:        strings.ToUpper(protocol),
+			TargetContainer: targetContainer,
+			TargetPort:      int(targetPort),
+			TargetProtocol:  strings.ToUpper(targetProtocol),
+			Certificate:     l.Certificate,
+			HealthCheck:     healthCheck,
+		})
+	}
+	return opts, nil
+}
+
+// nlbHealthCheckOpts converts the health check of a network load balancer's target group into a format parsable by the templates pkg.
+func nlbHealthCheckOpts(hc manifest.NLBHealthCheckArgs) (template.NLBHealthCheckOpts, error) {
+	opts := template.NLBHealthCheckOpts{
+		HealthyThreshold:   hc.HealthyThreshold,
+		UnhealthyThreshold: hc.UnhealthyThreshold,
+	}
+	if hc.Port != nil {
+		opts.Port = aws.Int(int(*hc.Port))
+	}
+	if hc.Interval != nil {
+		valid := false
+		for _, interval := range nlbHealthCheckIntervals {
+			valid = valid || *hc.Interval == interval
+		}
+		if !valid {
+			return template.NLBHealthCheckOpts{}, fmt.Errorf("health check interval %s must be either %s or %s", *hc.Interval, nlbHealthCheckIntervals[0], nlbHealthCheckIntervals[1])
+		}
+		opts.Interval = aws.Int(int(hc.Interval.Seconds()))
+	}
+	return opts, nil
+}
+
+// stateMachineOpts converts the job's failure handling configuration into a format parsable by the templates pkg.
+func stateMachineOpts(c manifest.JobFailureHandlerConfig) *template.StateMachineOpts {
+	opts := &template.StateMachineOpts{
+		Retries: c.Retries,
+	}
+	if c.Timeout != nil {
+		opts.Timeout = aws.Int(int(c.Timeout.Seconds()))
+	}
+	return opts
+}
+
+// queueOpts converts the worker service's queue configuration into a format parsable by the templates pkg.
+func queueOpts(q manifest.SQSQueue) (*template.QueueOpts, error) {
+	opts := &template.QueueOpts{}
+	if q.Retention != nil {
+		if *q.Retention < minQueueRetention || *q.Retention > maxQueueRetention {
+			return nil, fmt.Errorf("queue retention %s must be between %s and %s", *q.Retention, minQueueRetention, maxQueueRetention)
+		}
+		opts.Retention = aws.Int(int(q.Retention.Seconds()))
+	}
+	if q.Delay != nil {
+		if *q.Delay < 0 || *q.Delay > maxQueueDelay {
+			return nil, fmt.Errorf("queue delay %s must be between 0s and %s", *q.Delay, maxQueueDelay)
+		}
+		opts.Delay = aws.Int(int(q.Delay.Seconds()))
+	}
+	if q.Timeout != nil {
+		if *q.Timeout < 0 || *q.Timeout > maxQueueTimeout {
+			return nil, fmt.Errorf("queue timeout %s must be between 0s and %s", *q.Timeout, maxQueueTimeout)
+		}
+		opts.Timeout = aws.Int(int(q.Timeout.Seconds()))
+	}
+	tries := aws.IntValue(q.DeadLetter.Tries)
+	if tries < 1 || tries > maxDeadLetterTries {
+		return nil, fmt.Errorf("dead-letter queue tries %d must be between 1 and %d", tries, maxDeadLetterTries)
+	}
+	opts.DeadLetterTries = tries
+	return opts, nil
+}
+
+// convertSidecar converts the manifest's sidecar configurations into a format parsable by the templates pkg.
+// The sidecars are sorted by name so that the template is stable across deployments.
+// envFiles holds the S3 objects of the environment files uploaded for the containers, keyed by the container name.
+func convertSidecar(sidecars map[string]manifest.SidecarConfig, volumes map[string]manifest.Volume, envFiles map[string]string) ([]*template.SidecarOpts, error) {
+	if len(sidecars) == 0 {
+		return nil, nil
+	}
+	names := make([]string, 0, len(sidecars))
+	for name := range sidecars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var opts []*template.SidecarOpts
+	for _, name := range names {
+		if name == firelensContainerName {
+			return nil, fmt.Errorf("sidecar name %s is reserved for the container that routes logs", name)
+		}
+		config := sidecars[name]
+		port, protocol, err := parsePortMapping(config.Port)
+		if err != nil {
+			return nil, fmt.Errorf("sidecar %s: %w", name, err)
+		}
+		var mountPoints []*template.MountPoint
+		for _, mp := range config.MountPoints {
+			source := aws.StringValue(mp.SourceVolume)
+			if _, ok := volumes[source]; !ok {
+				return nil, fmt.Errorf("sidecar %s: source volume %q is not defined under %q", name, source, "storage.volumes")
+			}
+			if mp.ContainerPath == nil {
+				return nil, fmt.Errorf(`sidecar %s: "path" is required to mount volume %s`, name, source)
+			}
+			mountPoints = append(mountPoints, convertMountPoint(source, mp.MountPointOpts))
+		}
+		healthCheck, err := convertSidecarHealthCheck(config.HealthCheck)
+		if err != nil {
+			return nil, fmt.Errorf("sidecar %s: %w", name, err)
+		}
+		secrets, err := convertSecrets(config.Secrets)
+		if err != nil {
+			return nil, fmt.Errorf("sidecar %s: %w", name, err)
+		}
+		opts = append(opts, &template.SidecarOpts{
+			Name:        aws.String(name),
+			Image:       aws.String(config.Image),
+			Essential:   config.Essential,
+			Port:        port,
+			Protocol:    protocol,
+			CredsParam:  optionalString(config.CredParam),
+			Variables:   config.Variables,
+			EnvFileARN:  envFileARN(envFiles, name),
+			Secrets:     secrets,
+			DependsOn:   config.DependsOn,
+			Command:     stringSliceOpts(config.Command),
+			EntryPoint:  stringSliceOpts(config.EntryPoint),
+			HealthCheck: healthCheck,
+			MountPoints: mountPoints,
+		})
+	}
+	return opts, nil
+}
+
+// convertSecrets converts the secrets of a container into the "ValueFrom" of the secrets of its task definition.
+func convertSecrets(secrets map[string]manifest.SecretArgsOrString) (map[string]string, error) {
+	if len(secrets) == 0 {
+		return nil, nil
+	}
+	valueFroms := make(map[string]string, len(secrets))
+	for name, secret := range secrets {
+		args := secret.Args()
+		if err := args.Validate(); err != nil {
+			return nil, fmt.Errorf("secret %s: %w", name, err)
+		}
+		valueFroms[name] = args.ValueFrom()
+	}
+	return valueFroms, nil
+}
+
+// secretsPolicyOpts returns exactly the SSM parameters, Secrets Manager secrets and customer managed keys that the
+// execution role needs to access to inject the secrets of the main container and of the sidecars, or nil if there are none.
+// The secrets must have been validated by convertSecrets.
+func secretsPolicyOpts(secrets map[string]manifest.SecretArgsOrString, sidecars map[string]manifest.SidecarConfig) *template.SecretsPolicyOpts {
+	params, secretARNs, keys := make(map[string]bool), make(map[string]bool), make(map[string]bool)
+	addSecret := func(secret manifest.SecretArgsOrString) {
+		args := secret.Args()
+		if args.KMSKey != nil {
+			keys[*args.KMSKey] = true
+		}
+		resource := args.ResourceARN()
+		switch {
+		case args.IsSecretsManager():
+			secretARNs[secretPolicyARN(resource)] = true
+		case resource != "":
+			params[resource] = true
+		default:
+			params[fmt.Sprintf(fmtSSMParameterARN, strings.TrimPrefix(aws.StringValue(args.From), "/"))] = true
+		}
+	}
+	for _, secret := range secrets {
+		addSecret(secret)
+	}
+	for _, sidecar := range sidecars {
+		for _, secret := range sidecar.Secrets {
+			addSecret(secret)
+		}
+		if sidecar.CredParam == "" {
+			continue
+		}
+		// The credentials of the private registry of the sidecar's image are a Secrets Manager secret.
+		if args := (manifest.SecretArgs{From: aws.String(sidecar.CredParam)}); args.IsSecretsManager() {
+			secretARNs[secretPolicyARN(args.ResourceARN())] = true
+		} else {
+			secretARNs[fmt.Sprintf(fmtSecretARN, sidecar.CredParam)] = true
+		}
+	}
+	if len(params) == 0 && len(secretARNs) == 0 {
+		return nil
+	}
+	return &template.SecretsPolicyOpts{
+		SSMParameters: sortedKeys(params),
+		Secrets:       sortedKeys(secretARNs),
+		KMSKeys:       sortedKeys(keys),
+	}
+}
+
+// secretPolicyARN returns the ARN of a Secrets Manager secret that IAM policies can grant access to.
+// An ARN written without the random suffix of the secret, such as "arn:aws:secretsmanager:us-west-2:123456789012:secret:db",
+// never matches the full ARN of the secret, so the suffix is matched with wildcards instead.
+func secretPolicyARN(secretARN string) string {
+	if secretSuffixRegExp.MatchString(secretARN) {
+		return secretARN
+	}
+	return secretARN + "-??????"
+}
+
+// envFileARN returns the ARN of the environment file uploaded for the container, or nil if it doesn't have one.
+func envFileARN(envFiles map[string]string, container string) *string {
+	object, ok := envFiles[container]
+	if !ok {
+		return nil
+	}
+	return aws.String(fmt.Sprintf(fmtS3ARN, object))
+}
+
+// envFilesPolicyOpts returns the environment files and the buckets that contain them, which the execution role
+// needs to access to load the environment files of the containers, or nil if there are none.
+func envFilesPolicyOpts(envFiles map[string]string) *template.EnvFilesPolicyOpts {
+	if len(envFiles) == 0 {
+		return nil
+	}
+	objects, buckets := make(map[string]bool), make(map[string]bool)
+	for _, object := range envFiles {
+		objects[fmt.Sprintf(fmtS3ARN, object)] = true
+		buckets[fmt.Sprintf(fmtS3ARN, strings.SplitN(object, "/", 2)[0])] = true
+	}
+	return &template.EnvFilesPolicyOpts{
+		Objects: sortedKeys(objects),
+		Buckets: sortedKeys(buckets),
+	}
+}
+
+func sortedKeys(m map[string]bool) []string {
+	if len(m) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// stringSliceOpts converts a field such as the "command" of a container into the list of arguments of the
+// task definition, or nil if the field isn't set so that the image's default applies.
+func stringSliceOpts(s manifest.StringSliceOrString) []*string {
+	if s.IsEmpty() {
+		return nil
+	}
+	return aws.StringSlice(s.ToStringSlice())
+}
+
+// convertSidecarHealthCheck converts the sidecar's healthcheck configuration into a format parsable by the templates pkg.
+// Unlike the main container, sidecars don't have a default healthcheck command, so unset fields fall back to the ECS defaults.
+func convertSidecarHealthCheck(hc *manifest.ContainerHealthCheck) (*ecs.HealthCheck, error) {
+	if hc == nil {
+		return nil, nil
+	}
+	if len(hc.Command) == 0 {
+		return nil, errSidecarHealthCheckCommandRequired
+	}
+	opts := &ecs.HealthCheck{
+		Command: aws.StringSlice(hc.Command),
+	}
+	if hc.Interval != nil {
+		opts.Interval = aws.Int64(int64(hc.Interval.Seconds()))
+	}
+	if hc.Retries != nil {
+		opts.Retries = aws.Int64(int64(*hc.Retries))
+	}
+	if hc.StartPeriod != nil {
+		opts.StartPeriod = aws.Int64(int64(hc.StartPeriod.Seconds()))
+	}
+	if hc.Timeout != nil {
+		opts.Timeout = aws.Int64(int64(hc.Timeout.Seconds()))
+	}
+	return opts, nil
+}
+
+// validateDependsOn returns an error if a sidecar depends on a container that doesn't exist in the task,
+// or on a condition that the container can't satisfy.
+func validateDependsOn(mainContainer string, mainHasHealthCheck bool, sidecars map[string]manifest.SidecarConfig) error {
+	names := make([]string, 0, len(sidecars))
+	for name := range sidecars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for container, condition := range sidecars[name].DependsOn {
+			if container == name {
+				return fmt.Errorf("sidecar %s cannot depend on itself", name)
+			}
+			hasHealthCheck, essential := mainHasHealthCheck, true
+			if container != mainContainer {
+				target, ok := sidecars[container]
+				if !ok {
+					return fmt.Errorf("sidecar %s depends on container %s which does not exist", name, container)
+				}
+				hasHealthCheck = target.HealthCheck != nil
+				essential = target.Essential == nil || *target.Essential
+			}
+			switch condition {
+			case dependsOnHealthy:
+				if !hasHealthCheck {
+					return fmt.Errorf("sidecar %s depends on container %s to be %s, but %s does not have a healthcheck", name, container, condition, container)
+				}
+			case dependsOnComplete, dependsOnSuccess:
+				if essential {
+					return fmt.Errorf("sidecar %s depends on container %s to %s, but %s is essential", name, container, condition, container)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// logConfigOpts converts the manifest's logging configuration into a format parsable by the templates pkg.
+// If logging is not configured, it returns nil and the main container sends its logs to the service's log group.
+func logConfigOpts(l manifest.Logging) (*template.LogConfigOpts, error) {
+	if l.IsEmpty() {
+		return nil, nil
+	}
+	opts := &template.LogConfigOpts{
+		Image:          aws.String(defaultFluentbitImage),
+		EnableMetadata: aws.String("true"),
+		ConfigFile:     l.ConfigFile,
+	}
+	if l.Image != nil {
+		opts.Image = l.Image
+	}
+	if l.EnableMetadata != nil {
+		opts.EnableMetadata = aws.String(strconv.FormatBool(*l.EnableMetadata))
+	}
+	dest := l.Destination
+	var count int
+	for _, isSet := range []bool{dest.Firehose != nil, dest.CloudWatch != nil, dest.Elasticsearch != nil} {
+		if isSet {
+			count++
+		}
+	}
+	if count > 1 {
+		return nil, errMultipleLogDestinations
+	}
+	if count == 0 && l.ConfigFile == nil {
+		return nil, errLogDestinationRequired
+	}
+	region := func(r *string) string {
+		if r == nil {
+			return defaultFirelensLogsRegion
+		}
+		return strconv.Quote(*r)
+	}
+	switch {
+	case dest.Firehose != nil:
+		if dest.Firehose.DeliveryStream == nil {
+			return nil, fmt.Errorf(`"delivery_stream" is required for the %s log destination`, "firehose")
+		}
+		opts.Destination = map[string]string{
+			"Name":            "firehose",
+			"region":          region(dest.Firehose.Region),
+			"delivery_stream": strconv.Quote(*dest.Firehose.DeliveryStream),
+		}
+		opts.FirehoseStream = dest.Firehose.DeliveryStream
+	case dest.CloudWatch != nil:
+		if dest.CloudWatch.LogGroup == nil {
+			return nil, fmt.Errorf(`"log_group" is required for the %s log destination`, "cloudwatch")
+		}
+		prefix := defaultLogStreamPrefix
+		if dest.CloudWatch.StreamPrefix != nil {
+			prefix = *dest.CloudWatch.StreamPrefix
+		}
+		opts.Destination = map[string]string{
+			"Name":              "cloudwatch",
+			"region":            region(dest.CloudWatch.Region),
+			"log_group_name":    strconv.Quote(*dest.CloudWatch.LogGroup),
+			"log_stream_prefix": strconv.Quote(prefix),
+			"auto_create_group": strconv.Quote("true"),
+		}
+		opts.LogGroup = dest.CloudWatch.LogGroup
+	case dest.Elasticsearch != nil:
+		if dest.Elasticsearch.Host == nil || dest.Elasticsearch.Index == nil {
+			return nil, fmt.Errorf(`"host" and "index" are required for the %s log destination`, "elasticsearch")
+		}
+		port := defaultElasticsearchPort
+		if dest.Elasticsearch.Port != nil {
+			port = *dest.Elasticsearch.Port
+		}
+		opts.Destination = map[string]string{
+			"Name":       "es",
+			"Host":       strconv.Quote(*dest.Elasticsearch.Host),
+			"Port":       strconv.Quote(strconv.Itoa(port)),
+			"Index":      strconv.Quote(*dest.Elasticsearch.Index),
+			"aws_auth":   strconv.Quote("On"),
+			"aws_region": region(dest.Elasticsearch.Region),
+			"tls":        strconv.Quote("On"),
+		}
+		opts.Elasticsearch = true
+	}
+	return opts, nil
+}
+
+// parsePortMapping parses a port mapping such as "2000/udp" into the port and the optional protocol.
+func parsePortMapping(s string) (port *string, protocol *string, err error) {
+	if s == "" {
+		return nil, nil, nil
+	}
+	parts := strings.Split(s, "/")
+	switch len(parts) {
+	case 1:
+		return aws.String(parts[0]), nil, nil
+	case 2:
+		return aws.String(parts[0]), aws.String(parts[1]), nil
+	default:
+		return nil, nil, fmt.Errorf("cannot parse port mapping from %s", s)
+	}
+}
+
+// convertStorageOpts converts the manifest's storage configuration and the mount points of the sidecars
+// into a format parsable by the templates pkg. If no volumes are configured, it returns nil.
+func convertStorageOpts(storage manifest.Storage, sidecars map[string]manifest.SidecarConfig) (*template.StorageOpts, error) {
+	if storage.IsEmpty() {
+		return nil, nil
+	}
+	// The task role needs write permissions to a volume if it's mounted as read-write into any container.
+	writable := make(map[string]bool)
+	for name, volume := range storage.Volumes {
+		if volume.ContainerPath != nil && !isReadOnly(volume.MountPointOpts) {
+			writable[name] = true
+		}
+	}
+	for _, config := range sidecars {
+		for _, mp := range config.MountPoints {
+			if !isReadOnly(mp.MountPointOpts) {
+				writable[aws.StringValue(mp.SourceVolume)] = true
+			}
+		}
+	}
+
+	names := make([]string, 0, len(storage.Volumes))
+	for name := range storage.Volumes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	opts := &template.StorageOpts{}
+	var writeManagedEFS bool
+	for _, name := range names {
+		volume := storage.Volumes[name]
+		efs, err := convertEFSVolume(name, volume.EFS)
+		if err != nil {
+			return nil, err
+		}
+		opts.Volumes = append(opts.Volumes, &template.Volume{
+			Name: name,
+			EFS:  efs,
+		})
+		if volume.ContainerPath != nil {
+			opts.MountPoints = append(opts.MountPoints, convertMountPoint(name, volume.MountPointOpts))
+		}
+		if volume.EFS.UseManagedFS() {
+			opts.ManagedEFS = true
+			writeManagedEFS = writeManagedEFS || writable[name]
+			continue
+		}
+		opts.EFSPerms = append(opts.EFSPerms, &template.EFSPermission{
+			Filesystem:    efs.Filesystem,
+			AccessPointID: efs.AccessPointID,
+			Write:         writable[name],
+		})
+	}
+	if opts.ManagedEFS {
+		// All the volumes with "efs: true" share the single file system created by the service stack.
+		opts.EFSPerms = append(opts.EFSPerms, &template.EFSPermission{
+			Write: writeManagedEFS,
+		})
+	}
+	return opts, nil
+}
+
+// convertEFSVolume validates the EFS configuration of the volume and converts it into a format parsable by the templates pkg.
+// Volumes with "efs: true" use the file system created by the service stack.
+func convertEFSVolume(name string, efs manifest.EFSConfigOrBool) (*template.EFSVolumeConfiguration, error) {
+	if efs.UseManagedFS() {
+		return &template.EFSVolumeConfiguration{}, nil
+	}
+	if efs.IsEmpty() || efs.Enabled != nil {
+		return nil, fmt.Errorf(`volume %s must specify an EFS file system with "efs"`, name)
+	}
+	config := efs.Advanced
+	if config.FileSystemID == nil {
+		return nil, fmt.Errorf(`volume %s: "id" is required for an existing EFS file system`, name)
+	}
+	opts := &template.EFSVolumeConfiguration{
+		Filesystem:    config.FileSystemID,
+		RootDirectory: config.RootDirectory,
+	}
+	if config.AuthConfig.IsEmpty() {
+		return opts, nil
+	}
+	if config.AuthConfig.AccessPointID != nil && aws.StringValue(config.RootDirectory) != "" && aws.StringValue(config.RootDirectory) != "/" {
+		return nil, fmt.Errorf(`volume %s: "root_dir" must be empty or "/" when "access_point_id" is specified`, name)
+	}
+	opts.IAM = aws.String("DISABLED")
+	if aws.BoolValue(config.AuthConfig.IAM) {
+		opts.IAM = aws.String("ENABLED")
+	}
+	opts.AccessPointID = config.AuthConfig.AccessPointID
+	return opts, nil
+}
+
+func convertMountPoint(source string, opts manifest.MountPointOpts) *template.MountPoint {
+	return &template.MountPoint{
+		ContainerPath: aws.StringValue(opts.ContainerPath),
+		ReadOnly:      isReadOnly(opts),
+		SourceVolume:  source,
+	}
+}
+
+// isReadOnly returns whether the volume is mounted as read-only, which is the default.
+func isReadOnly(opts manifest.MountPointOpts) bool {
+	if opts.ReadOnly == nil {
+		return true
+	}
+	return *opts.ReadOnly
+}
+
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return aws.String(s)
+}
@@ -0,0 +1,1555 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package stack
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/manifest"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/template"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAutoscalingOpts(t *testing.T) {
+	testCases := map[string]struct {
+		in manifest.Autoscaling
+
+		wanted    *template.AutoscalingOpts
+		wantedErr error
+	}{
+		"returns nil if autoscaling is not configured": {
+			in:     manifest.Autoscaling{},
+			wanted: nil,
+		},
+		"returns an error if range is missing": {
+			in: manifest.Autoscaling{
+				CPU: aws.Int(70),
+			},
+			wantedErr: errAutoscalingRangeRequired,
+		},
+		"returns an error if range is invalid": {
+			in: manifest.Autoscaling{
+				Range: manifest.Range("10-1"),
+			},
+			wantedErr: errors.New("parse autoscaling range: minimum value 10 cannot be larger than maximum value 1"),
+		},
+		"converts all target tracking policies": {
+			in: manifest.Autoscaling{
+				Range:    manifest.Range("1-10"),
+				CPU:      aws.Int(70),
+				Memory:   aws.Int(80),
+				Requests: aws.Int(1000),
+			},
+			wanted: &template.AutoscalingOpts{
+				MinCapacity: aws.Int(1),
+				MaxCapacity: aws.Int(10),
+				CPU:         aws.Float64(70),
+				Memory:      aws.Float64(80),
+				Requests:    aws.Float64(1000),
+			},
+		},
+		"converts queue depth policy": {
+			in: manifest.Autoscaling{
+				Range:      manifest.Range("2-4"),
+				QueueDepth: aws.Int(50),
+			},
+			wanted: &template.AutoscalingOpts{
+				MinCapacity: aws.Int(2),
+				MaxCapacity: aws.Int(4),
+				QueueDepth:  aws.Float64(50),
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// WHEN
+			got, err := autoscalingOpts(tc.in)
+
+			// THEN
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.wanted, got)
+			}
+		})
+	}
+}
+
+func TestDesiredCount(t *testing.T) {
+	testCases := map[string]struct {
+		in     manifest.Count
+		wanted int
+	}{
+		"uses the fixed number of tasks": {
+			in: manifest.Count{
+				Value: aws.Int(3),
+			},
+			wanted: 3,
+		},
+		"uses the minimum of the autoscaling range": {
+			in: manifest.Count{
+				Autoscaling: manifest.Autoscaling{
+					Range: manifest.Range("2-10"),
+				},
+			},
+			wanted: 2,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.wanted, desiredCount(tc.in))
+		})
+	}
+}
+
+func TestCapacityProviderStrategy(t *testing.T) {
+	testCases := map[string]struct {
+		inPlatform manifest.Platform
+
+		wanted    []*template.CapacityProviderStrategy
+		wantedErr error
+	}{
+		"returns nil if the strategy is not configured": {},
+		"returns an error if the name is missing": {
+			inPlatform: manifest.Platform{
+				CapacityProviders: []manifest.CapacityProviderStrategy{
+					{
+						Weight: aws.Int(1),
+					},
+				},
+			},
+			wantedErr: errCapacityProviderNameRequired,
+		},
+		"returns an error if a capacity provider is duplicated": {
+			inPlatform: manifest.Platform{
+				CapacityProviders: []manifest.CapacityProviderStrategy{
+					{
+						Name:   aws.String("FARGATE_SPOT"),
+						Weight: aws.Int(1),
+					},
+					{
+						Name:   aws.String("FARGATE_SPOT"),
+						Weight: aws.Int(2),
+					},
+				},
+			},
+			wantedErr: errors.New("capacity provider FARGATE_SPOT is listed more than once"),
+		},
+		"returns an error if more than one capacity provider has a base": {
+			inPlatform: manifest.Platform{
+				CapacityProviders: []manifest.CapacityProviderStrategy{
+					{
+						Name:   aws.String("FARGATE"),
+						Base:   aws.Int(1),
+						Weight: aws.Int(1),
+					},
+					{
+						Name: aws.String("FARGATE_SPOT"),
+						Base: aws.Int(1),
+					},
+				},
+			},
+			wantedErr: errMultipleCapacityProviderBases,
+		},
+		"returns an error if no capacity provider has a weight": {
+			inPlatform: manifest.Platform{
+				CapacityProviders: []manifest.CapacityProviderStrategy{
+					{
+						Name: aws.String("FARGATE"),
+						Base: aws.Int(1),
+					},
+					{
+						Name:   aws.String("FARGATE_SPOT"),
+						Weight: aws.Int(0),
+					},
+				},
+			},
+			wantedErr: errCapacityProviderWeightRequired,
+		},
+		"mixes FARGATE and FARGATE_SPOT": {
+			inPlatform: manifest.Platform{
+				CapacityProviders: []manifest.CapacityProviderStrategy{
+					{
+						Name:   aws.String("FARGATE"),
+						Base:   aws.Int(1),
+						Weight: aws.Int(1),
+					},
+					{
+						Name:   aws.String("FARGATE_SPOT"),
+						Weight: aws.Int(3),
+					},
+				},
+			},
+			wanted: []*template.CapacityProviderStrategy{
+				{
+					CapacityProvider: "FARGATE",
+					Base:             aws.Int(1),
+					Weight:           aws.Int(1),
+				},
+				{
+					CapacityProvider: "FARGATE_SPOT",
+					Weight:           aws.Int(3),
+				},
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// WHEN
+			got, err := capacityProviderStrategy(tc.inPlatform)
+
+			// THEN
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.wanted, got)
+			}
+		})
+	}
+}
+
+func TestNetworkOpts(t *testing.T) {
+	testCases := map[string]struct {
+		inNetwork manifest.NetworkConfig
+
+		wanted *template.NetworkOpts
+	}{
+		"should return nil if the placement is not configured": {},
+		"should place tasks in the public subnets with a public IP": {
+			inNetwork: manifest.NetworkConfig{
+				VPC: manifest.VPCConfig{
+					Placement: aws.String(manifest.PublicSubnetPlacement),
+				},
+			},
+			wanted: &template.NetworkOpts{
+				AssignPublicIP: template.EnablePublicIP,
+				SubnetsType:    template.PublicSubnetsPlacement,
+			},
+		},
+		"should attach security groups and allow ingress from services": {
+			inNetwork: manifest.NetworkConfig{
+				VPC: manifest.VPCConfig{
+					SecurityGroups: []string{"sg-1234"},
+					Ingress: manifest.VPCIngress{
+						Services: []string{"frontend"},
+					},
+				},
+			},
+			wanted: &template.NetworkOpts{
+				AssignPublicIP:  template.EnablePublicIP,
+				SubnetsType:     template.PublicSubnetsPlacement,
+				SecurityGroups:  []string{"sg-1234"},
+				AllowedServices: []string{"frontend"},
+			},
+		},
+		"should place tasks in the private subnets without a public IP": {
+			inNetwork: manifest.NetworkConfig{
+				VPC: manifest.VPCConfig{
+					Placement: aws.String(manifest.PrivateSubnetPlacement),
+				},
+			},
+			wanted: &template.NetworkOpts{
+				AssignPublicIP: template.DisablePublicIP,
+				SubnetsType:    template.PrivateSubnetsPlacement,
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// WHEN
+			got := networkOpts(tc.inNetwork)
+
+			// THEN
+			require.Equal(t, tc.wanted, got)
+		})
+	}
+}
+
+func TestHTTPHealthCheckOpts(t *testing.T) {
+	duration := func(d time.Duration) *time.Duration { return &d }
+	testCases := map[string]struct {
+		inHealthCheck manifest.HealthCheckArgsOrString
+
+		wanted    template.HTTPHealthCheckOpts
+		wantedErr error
+	}{
+		"returns the defaults for a health check path": {
+			inHealthCheck: manifest.HealthCheckArgsOrString{
+				HealthCheckPath: aws.String("/ping"),
+			},
+		},
+		"returns an error if the interval is out of range": {
+			inHealthCheck: manifest.HealthCheckArgsOrString{
+				HealthCheckArgs: manifest.HTTPHealthCheckArgs{
+					Interval: duration(time.Second),
+				},
+			},
+			wantedErr: errors.New("health check interval 1s must be between 5s and 5m0s"),
+		},
+		"returns an error if the timeout is not less than the interval": {
+			inHealthCheck: manifest.HealthCheckArgsOrString{
+				HealthCheckArgs: manifest.HTTPHealthCheckArgs{
+					Timeout: duration(10 * time.Second),
+				},
+			},
+			wantedErr: errors.New("health check timeout 10s must be less than the interval 10s"),
+		},
+		"converts every setting": {
+			inHealthCheck: manifest.HealthCheckArgsOrString{
+				HealthCheckArgs: manifest.HTTPHealthCheckArgs{
+					Path:               aws.String("/ping"),
+					HealthyThreshold:   aws.Int(3),
+					UnhealthyThreshold: aws.Int(4),
+					SuccessCodes:       aws.String("200-299"),
+					Interval:           duration(time.Minute),
+					Timeout:            duration(30 * time.Second),
+				},
+			},
+			wanted: template.HTTPHealthCheckOpts{
+				HealthyThreshold:   aws.Int(3),
+				UnhealthyThreshold: aws.Int(4),
+				SuccessCodes:       aws.String("200-299"),
+				Interval:           aws.Int(60),
+				Timeout:            aws.Int(30),
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// WHEN
+			got, err := httpHealthCheckOpts(tc.inHealthCheck)
+
+			// THEN
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.wanted, got)
+			}
+		})
+	}
+}
+
+func TestNLBOpts(t *testing.T) {
+	duration := func(d time.Duration) *time.Duration { return &d }
+	port := func(p uint16) *uint16 { return &p }
+	sidecars := map[string]manifest.SidecarConfig{
+		"dns": {
+			Port: "53/udp",
+		},
+	}
+	testCases := map[string]struct {
+		inNLB manifest.NetworkLoadBalancerConfiguration
+
+		wanted    *template.NetworkLoadBalancerOpts
+		wantedErr error
+	}{
+		"returns nil without listeners": {},
+		"returns an error if the alias doesn't have listeners": {
+			inNLB: manifest.NetworkLoadBalancerConfiguration{
+				Alias: aws.String("mqtt.phonetool.example.com"),
+			},
+			wantedErr: errNLBAliasWithoutListeners,
+		},
+		"returns an error if a port is defined twice": {
+			inNLB: manifest.NetworkLoadBalancerConfiguration{
+				Listeners: []manifest.NetworkLoadBalancerListener{
+					{Port: "1883/tcp"},
+					{Port: "1883/udp"},
+				},
+			},
+			wantedErr: errors.New("network load balancer listener port 1883 is defined more than once"),
+		},
+		"returns an error if a TLS listener doesn't have a certificate": {
+			inNLB: manifest.NetworkLoadBalancerConfiguration{
+				Listeners: []manifest.NetworkLoadBalancerListener{
+					{Port: "8883/tls"},
+				},
+			},
+			wantedErr: errors.New(`listener 8883/tls requires a "certificate"`),
+		},
+		"returns an error if a TCP listener has a certificate": {
+			inNLB: manifest.NetworkLoadBalancerConfiguration{
+				Listeners: []manifest.NetworkLoadBalancerListener{
+					{Port: "1883/tcp", Certificate: aws.String("arn:aws:acm:us-west-2:123456789012:certificate/abc")},
+				},
+			},
+			wantedErr: errors.New(`listener 1883/tcp must use the "tls" protocol to have a "certificate"`),
+		},
+		"returns an error if the sidecar doesn't exist": {
+			inNLB: manifest.NetworkLoadBalancerConfiguration{
+				Listeners: []manifest.NetworkLoadBalancerListener{
+					{Port: "53/tcp_udp", TargetContainer: aws.String("bind")},
+				},
+			},
+			wantedErr: errors.New("target container bind of listener 53/tcp_udp doesn't exist"),
+		},
+		"returns an error if a UDP listener doesn't have a health check port": {
+			inNLB: manifest.NetworkLoadBalancerConfiguration{
+				Listeners: []manifest.NetworkLoadBalancerListener{
+					{Port: "53/udp", TargetContainer: aws.String("dns")},
+				},
+			},
+			wantedErr: errors.New(`listener 53/udp requires a "healthcheck.port", since the targets are health checked over TCP`),
+		},
+		"returns an error if the sidecar doesn't expose the target port": {
+			inNLB: manifest.NetworkLoadBalancerConfiguration{
+				Listeners: []manifest.NetworkLoadBalancerListener{
+					{Port: "53/tcp_udp", TargetContainer: aws.String("dns")},
+				},
+			},
+			wantedErr: errors.New("sidecar dns must expose port 53/tcp_udp to be the target of listener 53/tcp_udp"),
+		},
+		"returns an error if the health check interval is invalid": {
+			inNLB: manifest.NetworkLoadBalancerConfiguration{
+				Listeners: []manifest.NetworkLoadBalancerListener{
+					{
+						Port: "1883/tcp",
+						HealthCheck: manifest.NLBHealthCheckArgs{
+							Interval: duration(15 * time.Second),
+						},
+					},
+				},
+			},
+			wantedErr: errors.New("listener 1883/tcp: health check interval 15s must be either 10s or 30s"),
+		},
+		"accepts the traffic of TLS listeners from the VPC only": {
+			inNLB: manifest.NetworkLoadBalancerConfiguration{
+				Listeners: []manifest.NetworkLoadBalancerListener{
+					{
+						Port:        "443/tls",
+						TargetPort:  port(8443),
+						Certificate: aws.String("arn:aws:acm:us-west-2:123456789012:certificate/abc"),
+					},
+				},
+			},
+			wanted: &template.NetworkLoadBalancerOpts{
+				Listeners: []*template.NetworkLoadBalancerListenerOpts{
+					{
+						Port:           443,
+						Protocol:       "TLS",
+						TargetPort:     8443,
+						TargetProtocol: "TCP",
+						Certificate:    aws.String("arn:aws:acm:us-west-2:123456789012:certificate/abc"),
+					},
+				},
+				PortMappings: []*template.PortMappingOpts{
+					{Port: 8443, Protocol: "tcp"},
+				},
+				Ingress: []*template.NLBIngressOpts{
+					{Port: 8443, Protocol: "tcp"},
+				},
+			},
+		},
+		"converts the listeners of the main container and of the sidecars": {
+			inNLB: manifest.NetworkLoadBalancerConfiguration{
+				Listeners: []manifest.NetworkLoadBalancerListener{
+					{Port: "80/tcp"},
+					{
+						Port:        "8883/tls",
+						TargetPort:  port(1883),
+						Certificate: aws.String("arn:aws:acm:us-west-2:123456789012:certificate/abc"),
+						HealthCheck: manifest.NLBHealthCheckArgs{
+							Port:               port(8080),
+							HealthyThreshold:   aws.Int(3),
+							UnhealthyThreshold: aws.Int(3),
+							Interval:           duration(30 * time.Second),
+						},
+					},
+					{Port: "1883/tcp_udp", TargetContainer: aws.String("api")},
+					{
+						Port:            "53/udp",
+						TargetContainer: aws.String("dns"),
+						HealthCheck: manifest.NLBHealthCheckArgs{
+							Port: port(8053),
+						},
+					},
+				},
+				Alias: aws.String("mqtt.phonetool.example.com"),
+			},
+			wanted: &template.NetworkLoadBalancerOpts{
+				Listeners: []*template.NetworkLoadBalancerListenerOpts{
+					{
+						Port:           80,
+						Protocol:       "TCP",
+						TargetPort:     80,
+						TargetProtocol: "TCP",
+					},
+					{
+						Port:           8883,
+						Protocol:       "TLS",
+						TargetPort:     1883,
+						TargetProtocol: "TCP",
+						Certificate:    aws.String("arn:aws:acm:us-west-2:123456789012:certificate/abc"),
+						HealthCheck: template.NLBHealthCheckOpts{
+							Port:               aws.Int(8080),
+							HealthyThreshold:   aws.Int(3),
+							UnhealthyThreshold: aws.Int(3),
+							Interval:           aws.Int(30),
+						},
+					},
+					{
+						Port:           1883,
+						Protocol:       "TCP_UDP",
+						TargetPort:     1883,
+						TargetProtocol: "TCP_UDP",
+					},
+					{
+						Port:            53,
+						Protocol:        "UDP",
+						TargetContainer: aws.String("dns"),
+						TargetPort:      53,
+						TargetProtocol:  "UDP",
+						HealthCheck: template.NLBHealthCheckOpts{
+							Port: aws.Int(8053),
+						},
+					},
+				},
+				PortMappings: []*template.PortMappingOpts{
+					{Port: 1883, Protocol: "tcp"},
+					{Port: 1883, Protocol: "udp"},
+				},
+				Ingress: []*template.NLBIngressOpts{
+					{Port: 80, Protocol: "tcp"},
+					{Port: 1883, Protocol: "tcp", FromAnywhere: true},
+					{Port: 8080, Protocol: "tcp"}, // Health checks of the TLS listener.
+					{Port: 1883, Protocol: "udp", FromAnywhere: true},
+					{Port: 53, Protocol: "udp", FromAnywhere: true},
+					{Port: 8053, Protocol: "tcp"}, // Health checks of the UDP listener.
+				},
+				Alias: aws.String("mqtt.phonetool.example.com"),
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// WHEN
+			got, err := nlbOpts(tc.inNLB, "api", 80, sidecars)
+
+			// THEN
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.wanted, got)
+			}
+		})
+	}
+}
+
+func TestDeregistrationDelaySeconds(t *testing.T) {
+	duration := func(d time.Duration) *time.Duration { return &d }
+	testCases := map[string]struct {
+		inDelay *time.Duration
+
+		wanted    *int
+		wantedErr error
+	}{
+		"returns nil if the delay is not configured": {},
+		"returns an error if the delay is out of range": {
+			inDelay:   duration(2 * time.Hour),
+			wantedErr: errors.New("deregistration delay 2h0m0s must be between 0s and 1h0m0s"),
+		},
+		"converts the delay to seconds": {
+			inDelay: duration(30 * time.Second),
+			wanted:  aws.Int(30),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// WHEN
+			got, err := deregistrationDelaySeconds(tc.inDelay)
+
+			// THEN
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.wanted, got)
+			}
+		})
+	}
+}
+
+func TestQueueOpts(t *testing.T) {
+	duration := func(d time.Duration) *time.Duration { return &d }
+	testCases := map[string]struct {
+		in manifest.SQSQueue
+
+		wanted    *template.QueueOpts
+		wantedErr error
+	}{
+		"converts durations to seconds": {
+			in: manifest.SQSQueue{
+				Retention: duration(96 * time.Hour),
+				Delay:     duration(30 * time.Second),
+				Timeout:   duration(5 * time.Minute),
+				DeadLetter: manifest.DeadLetterQueue{
+					Tries: aws.Int(5),
+				},
+			},
+			wanted: &template.QueueOpts{
+				Retention:       aws.Int(345600),
+				Delay:           aws.Int(30),
+				Timeout:         aws.Int(300),
+				DeadLetterTries: 5,
+			},
+		},
+		"returns an error if retention is too short": {
+			in: manifest.SQSQueue{
+				Retention: duration(time.Second),
+			},
+			wantedErr: errors.New("queue retention 1s must be between 1m0s and 336h0m0s"),
+		},
+		"returns an error if delay is too long": {
+			in: manifest.SQSQueue{
+				Delay: duration(time.Hour),
+			},
+			wantedErr: errors.New("queue delay 1h0m0s must be between 0s and 15m0s"),
+		},
+		"returns an error if timeout is too long": {
+			in: manifest.SQSQueue{
+				Timeout: duration(24 * time.Hour),
+			},
+			wantedErr: errors.New("queue timeout 24h0m0s must be between 0s and 12h0m0s"),
+		},
+		"returns an error if dead-letter tries are missing": {
+			in:        manifest.SQSQueue{},
+			wantedErr: errors.New("dead-letter queue tries 0 must be between 1 and 1000"),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// WHEN
+			got, err := queueOpts(tc.in)
+
+			// THEN
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.wanted, got)
+			}
+		})
+	}
+}
+
+func TestConvertSecrets(t *testing.T) {
+	testCases := map[string]struct {
+		in map[string]manifest.SecretArgsOrString
+
+		wanted    map[string]string
+		wantedErr error
+	}{
+		"nil if there are no secrets": {},
+		"converts the secrets into their ValueFrom": {
+			in: map[string]manifest.SecretArgsOrString{
+				"GITHUB_TOKEN": {SecretString: aws.String("GITHUB_TOKEN")},
+				"API_KEY":      {SecretString: aws.String("arn:aws:ssm:us-west-2:210987654321:parameter/api/key")},
+				"DB_PASSWORD":  {SecretString: aws.String("arn:aws:secretsmanager:us-west-2:123456789012:secret:db-AbCdEf:password::")},
+				"DB_USER": {SecretArgs: manifest.SecretArgs{
+					From:         aws.String("arn:aws:secretsmanager:us-west-2:123456789012:secret:db-AbCdEf"),
+					JSONKey:      aws.String("username"),
+					VersionStage: aws.String("AWSPREVIOUS"),
+				}},
+				"LICENSE": {SecretArgs: manifest.SecretArgs{
+					From:   aws.String("/license"),
+					KMSKey: aws.String("arn:aws:kms:us-west-2:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab"),
+				}},
+			},
+			wanted: map[string]string{
+				"GITHUB_TOKEN": "GITHUB_TOKEN",
+				"API_KEY":      "arn:aws:ssm:us-west-2:210987654321:parameter/api/key",
+				"DB_PASSWORD":  "arn:aws:secretsmanager:us-west-2:123456789012:secret:db-AbCdEf:password::",
+				"DB_USER":      "arn:aws:secretsmanager:us-west-2:123456789012:secret:db-AbCdEf:username:AWSPREVIOUS:",
+				"LICENSE":      "/license",
+			},
+		},
+		"returns an error if from is missing": {
+			in: map[string]manifest.SecretArgsOrString{
+				"DB_USER": {SecretArgs: manifest.SecretArgs{
+					JSONKey: aws.String("username"),
+				}},
+			},
+			wantedErr: errors.New(`secret DB_USER: "from" is required`),
+		},
+		"returns an error if both the version stage and id are set": {
+			in: map[string]manifest.SecretArgsOrString{
+				"DB_USER": {SecretArgs: manifest.SecretArgs{
+					From:         aws.String("arn:aws:secretsmanager:us-west-2:123456789012:secret:db-AbCdEf"),
+					VersionStage: aws.String("AWSCURRENT"),
+					VersionID:    aws.String("EXAMPLE1-90ab-cdef-fedc-ba987EXAMPLE"),
+				}},
+			},
+			wantedErr: errors.New(`secret DB_USER: must specify one, not both, of "version_stage" and "version_id"`),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// WHEN
+			got, err := convertSecrets(tc.in)
+
+			// THEN
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.wanted, got)
+			}
+		})
+	}
+}
+
+func TestSecretsPolicyOpts(t *testing.T) {
+	testCases := map[string]struct {
+		inSecrets  map[string]manifest.SecretArgsOrString
+		inSidecars map[string]manifest.SidecarConfig
+
+		wanted *template.SecretsPolicyOpts
+	}{
+		"nil if there are no secrets": {
+			inSidecars: map[string]manifest.SidecarConfig{
+				"nginx": {Image: "nginx"},
+			},
+		},
+		"grants access to exactly the secrets of the containers": {
+			inSecrets: map[string]manifest.SecretArgsOrString{
+				"GITHUB_TOKEN": {SecretString: aws.String("GITHUB_TOKEN")},
+				"API_KEY":      {SecretString: aws.String("arn:aws:ssm:us-west-2:210987654321:parameter/api/key")},
+				"DB_PASSWORD":  {SecretString: aws.String("arn:aws:secretsmanager:us-west-2:123456789012:secret:db-AbCdEf:password::")},
+				"DB_USER": {SecretArgs: manifest.SecretArgs{
+					From:    aws.String("arn:aws:secretsmanager:us-west-2:123456789012:secret:db-AbCdEf"),
+					JSONKey: aws.String("username"),
+					KMSKey:  aws.String("arn:aws:kms:us-west-2:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab"),
+				}},
+			},
+			inSidecars: map[string]manifest.SidecarConfig{
+				"nginx": {
+					Image: "nginx",
+					Secrets: map[string]manifest.SecretArgsOrString{
+						"LICENSE": {SecretString: aws.String("/nginx/license")},
+					},
+				},
+				"xray": {
+					Image:     "private/xray",
+					CredParam: "registry-credentials",
+				},
+			},
+			wanted: &template.SecretsPolicyOpts{
+				SSMParameters: []string{
+					"arn:${AWS::Partition}:ssm:${AWS::Region}:${AWS::AccountId}:parameter/GITHUB_TOKEN",
+					"arn:${AWS::Partition}:ssm:${AWS::Region}:${AWS::AccountId}:parameter/nginx/license",
+					"arn:aws:ssm:us-west-2:210987654321:parameter/api/key",
+				},
+				Secrets: []string{
+					"arn:${AWS::Partition}:secretsmanager:${AWS::Region}:${AWS::AccountId}:secret:registry-credentials-??????",
+					"arn:aws:secretsmanager:us-west-2:123456789012:secret:db-AbCdEf",
+				},
+				KMSKeys: []string{
+					"arn:aws:kms:us-west-2:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab",
+				},
+			},
+		},
+		"matches the random suffix of Secrets Manager ARNs written without it": {
+			inSecrets: map[string]manifest.SecretArgsOrString{
+				"DB_PASSWORD": {SecretString: aws.String("arn:aws:secretsmanager:us-west-2:123456789012:secret:db-creds:password::")},
+				"DB_USER": {SecretArgs: manifest.SecretArgs{
+					From:    aws.String("arn:aws:secretsmanager:us-west-2:123456789012:secret:db-creds"),
+					JSONKey: aws.String("username"),
+				}},
+			},
+			inSidecars: map[string]manifest.SidecarConfig{
+				"xray": {
+					Image:     "private/xray",
+					CredParam: "arn:aws:secretsmanager:us-west-2:123456789012:secret:registry",
+				},
+			},
+			wanted: &template.SecretsPolicyOpts{
+				Secrets: []string{
+					"arn:aws:secretsmanager:us-west-2:123456789012:secret:db-creds-??????",
+					"arn:aws:secretsmanager:us-west-2:123456789012:secret:registry-??????",
+				},
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.wanted, secretsPolicyOpts(tc.inSecrets, tc.inSidecars))
+		})
+	}
+}
+
+func TestStringSliceOpts(t *testing.T) {
+	testCases := map[string]struct {
+		in manifest.StringSliceOrString
+
+		wanted []*string
+	}{
+		"nil if not set": {},
+		"splits the string form into arguments": {
+			in: manifest.StringSliceOrString{
+				String: aws.String(`echo "hello world"`),
+			},
+			wanted: aws.StringSlice([]string{"echo", "hello world"}),
+		},
+		"keeps the list form": {
+			in: manifest.StringSliceOrString{
+				StringSlice: []string{"/bin/sh", "-c", "echo hello"},
+			},
+			wanted: aws.StringSlice([]string{"/bin/sh", "-c", "echo hello"}),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.wanted, stringSliceOpts(tc.in))
+		})
+	}
+}
+
+func TestEnvFilesPolicyOpts(t *testing.T) {
+	testCases := map[string]struct {
+		inEnvFiles map[string]string
+
+		wanted *template.EnvFilesPolicyOpts
+	}{
+		"returns nil if there are no environment files": {},
+		"returns the objects and their buckets": {
+			inEnvFiles: map[string]string{
+				"frontend": "stackset-bucket/manual/1600000000/frontend.env",
+				"nginx":    "stackset-bucket/manual/1600000000/nginx.env",
+			},
+			wanted: &template.EnvFilesPolicyOpts{
+				Objects: []string{
+					"arn:${AWS::Partition}:s3:::stackset-bucket/manual/1600000000/frontend.env",
+					"arn:${AWS::Partition}:s3:::stackset-bucket/manual/1600000000/nginx.env",
+				},
+				Buckets: []string{"arn:${AWS::Partition}:s3:::stackset-bucket"},
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.wanted, envFilesPolicyOpts(tc.inEnvFiles))
+		})
+	}
+}
+
+func TestConvertSidecar(t *testing.T) {
+	duration := func(d time.Duration) *time.Duration { return &d }
+	testCases := map[string]struct {
+		inSidecars map[string]manifest.SidecarConfig
+		inVolumes  map[string]manifest.Volume
+		inEnvFiles map[string]string
+
+		wanted    []*template.SidecarOpts
+		wantedErr error
+	}{
+		"returns nil if there are no sidecars": {},
+		"returns an error if the port mapping is invalid": {
+			inSidecars: map[string]manifest.SidecarConfig{
+				"xray": {
+					Image: "amazon/aws-xray-daemon",
+					Port:  "2000/udp/tcp",
+				},
+			},
+			wantedErr: errors.New("sidecar xray: cannot parse port mapping from 2000/udp/tcp"),
+		},
+		"returns an error if the source volume is not defined": {
+			inSidecars: map[string]manifest.SidecarConfig{
+				"nginx": {
+					Image: "nginx",
+					MountPoints: []manifest.SidecarMountPoint{
+						{
+							SourceVolume: aws.String("data"),
+							MountPointOpts: manifest.MountPointOpts{
+								ContainerPath: aws.String("/var/www"),
+							},
+						},
+					},
+				},
+			},
+			wantedErr: errors.New(`sidecar nginx: source volume "data" is not defined under "storage.volumes"`),
+		},
+		"returns an error if the path of a mount point is missing": {
+			inSidecars: map[string]manifest.SidecarConfig{
+				"nginx": {
+					Image: "nginx",
+					MountPoints: []manifest.SidecarMountPoint{
+						{
+							SourceVolume: aws.String("data"),
+						},
+					},
+				},
+			},
+			inVolumes: map[string]manifest.Volume{
+				"data": {},
+			},
+			wantedErr: errors.New(`sidecar nginx: "path" is required to mount volume data`),
+		},
+		"returns an error if the healthcheck has no command": {
+			inSidecars: map[string]manifest.SidecarConfig{
+				"nginx": {
+					Image: "nginx",
+					HealthCheck: &manifest.ContainerHealthCheck{
+						Retries: aws.Int(3),
+					},
+				},
+			},
+			wantedErr: errors.New(`sidecar nginx: "command" is required for the healthcheck`),
+		},
+		"returns an error if a secret is invalid": {
+			inSidecars: map[string]manifest.SidecarConfig{
+				"nginx": {
+					Image: "nginx",
+					Secrets: map[string]manifest.SecretArgsOrString{
+						"TOKEN": {SecretArgs: manifest.SecretArgs{
+							From:    aws.String("/app/token"),
+							JSONKey: aws.String("token"),
+						}},
+					},
+				},
+			},
+			wantedErr: errors.New(`sidecar nginx: secret TOKEN: "json_key", "version_stage" and "version_id" are only supported by Secrets Manager secrets`),
+		},
+		"converts the container configuration": {
+			inSidecars: map[string]manifest.SidecarConfig{
+				"nginx": {
+					Image:     "nginx",
+					Essential: aws.Bool(false),
+					Variables: map[string]string{"LOG_LEVEL": "info"},
+					Secrets: map[string]manifest.SecretArgsOrString{
+						"TOKEN": {SecretString: aws.String("/app/token")},
+						"DB_USER": {SecretArgs: manifest.SecretArgs{
+							From:    aws.String("arn:aws:secretsmanager:us-west-2:123456789012:secret:db-AbCdEf"),
+							JSONKey: aws.String("username"),
+						}},
+					},
+					DependsOn:  map[string]string{"frontend": "START"},
+					Command:    manifest.StringSliceOrString{String: aws.String("nginx -g 'daemon off;'")},
+					EntryPoint: manifest.StringSliceOrString{StringSlice: []string{"/docker-entrypoint.sh"}},
+					HealthCheck: &manifest.ContainerHealthCheck{
+						Command:  []string{"CMD-SHELL", "curl -f http://localhost || exit 1"},
+						Interval: duration(10 * time.Second),
+					},
+				},
+			},
+			inEnvFiles: map[string]string{
+				"nginx":    "stackset-bucket/manual/1600000000/nginx.env",
+				"frontend": "stackset-bucket/manual/1600000000/frontend.env",
+			},
+			wanted: []*template.SidecarOpts{
+				{
+					Name:       aws.String("nginx"),
+					Image:      aws.String("nginx"),
+					Essential:  aws.Bool(false),
+					Variables:  map[string]string{"LOG_LEVEL": "info"},
+					EnvFileARN: aws.String("arn:${AWS::Partition}:s3:::stackset-bucket/manual/1600000000/nginx.env"),
+					Secrets: map[string]string{
+						"TOKEN":   "/app/token",
+						"DB_USER": "arn:aws:secretsmanager:us-west-2:123456789012:secret:db-AbCdEf:username::",
+					},
+					DependsOn:  map[string]string{"frontend": "START"},
+					Command:    aws.StringSlice([]string{"nginx", "-g", "daemon off;"}),
+					EntryPoint: aws.StringSlice([]string{"/docker-entrypoint.sh"}),
+					HealthCheck: &ecs.HealthCheck{
+						Command:  aws.StringSlice([]string{"CMD-SHELL", "curl -f http://localhost || exit 1"}),
+						Interval: aws.Int64(10),
+					},
+				},
+			},
+		},
+		"converts sidecars sorted by name": {
+			inSidecars: map[string]manifest.SidecarConfig{
+				"xray": {
+					Image:     "amazon/aws-xray-daemon",
+					Port:      "2000/udp",
+					CredParam: "some arn",
+				},
+				"nginx": {
+					Image: "nginx",
+					Port:  "80",
+					MountPoints: []manifest.SidecarMountPoint{
+						{
+							SourceVolume: aws.String("data"),
+							MountPointOpts: manifest.MountPointOpts{
+								ContainerPath: aws.String("/var/www"),
+								ReadOnly:      aws.Bool(false),
+							},
+						},
+					},
+				},
+			},
+			inVolumes: map[string]manifest.Volume{
+				"data": {},
+			},
+			wanted: []*template.SidecarOpts{
+				{
+					Name:  aws.String("nginx"),
+					Image: aws.String("nginx"),
+					Port:  aws.String("80"),
+					MountPoints: []*template.MountPoint{
+						{
+							ContainerPath: "/var/www",
+							ReadOnly:      false,
+							SourceVolume:  "data",
+						},
+					},
+				},
+				{
+					Name:       aws.String("xray"),
+					Image:      aws.String("amazon/aws-xray-daemon"),
+					Port:       aws.String("2000"),
+					Protocol:   aws.String("udp"),
+					CredsParam: aws.String("some arn"),
+				},
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// WHEN
+			got, err := convertSidecar(tc.inSidecars, tc.inVolumes, tc.inEnvFiles)
+
+			// THEN
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.wanted, got)
+			}
+		})
+	}
+}
+
+func TestConvertStorageOpts(t *testing.T) {
+	testCases := map[string]struct {
+		inStorage  manifest.Storage
+		inSidecars map[string]manifest.SidecarConfig
+
+		wanted    *template.StorageOpts
+		wantedErr error
+	}{
+		"returns nil if there are no volumes": {},
+		"returns an error if the volume is not backed by EFS": {
+			inStorage: manifest.Storage{
+				Volumes: map[string]manifest.Volume{
+					"data": {
+						EFS: manifest.EFSConfigOrBool{
+							Enabled: aws.Bool(false),
+						},
+					},
+				},
+			},
+			wantedErr: errors.New(`volume data must specify an EFS file system with "efs"`),
+		},
+		"returns an error if the file system id is missing": {
+			inStorage: manifest.Storage{
+				Volumes: map[string]manifest.Volume{
+					"data": {
+						EFS: manifest.EFSConfigOrBool{
+							Advanced: manifest.EFSVolumeConfiguration{
+								RootDirectory: aws.String("/data"),
+							},
+						},
+					},
+				},
+			},
+			wantedErr: errors.New(`volume data: "id" is required for an existing EFS file system`),
+		},
+		"returns an error if an access point is used with a root directory": {
+			inStorage: manifest.Storage{
+				Volumes: map[string]manifest.Volume{
+					"data": {
+						EFS: manifest.EFSConfigOrBool{
+							Advanced: manifest.EFSVolumeConfiguration{
+								FileSystemID:  aws.String("fs-1234"),
+								RootDirectory: aws.String("/data"),
+								AuthConfig: manifest.AuthorizationConfig{
+									AccessPointID: aws.String("fsap-1234"),
+								},
+							},
+						},
+					},
+				},
+			},
+			wantedErr: errors.New(`volume data: "root_dir" must be empty or "/" when "access_point_id" is specified`),
+		},
+		"converts existing and managed file systems": {
+			inStorage: manifest.Storage{
+				Volumes: map[string]manifest.Volume{
+					"shared": {
+						EFS: manifest.EFSConfigOrBool{
+							Advanced: manifest.EFSVolumeConfiguration{
+								FileSystemID: aws.String("fs-1234"),
+								AuthConfig: manifest.AuthorizationConfig{
+									IAM:           aws.Bool(true),
+									AccessPointID: aws.String("fsap-1234"),
+								},
+							},
+						},
+						MountPointOpts: manifest.MountPointOpts{
+							ContainerPath: aws.String("/etc/shared"),
+						},
+					},
+					"data": {
+						EFS: manifest.EFSConfigOrBool{
+							Enabled: aws.Bool(true),
+						},
+					},
+				},
+			},
+			inSidecars: map[string]manifest.SidecarConfig{
+				"nginx": {
+					MountPoints: []manifest.SidecarMountPoint{
+						{
+							SourceVolume: aws.String("data"),
+							MountPointOpts: manifest.MountPointOpts{
+								ContainerPath: aws.String("/var/www"),
+								ReadOnly:      aws.Bool(false),
+							},
+						},
+					},
+				},
+			},
+			wanted: &template.StorageOpts{
+				Volumes: []*template.Volume{
+					{
+						Name: "data",
+						EFS:  &template.EFSVolumeConfiguration{},
+					},
+					{
+						Name: "shared",
+						EFS: &template.EFSVolumeConfiguration{
+							Filesystem:    aws.String("fs-1234"),
+							IAM:           aws.String("ENABLED"),
+							AccessPointID: aws.String("fsap-1234"),
+						},
+					},
+				},
+				MountPoints: []*template.MountPoint{
+					{
+						ContainerPath: "/etc/shared",
+						ReadOnly:      true,
+						SourceVolume:  "shared",
+					},
+				},
+				EFSPerms: []*template.EFSPermission{
+					{
+						Filesystem:    aws.String("fs-1234"),
+						AccessPointID: aws.String("fsap-1234"),
+						Write:         false,
+					},
+					{
+						Write: true,
+					},
+				},
+				ManagedEFS: true,
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// WHEN
+			got, err := convertStorageOpts(tc.inStorage, tc.inSidecars)
+
+			// THEN
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.wanted, got)
+			}
+		})
+	}
+}
+
+func TestLogConfigOpts(t *testing.T) {
+	testCases := map[string]struct {
+		inLogging manifest.Logging
+
+		wanted    *template.LogConfigOpts
+		wantedErr error
+	}{
+		"returns nil if logging is not configured": {},
+		"returns an error if multiple destinations are set": {
+			inLogging: manifest.Logging{
+				Destination: manifest.LogDestination{
+					Firehose: &manifest.FirehoseDestination{
+						DeliveryStream: aws.String("my-stream"),
+					},
+					CloudWatch: &manifest.CloudWatchDestination{
+						LogGroup: aws.String("my-group"),
+					},
+				},
+			},
+			wantedErr: errMultipleLogDestinations,
+		},
+		"returns an error if there is neither a destination nor a config file": {
+			inLogging: manifest.Logging{
+				EnableMetadata: aws.Bool(false),
+			},
+			wantedErr: errLogDestinationRequired,
+		},
+		"returns an error if the delivery stream is missing": {
+			inLogging: manifest.Logging{
+				Destination: manifest.LogDestination{
+					Firehose: &manifest.FirehoseDestination{},
+				},
+			},
+			wantedErr: errors.New(`"delivery_stream" is required for the firehose log destination`),
+		},
+		"firehose destination with defaults": {
+			inLogging: manifest.Logging{
+				Destination: manifest.LogDestination{
+					Firehose: &manifest.FirehoseDestination{
+						DeliveryStream: aws.String("my-stream"),
+					},
+				},
+			},
+			wanted: &template.LogConfigOpts{
+				Image:          aws.String(defaultFluentbitImage),
+				EnableMetadata: aws.String("true"),
+				Destination: map[string]string{
+					"Name":            "firehose",
+					"region":          "!Ref AWS::Region",
+					"delivery_stream": `"my-stream"`,
+				},
+				FirehoseStream: aws.String("my-stream"),
+			},
+		},
+		"cloudwatch destination in another region": {
+			inLogging: manifest.Logging{
+				Image:          aws.String("123456789012.dkr.ecr.us-west-2.amazonaws.com/fluent-bit"),
+				EnableMetadata: aws.Bool(false),
+				Destination: manifest.LogDestination{
+					CloudWatch: &manifest.CloudWatchDestination{
+						LogGroup:     aws.String("my-group"),
+						StreamPrefix: aws.String("api/"),
+						Region:       aws.String("us-east-1"),
+					},
+				},
+			},
+			wanted: &template.LogConfigOpts{
+				Image:          aws.String("123456789012.dkr.ecr.us-west-2.amazonaws.com/fluent-bit"),
+				EnableMetadata: aws.String("false"),
+				Destination: map[string]string{
+					"Name":              "cloudwatch",
+					"region":            `"us-east-1"`,
+					"log_group_name":    `"my-group"`,
+					"log_stream_prefix": `"api/"`,
+					"auto_create_group": `"true"`,
+				},
+				LogGroup: aws.String("my-group"),
+			},
+		},
+		"elasticsearch destination": {
+			inLogging: manifest.Logging{
+				Destination: manifest.LogDestination{
+					Elasticsearch: &manifest.ElasticsearchDestination{
+						Host:  aws.String("search-logs.us-west-2.es.amazonaws.com"),
+						Index: aws.String("api"),
+					},
+				},
+			},
+			wanted: &template.LogConfigOpts{
+				Image:          aws.String(defaultFluentbitImage),
+				EnableMetadata: aws.String("true"),
+				Destination: map[string]string{
+					"Name":       "es",
+					"Host":       `"search-logs.us-west-2.es.amazonaws.com"`,
+					"Port":       `"443"`,
+					"Index":      `"api"`,
+					"aws_auth":   `"On"`,
+					"aws_region": "!Ref AWS::Region",
+					"tls":        `"On"`,
+				},
+				Elasticsearch: true,
+			},
+		},
+		"custom config file only": {
+			inLogging: manifest.Logging{
+				ConfigFile: aws.String("/fluent-bit/etc/extra.conf"),
+			},
+			wanted: &template.LogConfigOpts{
+				Image:          aws.String(defaultFluentbitImage),
+				EnableMetadata: aws.String("true"),
+				ConfigFile:     aws.String("/fluent-bit/etc/extra.conf"),
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// WHEN
+			got, err := logConfigOpts(tc.inLogging)
+
+			// THEN
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.wanted, got)
+			}
+		})
+	}
+}
+
+func TestValidateDependsOn(t *testing.T) {
+	testCases := map[string]struct {
+		inMainHasHealthCheck bool
+		inSidecars           map[string]manifest.SidecarConfig
+
+		wantedErr error
+	}{
+		"valid dependencies": {
+			inSidecars: map[string]manifest.SidecarConfig{
+				"nginx": {
+					DependsOn: map[string]string{
+						"frontend": "START",
+						"xray":     "HEALTHY",
+						"init":     "SUCCESS",
+					},
+				},
+				"xray": {
+					HealthCheck: &manifest.ContainerHealthCheck{},
+				},
+				"init": {
+					Essential: aws.Bool(false),
+				},
+			},
+		},
+		"error if a sidecar depends on itself": {
+			inSidecars: map[string]manifest.SidecarConfig{
+				"nginx": {
+					DependsOn: map[string]string{"nginx": "START"},
+				},
+			},
+			wantedErr: errors.New("sidecar nginx cannot depend on itself"),
+		},
+		"error if the container does not exist": {
+			inSidecars: map[string]manifest.SidecarConfig{
+				"nginx": {
+					DependsOn: map[string]string{"xray": "START"},
+				},
+			},
+			wantedErr: errors.New("sidecar nginx depends on container xray which does not exist"),
+		},
+		"error if the container has no healthcheck": {
+			inSidecars: map[string]manifest.SidecarConfig{
+				"nginx": {
+					DependsOn: map[string]string{"frontend": "HEALTHY"},
+				},
+			},
+			wantedErr: errors.New("sidecar nginx depends on container frontend to be HEALTHY, but frontend does not have a healthcheck"),
+		},
+		"error if the container is essential": {
+			inMainHasHealthCheck: true,
+			inSidecars: map[string]manifest.SidecarConfig{
+				"nginx": {
+					DependsOn: map[string]string{"frontend": "COMPLETE"},
+				},
+			},
+			wantedErr: errors.New("sidecar nginx depends on container frontend to COMPLETE, but frontend is essential"),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// WHEN
+			err := validateDependsOn("frontend", tc.inMainHasHealthCheck, tc.inSidecars)
+
+			// THEN
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestDeploymentConfigurationOpts(t *testing.T) {
+	testCases := map[string]struct {
+		inDeployment manifest.DeploymentConfig
+
+		wanted    template.DeploymentConfigurationOpts
+		wantedErr error
+	}{
+		"should use the defaults if the deployment is not configured": {},
+		"should return an error if the deployment can't replace tasks": {
+			inDeployment: manifest.DeploymentConfig{
+				MaxPercent: aws.Int(100),
+			},
+			wantedErr: errDeploymentCannotProgress,
+		},
+		"should return an error if rollback is set without enabling the circuit breaker": {
+			inDeployment: manifest.DeploymentConfig{
+				CircuitBreaker: manifest.CircuitBreaker{
+					Rollback: aws.Bool(true),
+				},
+			},
+			wantedErr: errCircuitBreakerRollbackWithoutEnable,
+		},
+		"should return an error if the circuit breaker is set with blue/green deployments": {
+			inDeployment: manifest.DeploymentConfig{
+				Strategy: aws.String(manifest.BlueGreenDeploymentStrategy),
+				CircuitBreaker: manifest.CircuitBreaker{
+					Enable: aws.Bool(true),
+				},
+			},
+			wantedErr: errCircuitBreakerWithBlueGreen,
+		},
+		"should return an error if blue/green deployments are configured with the rolling strategy": {
+			inDeployment: manifest.DeploymentConfig{
+				BlueGreen: manifest.BlueGreenConfig{
+					TestPort: aws.Int(9000),
+				},
+			},
+			wantedErr: errBlueGreenWithoutStrategy,
+		},
+		"should convert the percentages and the circuit breaker": {
+			inDeployment: manifest.DeploymentConfig{
+				MinHealthyPercent: aws.Int(50),
+				MaxPercent:        aws.Int(100),
+				CircuitBreaker: manifest.CircuitBreaker{
+					Enable:   aws.Bool(true),
+					Rollback: aws.Bool(true),
+				},
+			},
+			wanted: template.DeploymentConfigurationOpts{
+				MinHealthyPercent: aws.Int(50),
+				MaxPercent:        aws.Int(100),
+				CircuitBreaker: &template.CircuitBreakerOpts{
+					Enable:   true,
+					Rollback: true,
+				},
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// WHEN
+			got, err := deploymentConfigurationOpts(tc.inDeployment)
+
+			// THEN
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.wanted, got)
+			}
+		})
+	}
+}
+
+func TestBlueGreenDeploymentOpts(t *testing.T) {
+	duration := func(d time.Duration) *time.Duration { return &d }
+	testCases := map[string]struct {
+		inDeployment manifest.DeploymentConfig
+
+		wanted    *template.BlueGreenDeploymentOpts
+		wantedErr error
+	}{
+		"should return nil for rolling deployments": {
+			inDeployment: manifest.DeploymentConfig{
+				Strategy: aws.String(manifest.RollingDeploymentStrategy),
+			},
+		},
+		"should shift all the traffic at once by default": {
+			inDeployment: manifest.DeploymentConfig{
+				Strategy: aws.String(manifest.BlueGreenDeploymentStrategy),
+			},
+			wanted: &template.BlueGreenDeploymentOpts{
+				TrafficRouting: template.AllAtOnceTrafficRouting,
+			},
+		},
+		"should return an error if steps are set when shifting all the traffic at once": {
+			inDeployment: manifest.DeploymentConfig{
+				Strategy: aws.String(manifest.BlueGreenDeploymentStrategy),
+				BlueGreen: manifest.BlueGreenConfig{
+					Percentage: aws.Int(20),
+				},
+			},
+			wantedErr: errTrafficShiftingStepsWithAllAtOnce,
+		},
+		"should use the defaults of canary deployments": {
+			inDeployment: manifest.DeploymentConfig{
+				Strategy: aws.String(manifest.BlueGreenDeploymentStrategy),
+				BlueGreen: manifest.BlueGreenConfig{
+					TrafficShifting: aws.String(manifest.CanaryTrafficShifting),
+				},
+			},
+			wanted: &template.BlueGreenDeploymentOpts{
+				TrafficRouting:  template.CanaryTrafficRouting,
+				Percentage:      10,
+				IntervalMinutes: 5,
+			},
+		},
+		"should convert linear deployments with alarms": {
+			inDeployment: manifest.DeploymentConfig{
+				Strategy: aws.String(manifest.BlueGreenDeploymentStrategy),
+				BlueGreen: manifest.BlueGreenConfig{
+					TrafficShifting: aws.String(manifest.LinearTrafficShifting),
+					Percentage:      aws.Int(25),
+					Interval:        duration(3 * time.Minute),
+					Alarms:          []string{"payments-5xx"},
+					TestPort:        aws.Int(9000),
+				},
+			},
+			wanted: &template.BlueGreenDeploymentOpts{
+				TrafficRouting:  template.LinearTrafficRouting,
+				Percentage:      25,
+				IntervalMinutes: 3,
+				Alarms:          []string{"payments-5xx"},
+				TestPort:        9000,
+			},
+		},
+		"should return an error if the interval isn't a whole number of minutes": {
+			inDeployment: manifest.DeploymentConfig{
+				Strategy: aws.String(manifest.BlueGreenDeploymentStrategy),
+				BlueGreen: manifest.BlueGreenConfig{
+					TrafficShifting: aws.String(manifest.CanaryTrafficShifting),
+					Interval:        duration(90 * time.Second),
+				},
+			},
+			wantedErr: errors.New("blue/green deployment interval 1m30s must be a whole number of minutes"),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// WHEN
+			got, err := blueGreenDeploymentOpts(tc.inDeployment)
+
+			// THEN
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.wanted, got)
+			}
+		})
+	}
+}
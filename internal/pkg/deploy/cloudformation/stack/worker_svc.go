@@ -0,0 +1,138 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package stack
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/addons"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/manifest"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/template"
+	"github.com/aws/aws-sdk-go/service/cloudformation"
+)
+
+var (
+	errQueueDepthAutoscalingWithoutQueue = errors.New(`"queue_depth" autoscaling is only supported by worker services`)
+)
+
+type workerSvcReadParser interface {
+	template.ReadParser
+	ParseWorkerService(template.ServiceOpts) (*template.Content, error)
+}
+
+// WorkerService represents the configuration needed to create a CloudFormation stack from a worker service manifest.
+type WorkerService struct {
+	*svc
+	manifest *manifest.WorkerService
+
+	parser workerSvcReadParser
+}
+
+// NewWorkerService creates a new WorkerService stack from a manifest file.
+func NewWorkerService(mft *manifest.WorkerService, env, app string, rc RuntimeConfig) (*WorkerService, error) {
+	parser := template.New()
+	addons, err := addons.New(mft.Name)
+	if err != nil {
+		return nil, fmt.Errorf("new addons: %w", err)
+	}
+	envManifest := mft.ApplyEnv(env) // Apply environment overrides to the manifest values.
+	return &WorkerService{
+		svc: &svc{
+			name:   mft.Name,
+			env:    env,
+			app:    app,
+			tc:     envManifest.TaskConfig,
+			image:  envManifest.Image,
+			rc:     rc,
+			parser: parser,
+			addons: addons,
+		},
+		manifest: envManifest,
+
+		parser: parser,
+	}, nil
+}
+
+// Template returns the CloudFormation template for the worker service.
+func (s *WorkerService) Template() (string, error) {
+	outputs, err := s.addonsOutputs()
+	if err != nil {
+		return "", err
+	}
+	if s.manifest.Count.Autoscaling.Requests != nil {
+		return "", errRequestsAutoscalingWithoutLB
+	}
+	autoscaling, err := autoscalingOpts(s.manifest.Count.Autoscaling)
+	if err != nil {
+		return "", err
+	}
+	queue, err := queueOpts(s.manifest.Queue)
+	if err != nil {
+		return "", err
+	}
+	if err := validateDependsOn(s.name, false, s.manifest.Sidecars); err != nil {
+		return "", err
+	}
+	sidecars, err := convertSidecar(s.manifest.Sidecars, s.manifest.Storage.Volumes, s.rc.EnvFiles)
+	if err != nil {
+		return "", err
+	}
+	secrets, err := convertSecrets(s.manifest.Secrets)
+	if err != nil {
+		return "", err
+	}
+	storage, err := convertStorageOpts(s.manifest.Storage, s.manifest.Sidecars)
+	if err != nil {
+		return "", err
+	}
+	logConfig, err := logConfigOpts(s.manifest.Logging)
+	if err != nil {
+		return "", err
+	}
+	capacityProviders, err := capacityProviderStrategy(s.manifest.Platform)
+	if err != nil {
+		return "", err
+	}
+	if s.manifest.Deployment.IsBlueGreen() {
+		return "", errBlueGreenWithoutLoadBalancer
+	}
+	deploymentConfig, err := deploymentConfigurationOpts(s.manifest.Deployment)
+	if err != nil {
+		return "", err
+	}
+	content, err := s.parser.ParseWorkerService(template.ServiceOpts{
+		Variables:               s.manifest.Variables,
+		EnvFileARN:              envFileARN(s.rc.EnvFiles, s.name),
+		EnvFilesPolicy:          envFilesPolicyOpts(s.rc.EnvFiles),
+		Secrets:                 secrets,
+		SecretsPolicy:           secretsPolicyOpts(s.manifest.Secrets, s.manifest.Sidecars),
+		NestedStack:             outputs,
+		Autoscaling:             autoscaling,
+		Sidecars:                sidecars,
+		Storage:                 storage,
+		LogConfig:               logConfig,
+		CapacityProviders:       capacityProviders,
+		Network:                 networkOpts(s.manifest.Network),
+		DeploymentConfiguration: deploymentConfig,
+		EntryPoint:              stringSliceOpts(s.manifest.EntryPoint),
+		Command:                 stringSliceOpts(s.manifest.Command),
+		Queue:                   queue,
+	})
+	if err != nil {
+		return "", fmt.Errorf("parse worker service template: %w", err)
+	}
+	return content.String(), nil
+}
+
+// SerializedParameters returns the CloudFormation stack's parameters serialized
+// to a YAML document annotated with comments for readability to users.
+func (s *WorkerService) SerializedParameters() (string, error) {
+	return s.svc.templateConfiguration(s)
+}
+
+// Parameters returns the list of CloudFormation parameters used by the template.
+func (s *WorkerService) Parameters() []*cloudformation.Parameter {
+	return s.svc.Parameters()
+}
@@ -0,0 +1,249 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package stack
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/addons"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/deploy/cloudformation/stack/mocks"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/manifest"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/template"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func testWorkerSvcManifest() *manifest.WorkerService {
+	mft := manifest.NewWorkerService(manifest.WorkerServiceProps{
+		ServiceProps: manifest.ServiceProps{
+			Name:       "processor",
+			Dockerfile: "./processor/Dockerfile",
+		},
+	})
+	timeout := 2 * time.Minute
+	mft.Queue.Timeout = &timeout
+	return mft
+}
+
+func TestWorkerService_Template(t *testing.T) {
+	testCases := map[string]struct {
+		mockManifest     func(mft *manifest.WorkerService)
+		mockDependencies func(t *testing.T, ctrl *gomock.Controller, svc *WorkerService)
+
+		wantedTemplate string
+		wantedErr      error
+	}{
+		"unexpected addons parsing error": {
+			mockDependencies: func(t *testing.T, ctrl *gomock.Controller, svc *WorkerService) {
+				svc.addons = mockTemplater{err: errors.New("some error")}
+			},
+			wantedErr: fmt.Errorf("generate addons template for service %s: %w", "processor", errors.New("some error")),
+		},
+		"requests autoscaling is not supported": {
+			mockManifest: func(mft *manifest.WorkerService) {
+				mft.Count = manifest.Count{
+					Autoscaling: manifest.Autoscaling{
+						Range:    manifest.Range("1-10"),
+						Requests: aws.Int(100),
+					},
+				}
+			},
+			mockDependencies: func(t *testing.T, ctrl *gomock.Controller, svc *WorkerService) {
+				svc.addons = mockTemplater{err: &addons.ErrDirNotExist{}}
+			},
+			wantedErr: errRequestsAutoscalingWithoutLB,
+		},
+		"invalid queue configuration": {
+			mockManifest: func(mft *manifest.WorkerService) {
+				mft.Queue.DeadLetter.Tries = aws.Int(0)
+			},
+			mockDependencies: func(t *testing.T, ctrl *gomock.Controller, svc *WorkerService) {
+				svc.addons = mockTemplater{err: &addons.ErrDirNotExist{}}
+			},
+			wantedErr: errors.New("dead-letter queue tries 0 must be between 1 and 1000"),
+		},
+		"failed parsing svc template": {
+			mockDependencies: func(t *testing.T, ctrl *gomock.Controller, svc *WorkerService) {
+				m := mocks.NewMockworkerSvcReadParser(ctrl)
+				m.EXPECT().ParseWorkerService(gomock.Any()).Return(nil, errors.New("some error"))
+				svc.parser = m
+				svc.addons = mockTemplater{err: &addons.ErrDirNotExist{}}
+			},
+			wantedErr: fmt.Errorf("parse worker service template: %w", errors.New("some error")),
+		},
+		"render template": {
+			mockManifest: func(mft *manifest.WorkerService) {
+				mft.Count = manifest.Count{
+					Autoscaling: manifest.Autoscaling{
+						Range:      manifest.Range("1-10"),
+						QueueDepth: aws.Int(100),
+					},
+				}
+			},
+			mockDependencies: func(t *testing.T, ctrl *gomock.Controller, svc *WorkerService) {
+				m := mocks.NewMockworkerSvcReadParser(ctrl)
+				m.EXPECT().ParseWorkerService(template.ServiceOpts{
+					Autoscaling: &template.AutoscalingOpts{
+						MinCapacity: aws.Int(1),
+						MaxCapacity: aws.Int(10),
+						QueueDepth:  aws.Float64(100),
+					},
+					Queue: &template.QueueOpts{
+						Timeout:         aws.Int(120),
+						DeadLetterTries: 3,
+					},
+				}).Return(&template.Content{Buffer: bytes.NewBufferString("template")}, nil)
+				svc.parser = m
+				svc.addons = mockTemplater{err: &addons.ErrDirNotExist{}}
+			},
+			wantedTemplate: "template",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mft := testWorkerSvcManifest()
+			if tc.mockManifest != nil {
+				tc.mockManifest(mft)
+			}
+			conf := &WorkerService{
+				svc: &svc{
+					name: mft.Name,
+					env:  testEnvName,
+					app:  testAppName,
+					tc:   mft.TaskConfig,
+				},
+				manifest: mft,
+			}
+			tc.mockDependencies(t, ctrl, conf)
+
+			// WHEN
+			template, err := conf.Template()
+
+			// THEN
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.wantedTemplate, template)
+			}
+		})
+	}
+}
+
+func TestWorkerService_TemplateWithQueueDepthAutoscaling(t *testing.T) {
+	// GIVEN
+	defer useRepoTemplates()()
+	mft := testWorkerSvcManifest()
+	mft.Count = manifest.Count{
+		Autoscaling: manifest.Autoscaling{
+			Range:      "1-10",
+			QueueDepth: aws.Int(100),
+		},
+	}
+	conf, err := NewWorkerService(mft, testEnvName, testAppName, RuntimeConfig{})
+	require.NoError(t, err)
+	conf.addons = mockTemplater{err: &addons.ErrDirNotExist{}}
+
+	// WHEN
+	tpl, err := conf.Template()
+
+	// THEN
+	require.NoError(t, err)
+	var parsed struct {
+		Resources struct {
+			Policy struct {
+				Properties struct {
+					TargetTrackingScalingPolicyConfiguration struct {
+						CustomizedMetricSpecification struct {
+							Metrics []struct {
+								ID         string `yaml:"Id"`
+								Expression string `yaml:"Expression"`
+								MetricStat struct {
+									Metric struct {
+										Namespace  string `yaml:"Namespace"`
+										MetricName string `yaml:"MetricName"`
+									} `yaml:"Metric"`
+								} `yaml:"MetricStat"`
+								ReturnData bool `yaml:"ReturnData"`
+							} `yaml:"Metrics"`
+						} `yaml:"CustomizedMetricSpecification"`
+						TargetValue float64 `yaml:"TargetValue"`
+					} `yaml:"TargetTrackingScalingPolicyConfiguration"`
+				} `yaml:"Properties"`
+			} `yaml:"AutoScalingPolicySQSBacklogPerTask"`
+		} `yaml:"Resources"`
+	}
+	require.NoError(t, yaml.Unmarshal([]byte(tpl), &parsed))
+	config := parsed.Resources.Policy.Properties.TargetTrackingScalingPolicyConfiguration
+	require.Equal(t, float64(100), config.TargetValue)
+	metrics := config.CustomizedMetricSpecification.Metrics
+	require.Len(t, metrics, 3)
+	require.Equal(t, "ApproximateNumberOfMessagesVisible", metrics[0].MetricStat.Metric.MetricName)
+	require.Equal(t, "RunningTaskCount", metrics[1].MetricStat.Metric.MetricName)
+	require.Equal(t, "messages / tasks", metrics[2].Expression, "the policy should track the messages per task")
+	require.True(t, metrics[2].ReturnData)
+}
+
+func TestWorkerService_Parameters(t *testing.T) {
+	testCases := map[string]struct {
+		inImage manifest.ServiceImage
+
+		wantedImage string
+	}{
+		"uses the image pushed to the ECR repository": {
+			inImage: manifest.ServiceImage{
+				Build: manifest.BuildArgsOrString{BuildString: aws.String("./processor/Dockerfile")},
+			},
+			wantedImage: "12345.dkr.ecr.us-west-2.amazonaws.com/phonetool/frontend:manual-bf3678c",
+		},
+		"uses the pre-built image": {
+			inImage: manifest.ServiceImage{
+				Location: "public.ecr.aws/nginx/nginx:latest",
+			},
+			wantedImage: "public.ecr.aws/nginx/nginx:latest",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			mft := testWorkerSvcManifest()
+			mft.Image = tc.inImage
+			conf := &WorkerService{
+				svc: &svc{
+					name:  mft.Name,
+					env:   testEnvName,
+					app:   testAppName,
+					tc:    mft.TaskConfig,
+					image: mft.Image,
+					rc: RuntimeConfig{
+						ImageRepoURL: testImageRepoURL,
+						ImageTag:     testImageTag,
+					},
+				},
+				manifest: mft,
+			}
+
+			// WHEN
+			params := conf.Parameters()
+
+			// THEN
+			require.Contains(t, params, &cloudformation.Parameter{
+				ParameterKey:   aws.String(ServiceContainerImageParamKey),
+				ParameterValue: aws.String(tc.wantedImage),
+			})
+		})
+	}
+}
@@ -5,7 +5,11 @@
 // This file defines environment deployment resources.
 package deploy
 
-import "github.com/aws/amazon-ecs-cli-v2/internal/pkg/config"
+import (
+	"time"
+
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/config"
+)
 
 // CreateEnvironmentInput holds the fields required to deploy an environment.
 type CreateEnvironmentInput struct {
@@ -16,6 +20,17 @@ type CreateEnvironmentInput struct {
 	ToolsAccountPrincipalARN string            // The Principal ARN of the tools account.
 	AppDNSName               string            // The DNS name of this application, if it exists
 	AdditionalTags           map[string]string // AdditionalTags are labels applied to resources under the application.
+	ImportVPC                *ImportVPCConfig  // Existing VPC to place the environment in, if nil a new VPC is created.
+	LBIdleTimeout            time.Duration     // Idle timeout of the connections to the public load balancer, 60 seconds if 0.
+	NATGateways              bool              // Whether to create NAT gateways for the tasks placed in the private subnets of a new VPC.
+}
+
+// ImportVPCConfig holds the identifiers of an existing VPC and of its subnets to place an environment in.
+type ImportVPCConfig struct {
+	ID               string
+	CIDR             string // Primary CIDR block of the VPC.
+	PublicSubnetIDs  []string
+	PrivateSubnetIDs []string
 }
 
 // CreateEnvironmentResponse holds the created environment on successful deployment.
@@ -113,11 +113,11 @@ func (d *BackendServiceDescriber) Describe() (HumanJSONStringer, error) {
 			CPU:         svcParams[stack.ServiceTaskCPUParamKey],
 			Memory:      svcParams[stack.ServiceTaskMemoryParamKey],
 		})
-		backendSvcEnvVars, err := d.svcDescriber.EnvVars()
+		backendSvcEnvVars, sources, err := d.svcDescriber.EnvVars()
 		if err != nil {
 			return nil, fmt.Errorf("retrieve environment variables: %w", err)
 		}
-		envVars = append(envVars, flattenEnvVars(env.Name, backendSvcEnvVars)...)
+		envVars = append(envVars, flattenEnvVars(env.Name, backendSvcEnvVars, sources)...)
 	}
 	sort.SliceStable(envVars, func(i, j int) bool { return envVars[i].Environment < envVars[j].Environment })
 	sort.SliceStable(envVars, func(i, j int) bool { return envVars[i].Name < envVars[j].Name })
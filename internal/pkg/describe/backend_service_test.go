@@ -81,7 +81,7 @@ func TestBackendServiceDescriber_Describe(t *testing.T) {
 						stack.ServiceTaskCPUParamKey:            "256",
 						stack.ServiceTaskMemoryParamKey:         "512",
 					}, nil),
-					m.svcDescriber.EXPECT().EnvVars().Return(nil, mockErr),
+					m.svcDescriber.EXPECT().EnvVars().Return(nil, nil, mockErr),
 				)
 			},
 			wantedError: fmt.Errorf("retrieve environment variables: some error"),
@@ -125,6 +125,9 @@ func TestBackendServiceDescriber_Describe(t *testing.T) {
 					m.svcDescriber.EXPECT().EnvVars().Return(
 						map[string]string{
 							"COPILOT_ENVIRONMENT_NAME": testEnv,
+						},
+						map[string]string{
+							"COPILOT_ENVIRONMENT_NAME": "manifest",
 						}, nil),
 
 					m.svcDescriber.EXPECT().Params().Return(map[string]string{
@@ -136,6 +139,9 @@ func TestBackendServiceDescriber_Describe(t *testing.T) {
 					m.svcDescriber.EXPECT().EnvVars().Return(
 						map[string]string{
 							"COPILOT_ENVIRONMENT_NAME": prodEnv,
+						},
+						map[string]string{
+							"COPILOT_ENVIRONMENT_NAME": "manifest",
 						}, nil),
 
 					m.svcDescriber.EXPECT().ServiceStackResources().Return([]*cloudformation.StackResource{
@@ -183,11 +189,13 @@ func TestBackendServiceDescriber_Describe(t *testing.T) {
 						Environment: "prod",
 						Name:        "COPILOT_ENVIRONMENT_NAME",
 						Value:       "prod",
+						Source:      "manifest",
 					},
 					{
 						Environment: "test",
 						Name:        "COPILOT_ENVIRONMENT_NAME",
 						Value:       "test",
+						Source:      "manifest",
 					},
 				},
 				Resources: map[string][]*CfnResource{
@@ -272,9 +280,9 @@ Service Discovery
 
 Variables
 
-  Name                      Environment         Value
-  COPILOT_ENVIRONMENT_NAME  prod                prod
-  -                         test                test
+  Name                      Environment         Value               Source
+  COPILOT_ENVIRONMENT_NAME  prod                prod                manifest
+  -                         test                test                manifest
 
 Resources
 
@@ -284,7 +292,7 @@ Resources
   prod
     AWS::EC2::SecurityGroupIngress  ContainerSecurityGroupIngressFromPublicALB
 `,
-			wantedJSONString: "{\"service\":\"my-svc\",\"type\":\"Backend Service\",\"application\":\"my-app\",\"configurations\":[{\"environment\":\"test\",\"port\":\"80\",\"tasks\":\"1\",\"cpu\":\"256\",\"memory\":\"512\"},{\"environment\":\"prod\",\"port\":\"5000\",\"tasks\":\"3\",\"cpu\":\"512\",\"memory\":\"1024\"}],\"serviceDiscovery\":[{\"environment\":[\"test\",\"prod\"],\"namespace\":\"http://my-svc.my-app.local:5000\"}],\"variables\":[{\"environment\":\"prod\",\"name\":\"COPILOT_ENVIRONMENT_NAME\",\"value\":\"prod\"},{\"environment\":\"test\",\"name\":\"COPILOT_ENVIRONMENT_NAME\",\"value\":\"test\"}],\"resources\":{\"prod\":[{\"type\":\"AWS::EC2::SecurityGroupIngress\",\"physicalID\":\"ContainerSecurityGroupIngressFromPublicALB\"}],\"test\":[{\"type\":\"AWS::EC2::SecurityGroup\",\"physicalID\":\"sg-0758ed6b233743530\"}]}}\n",
+			wantedJSONString: "{\"service\":\"my-svc\",\"type\":\"Backend Service\",\"application\":\"my-app\",\"configurations\":[{\"environment\":\"test\",\"port\":\"80\",\"tasks\":\"1\",\"cpu\":\"256\",\"memory\":\"512\"},{\"environment\":\"prod\",\"port\":\"5000\",\"tasks\":\"3\",\"cpu\":\"512\",\"memory\":\"1024\"}],\"serviceDiscovery\":[{\"environment\":[\"test\",\"prod\"],\"namespace\":\"http://my-svc.my-app.local:5000\"}],\"variables\":[{\"environment\":\"prod\",\"name\":\"COPILOT_ENVIRONMENT_NAME\",\"value\":\"prod\",\"source\":\"manifest\"},{\"environment\":\"test\",\"name\":\"COPILOT_ENVIRONMENT_NAME\",\"value\":\"test\",\"source\":\"manifest\"}],\"resources\":{\"prod\":[{\"type\":\"AWS::EC2::SecurityGroupIngress\",\"physicalID\":\"ContainerSecurityGroupIngressFromPublicALB\"}],\"test\":[{\"type\":\"AWS::EC2::SecurityGroup\",\"physicalID\":\"sg-0758ed6b233743530\"}]}}\n",
 		},
 	}
 
@@ -311,11 +319,13 @@ Resources
 					Environment: "prod",
 					Name:        "COPILOT_ENVIRONMENT_NAME",
 					Value:       "prod",
+					Source:      "manifest",
 				},
 				{
 					Environment: "test",
 					Name:        "COPILOT_ENVIRONMENT_NAME",
 					Value:       "test",
+					Source:      "manifest",
 				},
 			}
 			sds := []*ServiceDiscovery{
@@ -73,7 +73,8 @@ type storeSvc interface {
 type svcDescriber interface {
 	Params() (map[string]string, error)
 	EnvOutputs() (map[string]string, error)
-	EnvVars() (map[string]string, error)
+	ServiceOutputs() (map[string]string, error)
+	EnvVars() (values map[string]string, sources map[string]string, err error)
 	GetServiceArn() (*ecs.ServiceArn, error)
 	ServiceStackResources() ([]*cloudformation.StackResource, error)
 }
@@ -146,17 +147,19 @@ func (d *WebServiceDescriber) Describe() (HumanJSONStringer, error) {
 		if err != nil {
 			return nil, err
 		}
-		webServiceURI, err := d.URI(env.Name)
+		uris, err := d.uris(env.Name)
 		if err != nil && !IsStackNotExistsErr(err) {
 			return nil, fmt.Errorf("retrieve service URI: %w", err)
 		}
 		if err != nil {
 			continue
 		}
-		routes = append(routes, &WebServiceRoute{
-			Environment: env.Name,
-			URL:         webServiceURI,
-		})
+		for _, uri := range uris {
+			routes = append(routes, &WebServiceRoute{
+				Environment: env.Name,
+				URL:         uri,
+			})
+		}
 		svcParams, err := d.svcDescriber.Params()
 		if err != nil {
 			return nil, fmt.Errorf("retrieve service deployment configuration: %w", err)
@@ -173,11 +176,11 @@ func (d *WebServiceDescriber) Describe() (HumanJSONStringer, error) {
 			Port:    svcParams[stack.LBWebServiceContainerPortParamKey],
 			App:     d.service.App,
 		}, env.Name)
-		webSvcEnvVars, err := d.svcDescriber.EnvVars()
+		webSvcEnvVars, sources, err := d.svcDescriber.EnvVars()
 		if err != nil {
 			return nil, fmt.Errorf("retrieve environment variables: %w", err)
 		}
-		envVars = append(envVars, flattenEnvVars(env.Name, webSvcEnvVars)...)
+		envVars = append(envVars, flattenEnvVars(env.Name, webSvcEnvVars, sources)...)
 	}
 	sort.SliceStable(envVars, func(i, j int) bool { return envVars[i].Environment < envVars[j].Environment })
 	sort.SliceStable(envVars, func(i, j int) bool { return envVars[i].Name < envVars[j].Name })
@@ -209,19 +212,29 @@ func (d *WebServiceDescriber) Describe() (HumanJSONStringer, error) {
 }
 
 // URI returns the WebServiceURI to identify this service uniquely given an environment name.
+// If the service has a network load balancer, the endpoints of its listeners follow the URI separated by commas.
 func (d *WebServiceDescriber) URI(envName string) (string, error) {
-	err := d.initServiceDescriber(envName)
+	uris, err := d.uris(envName)
 	if err != nil {
 		return "", err
 	}
+	return strings.Join(uris, ", "), nil
+}
+
+// uris returns the WebServiceURI of the service in the environment followed by the endpoints of its network load balancer, if any.
+func (d *WebServiceDescriber) uris(envName string) ([]string, error) {
+	err := d.initServiceDescriber(envName)
+	if err != nil {
+		return nil, err
+	}
 
 	envOutputs, err := d.svcDescriber.EnvOutputs()
 	if err != nil {
-		return "", fmt.Errorf("get output for environment %s: %w", envName, err)
+		return nil, fmt.Errorf("get output for environment %s: %w", envName, err)
 	}
 	svcParams, err := d.svcDescriber.Params()
 	if err != nil {
-		return "", fmt.Errorf("get parameters for service %s: %w", d.service.Name, err)
+		return nil, fmt.Errorf("get parameters for service %s: %w", d.service.Name, err)
 	}
 
 	uri := &WebServiceURI{
@@ -235,7 +248,15 @@ func (d *WebServiceDescriber) URI(envName string) (string, error) {
 			DNSName: dnsName,
 		}
 	}
-	return uri.String(), nil
+	svcOutputs, err := d.svcDescriber.ServiceOutputs()
+	if err != nil {
+		return nil, fmt.Errorf("get outputs for service %s: %w", d.service.Name, err)
+	}
+	uris := []string{uri.String()}
+	if endpoints := svcOutputs[stack.LBWebServiceOutputNLBEndpoints]; endpoints != "" {
+		uris = append(uris, strings.Split(endpoints, ",")...)
+	}
+	return uris, nil
 }
 
 // EnvVars contains serialized environment variables for a service.
@@ -243,27 +264,28 @@ type EnvVars struct {
 	Environment string `json:"environment"`
 	Name        string `json:"name"`
 	Value       string `json:"value"`
+	Source      string `json:"source"` // One of "manifest", "env file" or "addons output".
 }
 
 type envVars []*EnvVars
 
 func (e envVars) humanString(w io.Writer) {
-	fmt.Fprintf(w, "  %s\t%s\t%s\n", "Name", "Environment", "Value")
+	fmt.Fprintf(w, "  %s\t%s\t%s\t%s\n", "Name", "Environment", "Value", "Source")
 	var prevName string
 	var prevValue string
 	for _, variable := range e {
 		// Instead of re-writing the same variable value, we replace it with "-" to reduce text.
 		if variable.Name != prevName {
 			if variable.Value != prevValue {
-				fmt.Fprintf(w, "  %s\t%s\t%s\n", variable.Name, variable.Environment, variable.Value)
+				fmt.Fprintf(w, "  %s\t%s\t%s\t%s\n", variable.Name, variable.Environment, variable.Value, variable.Source)
 			} else {
-				fmt.Fprintf(w, "  %s\t%s\t-\n", variable.Name, variable.Environment)
+				fmt.Fprintf(w, "  %s\t%s\t-\t%s\n", variable.Name, variable.Environment, variable.Source)
 			}
 		} else {
 			if variable.Value != prevValue {
-				fmt.Fprintf(w, "  -\t%s\t%s\n", variable.Environment, variable.Value)
+				fmt.Fprintf(w, "  -\t%s\t%s\t%s\n", variable.Environment, variable.Value, variable.Source)
 			} else {
-				fmt.Fprintf(w, "  -\t%s\t-\n", variable.Environment)
+				fmt.Fprintf(w, "  -\t%s\t-\t%s\n", variable.Environment, variable.Source)
 			}
 		}
 		prevName = variable.Name
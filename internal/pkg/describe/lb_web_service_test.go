@@ -108,11 +108,44 @@ func TestWebServiceDescriber_URI(t *testing.T) {
 					m.svcDescriber.EXPECT().Params().Return(map[string]string{
 						stack.LBWebServiceRulePathParamKey: testSvcPath,
 					}, nil),
+					m.svcDescriber.EXPECT().ServiceOutputs().Return(map[string]string{}, nil),
 				)
 			},
 
 			wantedURI: "https://jobs.test.phonetool.com",
 		},
+		"fail to get outputs of service stack": {
+			setupMocks: func(m webSvcDescriberMocks) {
+				gomock.InOrder(
+					m.svcDescriber.EXPECT().EnvOutputs().Return(map[string]string{
+						stack.EnvOutputPublicLoadBalancerDNSName: testEnvLBDNSName,
+					}, nil),
+					m.svcDescriber.EXPECT().Params().Return(map[string]string{
+						stack.LBWebServiceRulePathParamKey: testSvcPath,
+					}, nil),
+					m.svcDescriber.EXPECT().ServiceOutputs().Return(nil, mockErr),
+				)
+			},
+			wantedError: fmt.Errorf("get outputs for service jobs: some error"),
+		},
+		"https web service with a network load balancer": {
+			setupMocks: func(m webSvcDescriberMocks) {
+				gomock.InOrder(
+					m.svcDescriber.EXPECT().EnvOutputs().Return(map[string]string{
+						stack.EnvOutputPublicLoadBalancerDNSName: testEnvLBDNSName,
+						stack.EnvOutputSubdomain:                 testEnvSubdomain,
+					}, nil),
+					m.svcDescriber.EXPECT().Params().Return(map[string]string{
+						stack.LBWebServiceRulePathParamKey: testSvcPath,
+					}, nil),
+					m.svcDescriber.EXPECT().ServiceOutputs().Return(map[string]string{
+						stack.LBWebServiceOutputNLBEndpoints: "jobs-nlb.elb.us-west-1.amazonaws.com:1883/tcp",
+					}, nil),
+				)
+			},
+
+			wantedURI: "https://jobs.test.phonetool.com, jobs-nlb.elb.us-west-1.amazonaws.com:1883/tcp",
+		},
 		"http web service": {
 			setupMocks: func(m webSvcDescriberMocks) {
 				gomock.InOrder(
@@ -122,6 +155,7 @@ func TestWebServiceDescriber_URI(t *testing.T) {
 					m.svcDescriber.EXPECT().Params().Return(map[string]string{
 						stack.LBWebServiceRulePathParamKey: testSvcPath,
 					}, nil),
+					m.svcDescriber.EXPECT().ServiceOutputs().Return(map[string]string{}, nil),
 				)
 			},
 
@@ -225,13 +259,14 @@ func TestWebServiceDescriber_Describe(t *testing.T) {
 					m.svcDescriber.EXPECT().Params().Return(map[string]string{
 						stack.LBWebServiceRulePathParamKey: testSvcPath,
 					}, nil),
+					m.svcDescriber.EXPECT().ServiceOutputs().Return(map[string]string{}, nil),
 					m.svcDescriber.EXPECT().Params().Return(map[string]string{
 						stack.LBWebServiceContainerPortParamKey: "80",
 						stack.ServiceTaskCountParamKey:          "1",
 						stack.ServiceTaskCPUParamKey:            "256",
 						stack.ServiceTaskMemoryParamKey:         "512",
 					}, nil),
-					m.svcDescriber.EXPECT().EnvVars().Return(nil, mockErr),
+					m.svcDescriber.EXPECT().EnvVars().Return(nil, nil, mockErr),
 				)
 			},
 			wantedError: fmt.Errorf("retrieve environment variables: some error"),
@@ -249,6 +284,7 @@ func TestWebServiceDescriber_Describe(t *testing.T) {
 					m.svcDescriber.EXPECT().Params().Return(map[string]string{
 						stack.LBWebServiceRulePathParamKey: testSvcPath,
 					}, nil),
+					m.svcDescriber.EXPECT().ServiceOutputs().Return(map[string]string{}, nil),
 					m.svcDescriber.EXPECT().Params().Return(map[string]string{
 						stack.LBWebServiceContainerPortParamKey: "80",
 						stack.ServiceTaskCountParamKey:          "1",
@@ -258,6 +294,9 @@ func TestWebServiceDescriber_Describe(t *testing.T) {
 					m.svcDescriber.EXPECT().EnvVars().Return(
 						map[string]string{
 							"COPILOT_ENVIRONMENT_NAME": testEnv,
+						},
+						map[string]string{
+							"COPILOT_ENVIRONMENT_NAME": "manifest",
 						}, nil),
 					m.svcDescriber.EXPECT().ServiceStackResources().Return(nil, mockErr),
 				)
@@ -301,6 +340,7 @@ func TestWebServiceDescriber_Describe(t *testing.T) {
 					m.svcDescriber.EXPECT().Params().Return(map[string]string{
 						stack.LBWebServiceRulePathParamKey: testSvcPath,
 					}, nil),
+					m.svcDescriber.EXPECT().ServiceOutputs().Return(map[string]string{}, nil),
 					m.svcDescriber.EXPECT().Params().Return(map[string]string{
 						stack.LBWebServiceContainerPortParamKey: "5000",
 						stack.ServiceTaskCountParamKey:          "1",
@@ -310,6 +350,9 @@ func TestWebServiceDescriber_Describe(t *testing.T) {
 					m.svcDescriber.EXPECT().EnvVars().Return(
 						map[string]string{
 							"COPILOT_ENVIRONMENT_NAME": testEnv,
+						},
+						map[string]string{
+							"COPILOT_ENVIRONMENT_NAME": "manifest",
 						}, nil),
 
 					m.svcDescriber.EXPECT().EnvOutputs().Return(map[string]string{
@@ -318,6 +361,9 @@ func TestWebServiceDescriber_Describe(t *testing.T) {
 					m.svcDescriber.EXPECT().Params().Return(map[string]string{
 						stack.LBWebServiceRulePathParamKey: prodSvcPath,
 					}, nil),
+					m.svcDescriber.EXPECT().ServiceOutputs().Return(map[string]string{
+						stack.LBWebServiceOutputNLBEndpoints: "mqtt.phonetool.com:1883/tcp,mqtt.phonetool.com:8883/tls",
+					}, nil),
 					m.svcDescriber.EXPECT().Params().Return(map[string]string{
 						stack.LBWebServiceContainerPortParamKey: "5000",
 						stack.ServiceTaskCountParamKey:          "2",
@@ -327,6 +373,9 @@ func TestWebServiceDescriber_Describe(t *testing.T) {
 					m.svcDescriber.EXPECT().EnvVars().Return(
 						map[string]string{
 							"COPILOT_ENVIRONMENT_NAME": prodEnv,
+						},
+						map[string]string{
+							"COPILOT_ENVIRONMENT_NAME": "manifest",
 						}, nil),
 
 					m.svcDescriber.EXPECT().ServiceStackResources().Return([]*cloudformation.StackResource{
@@ -372,6 +421,14 @@ func TestWebServiceDescriber_Describe(t *testing.T) {
 						Environment: "prod",
 						URL:         "http://abc.us-west-1.elb.amazonaws.com/*",
 					},
+					{
+						Environment: "prod",
+						URL:         "mqtt.phonetool.com:1883/tcp",
+					},
+					{
+						Environment: "prod",
+						URL:         "mqtt.phonetool.com:8883/tls",
+					},
 				},
 				ServiceDiscovery: []*ServiceDiscovery{
 					{
@@ -384,11 +441,13 @@ func TestWebServiceDescriber_Describe(t *testing.T) {
 						Environment: "prod",
 						Name:        "COPILOT_ENVIRONMENT_NAME",
 						Value:       "prod",
+						Source:      "manifest",
 					},
 					{
 						Environment: "test",
 						Name:        "COPILOT_ENVIRONMENT_NAME",
 						Value:       "test",
+						Source:      "manifest",
 					},
 				},
 				Resources: map[string][]*CfnResource{
@@ -479,9 +538,9 @@ Service Discovery
 
 Variables
 
-  Name                      Environment         Value
-  COPILOT_ENVIRONMENT_NAME  prod                prod
-  -                         test                test
+  Name                      Environment         Value               Source
+  COPILOT_ENVIRONMENT_NAME  prod                prod                manifest
+  -                         test                test                manifest
 
 Resources
 
@@ -491,7 +550,7 @@ Resources
   prod
     AWS::EC2::SecurityGroupIngress  ContainerSecurityGroupIngressFromPublicALB
 `,
-			wantedJSONString: "{\"service\":\"my-svc\",\"type\":\"Load Balanced Web Service\",\"application\":\"my-app\",\"configurations\":[{\"environment\":\"test\",\"port\":\"80\",\"tasks\":\"1\",\"cpu\":\"256\",\"memory\":\"512\"},{\"environment\":\"prod\",\"port\":\"5000\",\"tasks\":\"3\",\"cpu\":\"512\",\"memory\":\"1024\"}],\"routes\":[{\"environment\":\"test\",\"url\":\"http://my-pr-Publi.us-west-2.elb.amazonaws.com/frontend\"},{\"environment\":\"prod\",\"url\":\"http://my-pr-Publi.us-west-2.elb.amazonaws.com/backend\"}],\"serviceDiscovery\":[{\"environment\":[\"test\",\"prod\"],\"namespace\":\"http://my-svc.my-app.local:5000\"}],\"variables\":[{\"environment\":\"prod\",\"name\":\"COPILOT_ENVIRONMENT_NAME\",\"value\":\"prod\"},{\"environment\":\"test\",\"name\":\"COPILOT_ENVIRONMENT_NAME\",\"value\":\"test\"}],\"resources\":{\"prod\":[{\"type\":\"AWS::EC2::SecurityGroupIngress\",\"physicalID\":\"ContainerSecurityGroupIngressFromPublicALB\"}],\"test\":[{\"type\":\"AWS::EC2::SecurityGroup\",\"physicalID\":\"sg-0758ed6b233743530\"}]}}\n",
+			wantedJSONString: "{\"service\":\"my-svc\",\"type\":\"Load Balanced Web Service\",\"application\":\"my-app\",\"configurations\":[{\"environment\":\"test\",\"port\":\"80\",\"tasks\":\"1\",\"cpu\":\"256\",\"memory\":\"512\"},{\"environment\":\"prod\",\"port\":\"5000\",\"tasks\":\"3\",\"cpu\":\"512\",\"memory\":\"1024\"}],\"routes\":[{\"environment\":\"test\",\"url\":\"http://my-pr-Publi.us-west-2.elb.amazonaws.com/frontend\"},{\"environment\":\"prod\",\"url\":\"http://my-pr-Publi.us-west-2.elb.amazonaws.com/backend\"}],\"serviceDiscovery\":[{\"environment\":[\"test\",\"prod\"],\"namespace\":\"http://my-svc.my-app.local:5000\"}],\"variables\":[{\"environment\":\"prod\",\"name\":\"COPILOT_ENVIRONMENT_NAME\",\"value\":\"prod\",\"source\":\"manifest\"},{\"environment\":\"test\",\"name\":\"COPILOT_ENVIRONMENT_NAME\",\"value\":\"test\",\"source\":\"manifest\"}],\"resources\":{\"prod\":[{\"type\":\"AWS::EC2::SecurityGroupIngress\",\"physicalID\":\"ContainerSecurityGroupIngressFromPublicALB\"}],\"test\":[{\"type\":\"AWS::EC2::SecurityGroup\",\"physicalID\":\"sg-0758ed6b233743530\"}]}}\n",
 		},
 	}
 
@@ -518,11 +577,13 @@ Resources
 					Environment: "prod",
 					Name:        "COPILOT_ENVIRONMENT_NAME",
 					Value:       "prod",
+					Source:      "manifest",
 				},
 				{
 					Environment: "test",
 					Name:        "COPILOT_ENVIRONMENT_NAME",
 					Value:       "test",
+					Source:      "manifest",
 				},
 			}
 			routes := []*WebServiceRoute{
@@ -118,15 +118,31 @@ func (mr *MocksvcDescriberMockRecorder) EnvOutputs() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnvOutputs", reflect.TypeOf((*MocksvcDescriber)(nil).EnvOutputs))
 }
 
-// EnvVars mocks base method
-func (m *MocksvcDescriber) EnvVars() (map[string]string, error) {
+// ServiceOutputs mocks base method
+func (m *MocksvcDescriber) ServiceOutputs() (map[string]string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "EnvVars")
+	ret := m.ctrl.Call(m, "ServiceOutputs")
 	ret0, _ := ret[0].(map[string]string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
+// ServiceOutputs indicates an expected call of ServiceOutputs
+func (mr *MocksvcDescriberMockRecorder) ServiceOutputs() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ServiceOutputs", reflect.TypeOf((*MocksvcDescriber)(nil).ServiceOutputs))
+}
+
+// EnvVars mocks base method
+func (m *MocksvcDescriber) EnvVars() (map[string]string, map[string]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnvVars")
+	ret0, _ := ret[0].(map[string]string)
+	ret1, _ := ret[1].(map[string]string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
 // EnvVars indicates an expected call of EnvVars
 func (mr *MocksvcDescriberMockRecorder) EnvVars() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
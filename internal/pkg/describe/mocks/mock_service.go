@@ -101,3 +101,41 @@ func (mr *MockecsClientMockRecorder) TaskDefinition(taskDefName interface{}) *go
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TaskDefinition", reflect.TypeOf((*MockecsClient)(nil).TaskDefinition), taskDefName)
 }
+
+// Mocks3ObjectGetter is a mock of s3ObjectGetter interface
+type Mocks3ObjectGetter struct {
+	ctrl     *gomock.Controller
+	recorder *Mocks3ObjectGetterMockRecorder
+}
+
+// Mocks3ObjectGetterMockRecorder is the mock recorder for Mocks3ObjectGetter
+type Mocks3ObjectGetterMockRecorder struct {
+	mock *Mocks3ObjectGetter
+}
+
+// NewMocks3ObjectGetter creates a new mock instance
+func NewMocks3ObjectGetter(ctrl *gomock.Controller) *Mocks3ObjectGetter {
+	mock := &Mocks3ObjectGetter{ctrl: ctrl}
+	mock.recorder = &Mocks3ObjectGetterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *Mocks3ObjectGetter) EXPECT() *Mocks3ObjectGetterMockRecorder {
+	return m.recorder
+}
+
+// GetObject mocks base method
+func (m *Mocks3ObjectGetter) GetObject(bucket, key string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetObject", bucket, key)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetObject indicates an expected call of GetObject
+func (mr *Mocks3ObjectGetterMockRecorder) GetObject(bucket, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetObject", reflect.TypeOf((*Mocks3ObjectGetter)(nil).GetObject), bucket, key)
+}
@@ -5,12 +5,18 @@ package describe
 
 import (
 	"fmt"
+	"strings"
 
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/addons"
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/aws/ecs"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/aws/s3"
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/aws/session"
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/config"
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/deploy/cloudformation/stack"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/envfile"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/template"
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
 	"github.com/aws/aws-sdk-go/service/cloudformation"
 )
 
@@ -20,7 +26,15 @@ const (
 	waitCondition        = "AWS::CloudFormation::WaitCondition"
 	waitConditionHandle  = "AWS::CloudFormation::WaitConditionHandle"
 
-	serviceLogicalID = "Service"
+	serviceLogicalID        = "Service"
+	taskDefinitionLogicalID = "TaskDefinition"
+)
+
+// Sources of the environment variables of a service.
+const (
+	envVarSourceManifest = "manifest"
+	envVarSourceEnvFile  = "env file"
+	envVarSourceAddons   = "addons output"
 )
 
 type stackAndResourcesDescriber interface {
@@ -32,6 +46,10 @@ type ecsClient interface {
 	TaskDefinition(taskDefName string) (*ecs.TaskDefinition, error)
 }
 
+type s3ObjectGetter interface {
+	GetObject(bucket, key string) ([]byte, error)
+}
+
 // ServiceDescriber retrieves information about a service.
 type ServiceDescriber struct {
 	app     string
@@ -40,6 +58,7 @@ type ServiceDescriber struct {
 
 	ecsClient      ecsClient
 	stackDescriber stackAndResourcesDescriber
+	s3Client       s3ObjectGetter
 }
 
 // NewServiceDescriber instantiates a new service.
@@ -68,19 +87,92 @@ func NewServiceDescriber(app, env, svc string) (*ServiceDescriber, error) {
 
 		ecsClient:      ecs.New(sess),
 		stackDescriber: d,
+		s3Client:       s3.New(sess),
 	}, nil
 }
 
-// EnvVars returns the environment variables of the task definition.
-func (d *ServiceDescriber) EnvVars() (map[string]string, error) {
+// EnvVars returns the environment variables of the main container of the service, and where each of their values comes from.
+// The variables of the task definition are either set in the manifest or set to the outputs of the addons stack.
+// The variables of the environment file are listed unless the task definition overrides them.
+func (d *ServiceDescriber) EnvVars() (values map[string]string, sources map[string]string, err error) {
 	taskDefName := fmt.Sprintf("%s-%s-%s", d.app, d.env, d.service)
 	taskDefinition, err := d.ecsClient.TaskDefinition(taskDefName)
+	if err != nil {
+		return nil, nil, err
+	}
+	addonsVars, err := d.addonsEnvVarNames()
+	if err != nil {
+		return nil, nil, err
+	}
+	fileVars, err := d.envFileVars()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	values, sources = make(map[string]string), make(map[string]string)
+	for name, value := range fileVars {
+		values[name] = value
+		sources[name] = envVarSourceEnvFile
+	}
+	for name, value := range taskDefinition.EnvironmentVariables() {
+		values[name] = value
+		sources[name] = envVarSourceManifest
+		if addonsVars[name] {
+			sources[name] = envVarSourceAddons
+		}
+	}
+	return values, sources, nil
+}
+
+// addonsEnvVarNames returns the names of the environment variables set to the outputs of the addons stack of the service.
+func (d *ServiceDescriber) addonsEnvVarNames() (map[string]bool, error) {
+	svcResources, err := d.stackDescriber.StackResources(stack.NameForService(d.app, d.env, d.service))
 	if err != nil {
 		return nil, err
 	}
-	envVars := taskDefinition.EnvironmentVariables()
+	names := make(map[string]bool)
+	for _, svcResource := range svcResources {
+		if aws.StringValue(svcResource.LogicalResourceId) != addons.StackName {
+			continue
+		}
+		addonsStack, err := d.stackDescriber.Stack(aws.StringValue(svcResource.PhysicalResourceId))
+		if err != nil {
+			return nil, err
+		}
+		for _, out := range addonsStack.Outputs {
+			names[template.ToSnakeCase(aws.StringValue(out.OutputKey))] = true
+		}
+	}
+	return names, nil
+}
 
-	return envVars, nil
+// envFileVars returns the environment variables of the environment file of the main container, if it has one.
+func (d *ServiceDescriber) envFileVars() (map[string]string, error) {
+	outputs, err := d.ServiceOutputs()
+	if err != nil {
+		return nil, err
+	}
+	fileARN := outputs[stack.ServiceOutputEnvFileARN]
+	if fileARN == "" {
+		return nil, nil
+	}
+	parsed, err := arn.Parse(fileARN)
+	if err != nil {
+		return nil, fmt.Errorf("parse environment file ARN %s: %w", fileARN, err)
+	}
+	parts := strings.SplitN(parsed.Resource, "/", 2)
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("environment file ARN %s is not the ARN of an S3 object", fileARN)
+	}
+	content, err := d.s3Client.GetObject(parts[0], parts[1])
+	if err != nil {
+		return nil, err
+	}
+	vars, err := envfile.Parse(content)
+	if err != nil {
+		return nil, fmt.Errorf("parse environment file %s: %w", fileARN, err)
+	}
+	return vars, nil
 }
 
 // GetServiceArn returns the ECS service ARN of the service in an environment.
@@ -98,6 +190,20 @@ func (d *ServiceDescriber) GetServiceArn() (*ecs.ServiceArn, error) {
 	return nil, fmt.Errorf("cannot find service arn in service stack resource")
 }
 
+// TaskDefinitionArn returns the ARN of the task definition registered by the service stack in an environment.
+func (d *ServiceDescriber) TaskDefinitionArn() (string, error) {
+	svcResources, err := d.stackDescriber.StackResources(stack.NameForService(d.app, d.env, d.service))
+	if err != nil {
+		return "", err
+	}
+	for _, svcResource := range svcResources {
+		if aws.StringValue(svcResource.LogicalResourceId) == taskDefinitionLogicalID {
+			return aws.StringValue(svcResource.PhysicalResourceId), nil
+		}
+	}
+	return "", fmt.Errorf("cannot find task definition arn in service stack resource")
+}
+
 // ServiceStackResources returns the filtered service stack resources created by CloudFormation.
 func (d *ServiceDescriber) ServiceStackResources() ([]*cloudformation.StackResource, error) {
 	svcResources, err := d.stackDescriber.StackResources(stack.NameForService(d.app, d.env, d.service))
@@ -135,6 +241,19 @@ func (d *ServiceDescriber) EnvOutputs() (map[string]string, error) {
 	return outputs, nil
 }
 
+// ServiceOutputs returns the outputs of the service stack.
+func (d *ServiceDescriber) ServiceOutputs() (map[string]string, error) {
+	svcStack, err := d.stackDescriber.Stack(stack.NameForService(d.app, d.env, d.service))
+	if err != nil {
+		return nil, err
+	}
+	outputs := make(map[string]string)
+	for _, out := range svcStack.Outputs {
+		outputs[aws.StringValue(out.OutputKey)] = aws.StringValue(out.OutputValue)
+	}
+	return outputs, nil
+}
+
 // Params returns the parameters of the service stack.
 func (d *ServiceDescriber) Params() (map[string]string, error) {
 	svcStack, err := d.stackDescriber.Stack(stack.NameForService(d.app, d.env, d.service))
@@ -148,13 +267,14 @@ func (d *ServiceDescriber) Params() (map[string]string, error) {
 	return params, nil
 }
 
-func flattenEnvVars(envName string, m map[string]string) []*EnvVars {
+func flattenEnvVars(envName string, values, sources map[string]string) []*EnvVars {
 	var envVarList []*EnvVars
-	for k, v := range m {
+	for k, v := range values {
 		envVarList = append(envVarList, &EnvVars{
 			Environment: envName,
 			Name:        k,
 			Value:       v,
+			Source:      sources[k],
 		})
 	}
 	return envVarList
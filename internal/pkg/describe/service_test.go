@@ -21,6 +21,7 @@ import (
 type svcDescriberMocks struct {
 	mockStackDescriber *mocks.MockstackAndResourcesDescriber
 	mockecsClient      *mocks.MockecsClient
+	mockS3Client       *mocks.Mocks3ObjectGetter
 }
 
 func TestServiceDescriber_EnvVars(t *testing.T) {
@@ -30,11 +31,33 @@ func TestServiceDescriber_EnvVars(t *testing.T) {
 		testEnv            = "test"
 		testRegion         = "us-west-2"
 		testManagerRoleARN = "arn:aws:iam::1111:role/manager"
+		testAddonsStackARN = "arn:aws:cloudformation:us-west-2:1111:stack/phonetool-test-jobs-AddonsStack-1ABC/1234"
 	)
+	taskDefinition := &ecs.TaskDefinition{
+		ContainerDefinitions: []*ecsapi.ContainerDefinition{
+			{
+				Environment: []*ecsapi.KeyValuePair{
+					{
+						Name:  aws.String("COPILOT_SERVICE_NAME"),
+						Value: aws.String("my-svc"),
+					},
+					{
+						Name:  aws.String("LOG_LEVEL"),
+						Value: aws.String("info"),
+					},
+					{
+						Name:  aws.String("USERS_TABLE_NAME"),
+						Value: aws.String("users"),
+					},
+				},
+			},
+		},
+	}
 	testCases := map[string]struct {
 		setupMocks func(mocks svcDescriberMocks)
 
-		wantedEnvVars map[string]string
+		wantedValues  map[string]string
+		wantedSources map[string]string
 		wantedError   error
 	}{
 		"returns error if fails to get environment variables": {
@@ -46,31 +69,85 @@ func TestServiceDescriber_EnvVars(t *testing.T) {
 
 			wantedError: fmt.Errorf("some error"),
 		},
-		"get environment variables": {
+		"get environment variables set in the manifest": {
 			setupMocks: func(m svcDescriberMocks) {
 				gomock.InOrder(
-					m.mockecsClient.EXPECT().TaskDefinition("phonetool-test-jobs").Return(&ecs.TaskDefinition{
-						ContainerDefinitions: []*ecsapi.ContainerDefinition{
-							&ecsapi.ContainerDefinition{
-								Environment: []*ecsapi.KeyValuePair{
-									&ecsapi.KeyValuePair{
-										Name:  aws.String("COPILOT_SERVICE_NAME"),
-										Value: aws.String("my-svc"),
-									},
-									&ecsapi.KeyValuePair{
-										Name:  aws.String("COPILOT_ENVIRONMENT_NAME"),
-										Value: aws.String("prod"),
-									},
-								},
+					m.mockecsClient.EXPECT().TaskDefinition("phonetool-test-jobs").Return(taskDefinition, nil),
+					m.mockStackDescriber.EXPECT().StackResources(stack.NameForService(testApp, testEnv, testSvc)).Return(nil, nil),
+					m.mockStackDescriber.EXPECT().Stack(stack.NameForService(testApp, testEnv, testSvc)).Return(&cloudformation.Stack{}, nil),
+				)
+			},
+			wantedValues: map[string]string{
+				"COPILOT_SERVICE_NAME": "my-svc",
+				"LOG_LEVEL":            "info",
+				"USERS_TABLE_NAME":     "users",
+			},
+			wantedSources: map[string]string{
+				"COPILOT_SERVICE_NAME": "manifest",
+				"LOG_LEVEL":            "manifest",
+				"USERS_TABLE_NAME":     "manifest",
+			},
+		},
+		"get environment variables from the manifest, the environment file and the addons outputs": {
+			setupMocks: func(m svcDescriberMocks) {
+				gomock.InOrder(
+					m.mockecsClient.EXPECT().TaskDefinition("phonetool-test-jobs").Return(taskDefinition, nil),
+					m.mockStackDescriber.EXPECT().StackResources(stack.NameForService(testApp, testEnv, testSvc)).Return([]*cloudformation.StackResource{
+						{
+							LogicalResourceId:  aws.String("AddonsStack"),
+							PhysicalResourceId: aws.String(testAddonsStackARN),
+						},
+					}, nil),
+					m.mockStackDescriber.EXPECT().Stack(testAddonsStackARN).Return(&cloudformation.Stack{
+						Outputs: []*cloudformation.Output{
+							{
+								OutputKey:   aws.String("UsersTableName"),
+								OutputValue: aws.String("users"),
 							},
 						},
 					}, nil),
+					m.mockStackDescriber.EXPECT().Stack(stack.NameForService(testApp, testEnv, testSvc)).Return(&cloudformation.Stack{
+						Outputs: []*cloudformation.Output{
+							{
+								OutputKey:   aws.String("EnvFileARN"),
+								OutputValue: aws.String("arn:aws:s3:::stackset-bucket/manual/1600000000/jobs.jobs.env"),
+							},
+						},
+					}, nil),
+					m.mockS3Client.EXPECT().GetObject("stackset-bucket", "manual/1600000000/jobs.jobs.env").Return([]byte("LOG_LEVEL=debug\nGREETING=hello"), nil),
 				)
 			},
-			wantedEnvVars: map[string]string{
-				"COPILOT_SERVICE_NAME":     "my-svc",
-				"COPILOT_ENVIRONMENT_NAME": "prod",
+			wantedValues: map[string]string{
+				"COPILOT_SERVICE_NAME": "my-svc",
+				"GREETING":             "hello",
+				"LOG_LEVEL":            "info",
+				"USERS_TABLE_NAME":     "users",
+			},
+			wantedSources: map[string]string{
+				"COPILOT_SERVICE_NAME": "manifest",
+				"GREETING":             "env file",
+				"LOG_LEVEL":            "manifest",
+				"USERS_TABLE_NAME":     "addons output",
+			},
+		},
+		"returns error if fails to get the environment file": {
+			setupMocks: func(m svcDescriberMocks) {
+				gomock.InOrder(
+					m.mockecsClient.EXPECT().TaskDefinition("phonetool-test-jobs").Return(taskDefinition, nil),
+					m.mockStackDescriber.EXPECT().StackResources(stack.NameForService(testApp, testEnv, testSvc)).Return(nil, nil),
+					m.mockStackDescriber.EXPECT().Stack(stack.NameForService(testApp, testEnv, testSvc)).Return(&cloudformation.Stack{
+						Outputs: []*cloudformation.Output{
+							{
+								OutputKey:   aws.String("EnvFileARN"),
+								OutputValue: aws.String("arn:aws:s3:::stackset-bucket/manual/1600000000/jobs.jobs.env"),
+							},
+						},
+					}, nil),
+					m.mockS3Client.EXPECT().GetObject("stackset-bucket", "manual/1600000000/jobs.jobs.env").Return(nil, errors.New("some error")),
+				)
 			},
+
+			wantedError: fmt.Errorf("some error"),
 		},
 	}
 
@@ -82,9 +159,11 @@ func TestServiceDescriber_EnvVars(t *testing.T) {
 
 			mockecsClient := mocks.NewMockecsClient(ctrl)
 			mockStackDescriber := mocks.NewMockstackAndResourcesDescriber(ctrl)
+			mockS3Client := mocks.NewMocks3ObjectGetter(ctrl)
 			mocks := svcDescriberMocks{
 				mockecsClient:      mockecsClient,
 				mockStackDescriber: mockStackDescriber,
+				mockS3Client:       mockS3Client,
 			}
 
 			tc.setupMocks(mocks)
@@ -96,17 +175,19 @@ func TestServiceDescriber_EnvVars(t *testing.T) {
 
 				ecsClient:      mockecsClient,
 				stackDescriber: mockStackDescriber,
+				s3Client:       mockS3Client,
 			}
 
 			// WHEN
-			actual, err := d.EnvVars()
+			values, sources, err := d.EnvVars()
 
 			// THEN
 			if tc.wantedError != nil {
 				require.EqualError(t, err, tc.wantedError.Error())
 			} else {
 				require.Nil(t, err)
-				require.Equal(t, tc.wantedEnvVars, actual)
+				require.Equal(t, tc.wantedValues, values)
+				require.Equal(t, tc.wantedSources, sources)
 			}
 		})
 	}
@@ -286,3 +367,84 @@ func TestServiceDescriber_GetServiceArn(t *testing.T) {
 		})
 	}
 }
+
+func TestServiceDescriber_TaskDefinitionArn(t *testing.T) {
+	const (
+		testApp = "phonetool"
+		testEnv = "test"
+		testSvc = "jobs"
+	)
+	testCases := map[string]struct {
+		setupMocks func(mocks svcDescriberMocks)
+
+		wantedTaskDefArn string
+		wantedError      error
+	}{
+		"success": {
+			setupMocks: func(m svcDescriberMocks) {
+				m.mockStackDescriber.EXPECT().StackResources(stack.NameForService(testApp, testEnv, testSvc)).
+					Return([]*cloudformation.StackResource{
+						{
+							LogicalResourceId:  aws.String("Service"),
+							PhysicalResourceId: aws.String("mockServiceArn"),
+						},
+						{
+							LogicalResourceId:  aws.String("TaskDefinition"),
+							PhysicalResourceId: aws.String("mockTaskDefArn"),
+						},
+					}, nil)
+			},
+
+			wantedTaskDefArn: "mockTaskDefArn",
+		},
+		"error if cannot find task definition arn": {
+			setupMocks: func(m svcDescriberMocks) {
+				m.mockStackDescriber.EXPECT().StackResources(stack.NameForService(testApp, testEnv, testSvc)).
+					Return([]*cloudformation.StackResource{}, nil)
+			},
+
+			wantedError: fmt.Errorf("cannot find task definition arn in service stack resource"),
+		},
+		"error if fail to describe stack resources": {
+			setupMocks: func(m svcDescriberMocks) {
+				m.mockStackDescriber.EXPECT().StackResources(stack.NameForService(testApp, testEnv, testSvc)).
+					Return(nil, errors.New("some error"))
+			},
+
+			wantedError: fmt.Errorf("some error"),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockStackDescriber := mocks.NewMockstackAndResourcesDescriber(ctrl)
+			mocks := svcDescriberMocks{
+				mockStackDescriber: mockStackDescriber,
+			}
+
+			tc.setupMocks(mocks)
+
+			d := &ServiceDescriber{
+				app:            testApp,
+				service:        testSvc,
+				env:            testEnv,
+				stackDescriber: mockStackDescriber,
+			}
+
+			// WHEN
+			actual, err := d.TaskDefinitionArn()
+
+			// THEN
+			if tc.wantedError != nil {
+				require.EqualError(t, err, tc.wantedError.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.wantedTaskDefArn, actual)
+			}
+		})
+	}
+}
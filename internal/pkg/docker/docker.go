@@ -6,6 +6,8 @@ package docker
 
 import (
 	"fmt"
+	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/term/command"
@@ -28,11 +30,43 @@ func New() Service {
 	}
 }
 
-// Build will run a `docker build` command with the input uri, tag, and Dockerfile image path.
-func (s Service) Build(uri, imageTag, path string) error {
-	imageName := imageName(uri, imageTag)
+// BuildArguments holds the arguments we can pass in as flags from the manifest.
+type BuildArguments struct {
+	URI        string            // Required. Location of ECR Repo. Used to generate image name in conjunction with tag.
+	ImageTag   string            // Required. Tag to pass to `docker build` via -t flag. Usually Git commit short ID.
+	Dockerfile string            // Required. Dockerfile to pass to `docker build` via --file flag.
+	Context    string            // Optional. Build context directory to pass to `docker build`, defaults to the Dockerfile's directory.
+	Target     string            // Optional. The target build stage to pass to `docker build`.
+	CacheFrom  []string          // Optional. Images to consider as cache sources to pass to `docker build`.
+	Args       map[string]string // Optional. Build args to pass via `--build-arg` flags. Equivalent to ARG directives in dockerfile.
+}
+
+// Build will run a `docker build` command with the input uri, tag, and Dockerfile path,
+// along with the optional build context, target stage, cache sources and build args.
+func (s Service) Build(in *BuildArguments) error {
+	args := []string{"build", "-t", imageName(in.URI, in.ImageTag)}
+	if in.Target != "" {
+		args = append(args, "--target", in.Target)
+	}
+	for _, imageName := range in.CacheFrom {
+		args = append(args, "--cache-from", imageName)
+	}
+	// Sort the build args so that the command is deterministic.
+	keys := make([]string, 0, len(in.Args))
+	for k := range in.Args {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		args = append(args, "--build-arg", fmt.Sprintf("%s=%s", k, in.Args[k]))
+	}
+	dfDir := in.Context
+	if dfDir == "" {
+		dfDir = filepath.Dir(in.Dockerfile)
+	}
+	args = append(args, "-f", in.Dockerfile, dfDir)
 
-	err := s.runner.Run("docker", []string{"build", "-t", imageName, path})
+	err := s.runner.Run("docker", args)
 
 	if err != nil {
 		return fmt.Errorf("building image: %w", err)
@@ -17,12 +17,16 @@ func TestBuild(t *testing.T) {
 
 	mockURI := "mockURI"
 	mockImageTag := "mockImageTag"
-	mockPath := "mockPath"
+	mockPath := "mockPath/to/mockDockerfile"
 
 	var mockRunner *mocks.Mockrunner
 
 	tests := map[string]struct {
-		setupMocks func(controller *gomock.Controller)
+		inContext   string
+		inTarget    string
+		inCacheFrom []string
+		inArgs      map[string]string
+		setupMocks  func(controller *gomock.Controller)
 
 		want error
 	}{
@@ -30,7 +34,7 @@ func TestBuild(t *testing.T) {
 			setupMocks: func(controller *gomock.Controller) {
 				mockRunner = mocks.NewMockrunner(controller)
 
-				mockRunner.EXPECT().Run("docker", []string{"build", "-t", imageName(mockURI, mockImageTag), mockPath}).Return(mockError)
+				mockRunner.EXPECT().Run("docker", []string{"build", "-t", imageName(mockURI, mockImageTag), "-f", mockPath, "mockPath/to"}).Return(mockError)
 			},
 			want: fmt.Errorf("building image: %w", mockError),
 		},
@@ -38,7 +42,34 @@ func TestBuild(t *testing.T) {
 			setupMocks: func(controller *gomock.Controller) {
 				mockRunner = mocks.NewMockrunner(controller)
 
-				mockRunner.EXPECT().Run("docker", []string{"build", "-t", imageName(mockURI, mockImageTag), mockPath}).Return(nil)
+				mockRunner.EXPECT().Run("docker", []string{"build", "-t", imageName(mockURI, mockImageTag), "-f", mockPath, "mockPath/to"}).Return(nil)
+			},
+		},
+		"with context": {
+			inContext: "mockPath",
+			setupMocks: func(controller *gomock.Controller) {
+				mockRunner = mocks.NewMockrunner(controller)
+
+				mockRunner.EXPECT().Run("docker", []string{"build", "-t", imageName(mockURI, mockImageTag), "-f", mockPath, "mockPath"}).Return(nil)
+			},
+		},
+		"with target, cache sources and sorted build args": {
+			inTarget:    "builder",
+			inCacheFrom: []string{"foo/bar:latest", "foo/bar/baz:1.2.3"},
+			inArgs: map[string]string{
+				"GOPROXY": "direct",
+				"ARG":     "value",
+			},
+			setupMocks: func(controller *gomock.Controller) {
+				mockRunner = mocks.NewMockrunner(controller)
+
+				mockRunner.EXPECT().Run("docker", []string{"build", "-t", imageName(mockURI, mockImageTag),
+					"--target", "builder",
+					"--cache-from", "foo/bar:latest",
+					"--cache-from", "foo/bar/baz:1.2.3",
+					"--build-arg", "ARG=value",
+					"--build-arg", "GOPROXY=direct",
+					"-f", mockPath, "mockPath/to"}).Return(nil)
 			},
 		},
 	}
@@ -51,7 +82,15 @@ func TestBuild(t *testing.T) {
 				runner: mockRunner,
 			}
 
-			got := s.Build(mockURI, mockImageTag, mockPath)
+			got := s.Build(&BuildArguments{
+				URI:        mockURI,
+				ImageTag:   mockImageTag,
+				Dockerfile: mockPath,
+				Context:    test.inContext,
+				Target:     test.inTarget,
+				CacheFrom:  test.inCacheFrom,
+				Args:       test.inArgs,
+			})
 
 			require.Equal(t, test.want, got)
 		})
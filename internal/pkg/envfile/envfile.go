@@ -0,0 +1,52 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package envfile provides functionality to parse environment files referenced by containers.
+package envfile
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var variableNameRegexp = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// Parse returns the environment variables of the file content in the format read by Amazon ECS.
+// An error is returned if a line isn't a comment, a blank line or a VARIABLE=VALUE pair,
+// or if a variable is defined more than once.
+//
+// For example, the method returns {"LOG_LEVEL": "info", "GREETING": "hello world"} if the file's content is:
+//  # Comments start with "#".
+//  LOG_LEVEL=info
+//  GREETING=hello world
+//
+// The value is taken as is, quotes aren't interpreted.
+func Parse(data []byte) (map[string]string, error) {
+	vars := make(map[string]string)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		parts := strings.SplitN(text, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("line %d: expected VARIABLE=VALUE", line)
+		}
+		name := parts[0]
+		if !variableNameRegexp.MatchString(name) {
+			return nil, fmt.Errorf("line %d: invalid variable name %q", line, name)
+		}
+		if _, ok := vars[name]; ok {
+			return nil, fmt.Errorf("line %d: variable %s is already defined", line, name)
+		}
+		vars[name] = parts[1]
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read environment file: %w", err)
+	}
+	return vars, nil
+}
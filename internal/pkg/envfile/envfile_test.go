@@ -0,0 +1,72 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package envfile
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	testCases := map[string]struct {
+		inContent string
+
+		wantedVars map[string]string
+		wantedErr  error
+	}{
+		"ignores comments and blank lines": {
+			inContent: `# Logging
+LOG_LEVEL=info
+
+GREETING=hello world
+QUOTED="kept as is"
+EMPTY=
+URL=https://example.com/?a=b
+`,
+			wantedVars: map[string]string{
+				"LOG_LEVEL": "info",
+				"GREETING":  "hello world",
+				"QUOTED":    `"kept as is"`,
+				"EMPTY":     "",
+				"URL":       "https://example.com/?a=b",
+			},
+		},
+		"empty file": {
+			inContent:  "",
+			wantedVars: map[string]string{},
+		},
+		"error if a line isn't a pair": {
+			inContent: `LOG_LEVEL=info
+export`,
+			wantedErr: errors.New("line 2: expected VARIABLE=VALUE"),
+		},
+		"error if the name is invalid": {
+			inContent: `1PASSWORD=secret`,
+			wantedErr: errors.New(`line 1: invalid variable name "1PASSWORD"`),
+		},
+		"error if a variable is defined twice": {
+			inContent: `LOG_LEVEL=info
+# Override
+LOG_LEVEL=debug`,
+			wantedErr: errors.New("line 3: variable LOG_LEVEL is already defined"),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// WHEN
+			vars, err := Parse([]byte(tc.inContent))
+
+			// THEN
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.wantedVars, vars)
+		})
+	}
+}
@@ -27,19 +27,30 @@ type BackendServiceProps struct {
 
 // BackendService holds the configuration to create a backend service manifest.
 type BackendService struct {
-	Service      `yaml:",inline"`
-	Image        imageWithPortAndHealthcheck `yaml:",flow"`
-	TaskConfig   `yaml:",inline"`
-	Sidecar      `yaml:",inline"`
-	Environments map[string]backendServiceOverrideConfig `yaml:",flow"`
-
-	parser template.Parser
+	Service       `yaml:",inline"`
+	Image         imageWithPortAndHealthcheck `yaml:",flow"`
+	ImageOverride `yaml:",inline"`
+	TaskConfig    `yaml:",inline"`
+	Sidecar       `yaml:",inline"`
+	Logging       Logging                                 `yaml:"logging,flow"`
+	Platform      Platform                                `yaml:"platform,flow"`
+	Network       NetworkConfig                           `yaml:"network"`
+	Deployment    DeploymentConfig                        `yaml:"deployment"`
+	Environments  map[string]backendServiceOverrideConfig `yaml:",flow"`
+
+	envNulls map[string][][]string // Paths of the fields set to null in each environment override.
+	parser   template.Parser
 }
 
 type backendServiceOverrideConfig struct {
-	Image      imageWithPortAndHealthcheck `yaml:",flow"`
-	TaskConfig `yaml:",inline"`
-	Sidecar    `yaml:",inline"`
+	Image         imageWithPortAndHealthcheck `yaml:",flow"`
+	ImageOverride `yaml:",inline"`
+	TaskConfig    `yaml:",inline"`
+	Sidecar       `yaml:",inline"`
+	Logging       Logging          `yaml:"logging,flow"`
+	Platform      Platform         `yaml:"platform,flow"`
+	Network       NetworkConfig    `yaml:"network"`
+	Deployment    DeploymentConfig `yaml:"deployment"`
 }
 
 type imageWithPortAndHealthcheck struct {
@@ -69,7 +80,8 @@ func NewBackendService(props BackendServiceProps) *BackendService {
 	}
 	// Apply overrides.
 	svc.Name = props.Name
-	svc.Image.Build = props.Dockerfile
+	svc.Version = LatestServiceManifestVersion()
+	svc.Image.Build = BuildArgsOrString{BuildString: stringp(props.Dockerfile)}
 	svc.Image.Port = props.Port
 	svc.Image.HealthCheck = healthCheck
 	svc.parser = template.New()
@@ -97,9 +109,25 @@ func (s *BackendService) MarshalBinary() ([]byte, error) {
 	return content.Bytes(), nil
 }
 
-// DockerfilePath returns the image build path.
+// DockerfilePath returns the path to the image's Dockerfile.
 func (s *BackendService) DockerfilePath() string {
-	return s.Image.Build
+	return aws.StringValue(s.Image.BuildConfig().Dockerfile)
+}
+
+// BuildArgs returns the docker build options of the image in the environment.
+func (s *BackendService) BuildArgs(envName string) DockerBuildArgs {
+	return s.ApplyEnv(envName).Image.BuildConfig()
+}
+
+// ImageLocation returns the location of the pre-built container image in the environment, if any.
+func (s *BackendService) ImageLocation(envName string) string {
+	return s.ApplyEnv(envName).Image.Location
+}
+
+// EnvFiles returns the paths of the environment files of the containers in the environment, keyed by the container name.
+func (s *BackendService) EnvFiles(envName string) map[string]string {
+	mft := s.ApplyEnv(envName)
+	return envFiles(mft.Name, mft.TaskConfig, mft.Sidecars)
 }
 
 // ApplyEnv returns the service manifest with environment overrides.
@@ -109,15 +137,22 @@ func (s *BackendService) ApplyEnv(envName string) *BackendService {
 	if !ok {
 		return s
 	}
-	return &BackendService{
-		Service: s.Service,
-		Image: imageWithPortAndHealthcheck{
-			ServiceImageWithPort: target.Image.ServiceImageWithPort,
-			HealthCheck:          target.Image.HealthCheck,
-		},
-		TaskConfig: s.TaskConfig.copyAndApply(target.TaskConfig),
-		Sidecar:    s.Sidecar.copyAndApply(target.Sidecar),
+	out := &BackendService{}
+	applyEnvOverride(out, s, target, s.envNulls[envName])
+	return out
+}
+
+// validate returns an error if the manifest or any of its environment overrides are invalid.
+func (s *BackendService) validate() error {
+	if err := s.Image.validate(); err != nil {
+		return err
+	}
+	for env, override := range s.Environments {
+		if err := override.Image.validate(); err != nil {
+			return fmt.Errorf("environment %s: %w", env, err)
+		}
 	}
+	return nil
 }
 
 // newDefaultBackendService returns a backend service with minimal task sizes and a single replica.
@@ -129,7 +164,9 @@ func newDefaultBackendService() *BackendService {
 		TaskConfig: TaskConfig{
 			CPU:    256,
 			Memory: 512,
-			Count:  intp(1),
+			Count: Count{
+				Value: intp(1),
+			},
 		},
 	}
 }
@@ -29,13 +29,14 @@ func TestNewBackendSvc(t *testing.T) {
 			},
 			wantedManifest: &BackendService{
 				Service: Service{
-					Name: "subscribers",
-					Type: BackendServiceType,
+					Name:    "subscribers",
+					Type:    BackendServiceType,
+					Version: 1,
 				},
 				Image: imageWithPortAndHealthcheck{
 					ServiceImageWithPort: ServiceImageWithPort{
 						ServiceImage: ServiceImage{
-							Build: "./subscribers/Dockerfile",
+							Build: BuildArgsOrString{BuildString: stringp("./subscribers/Dockerfile")},
 						},
 						Port: 8080,
 					},
@@ -43,7 +44,7 @@ func TestNewBackendSvc(t *testing.T) {
 				TaskConfig: TaskConfig{
 					CPU:    256,
 					Memory: 512,
-					Count:  intp(1),
+					Count:  Count{Value: intp(1)},
 				},
 			},
 		},
@@ -60,13 +61,14 @@ func TestNewBackendSvc(t *testing.T) {
 			},
 			wantedManifest: &BackendService{
 				Service: Service{
-					Name: "subscribers",
-					Type: BackendServiceType,
+					Name:    "subscribers",
+					Type:    BackendServiceType,
+					Version: 1,
 				},
 				Image: imageWithPortAndHealthcheck{
 					ServiceImageWithPort: ServiceImageWithPort{
 						ServiceImage: ServiceImage{
-							Build: "./subscribers/Dockerfile",
+							Build: BuildArgsOrString{BuildString: stringp("./subscribers/Dockerfile")},
 						},
 						Port: 8080,
 					},
@@ -81,7 +83,7 @@ func TestNewBackendSvc(t *testing.T) {
 				TaskConfig: TaskConfig{
 					CPU:    256,
 					Memory: 512,
-					Count:  intp(1),
+					Count:  Count{Value: intp(1)},
 				},
 			},
 		},
@@ -184,7 +186,7 @@ func TestBackendSvc_ApplyEnv(t *testing.T) {
 				Image: imageWithPortAndHealthcheck{
 					ServiceImageWithPort: ServiceImageWithPort{
 						ServiceImage: ServiceImage{
-							Build: "./Dockerfile",
+							Build: BuildArgsOrString{BuildString: stringp("./Dockerfile")},
 						},
 						Port: 8080,
 					},
@@ -199,7 +201,7 @@ func TestBackendSvc_ApplyEnv(t *testing.T) {
 				TaskConfig: TaskConfig{
 					CPU:    256,
 					Memory: 256,
-					Count:  intp(1),
+					Count:  Count{Value: intp(1)},
 				},
 			},
 			inEnvName: "test",
@@ -212,7 +214,7 @@ func TestBackendSvc_ApplyEnv(t *testing.T) {
 				Image: imageWithPortAndHealthcheck{
 					ServiceImageWithPort: ServiceImageWithPort{
 						ServiceImage: ServiceImage{
-							Build: "./Dockerfile",
+							Build: BuildArgsOrString{BuildString: stringp("./Dockerfile")},
 						},
 						Port: 8080,
 					},
@@ -227,7 +229,7 @@ func TestBackendSvc_ApplyEnv(t *testing.T) {
 				TaskConfig: TaskConfig{
 					CPU:    256,
 					Memory: 256,
-					Count:  intp(1),
+					Count:  Count{Value: intp(1)},
 				},
 			},
 		},
@@ -241,7 +243,7 @@ func TestBackendSvc_ApplyEnv(t *testing.T) {
 				TaskConfig: TaskConfig{
 					CPU:    256,
 					Memory: 256,
-					Count:  intp(1),
+					Count:  Count{Value: intp(1)},
 				},
 				Sidecar: Sidecar{
 					Sidecars: map[string]SidecarConfig{
@@ -259,7 +261,7 @@ func TestBackendSvc_ApplyEnv(t *testing.T) {
 							},
 						},
 						TaskConfig: TaskConfig{
-							Count: intp(0),
+							Count: Count{Value: intp(0)},
 							Variables: map[string]string{
 								"LOG_LEVEL": "DEBUG",
 							},
@@ -285,11 +287,10 @@ func TestBackendSvc_ApplyEnv(t *testing.T) {
 				TaskConfig: TaskConfig{
 					CPU:    256,
 					Memory: 256,
-					Count:  intp(0),
+					Count:  Count{Value: intp(0)},
 					Variables: map[string]string{
 						"LOG_LEVEL": "DEBUG",
 					},
-					Secrets: map[string]string{},
 				},
 				Sidecar: Sidecar{
 					Sidecars: map[string]SidecarConfig{
@@ -312,3 +313,44 @@ func TestBackendSvc_ApplyEnv(t *testing.T) {
 		})
 	}
 }
+
+func TestBackendSvc_EnvFiles(t *testing.T) {
+	// GIVEN
+	svc := &BackendService{
+		Service: Service{
+			Name: "api",
+			Type: BackendServiceType,
+		},
+		TaskConfig: TaskConfig{
+			EnvFile: stringp("api/.env"),
+		},
+		Sidecar: Sidecar{
+			Sidecars: map[string]SidecarConfig{
+				"nginx": {
+					Image:   "nginx",
+					EnvFile: stringp("nginx/.env"),
+				},
+				"xray": {
+					Image: "amazon/aws-xray-daemon",
+				},
+			},
+		},
+		Environments: map[string]backendServiceOverrideConfig{
+			"prod": {
+				TaskConfig: TaskConfig{
+					EnvFile: stringp("api/prod.env"),
+				},
+			},
+		},
+	}
+
+	// THEN
+	require.Equal(t, map[string]string{
+		"api":   "api/.env",
+		"nginx": "nginx/.env",
+	}, svc.EnvFiles("test"))
+	require.Equal(t, map[string]string{
+		"api":   "api/prod.env",
+		"nginx": "nginx/.env",
+	}, svc.EnvFiles("prod"))
+}
@@ -0,0 +1,66 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package manifest
+
+import "time"
+
+// Strategies to replace the tasks of a service during a deployment.
+const (
+	RollingDeploymentStrategy   = "rolling"
+	BlueGreenDeploymentStrategy = "blue_green"
+)
+
+// Ways CodeDeploy shifts the production traffic to the tasks of a blue/green deployment.
+const (
+	CanaryTrafficShifting    = "canary"
+	LinearTrafficShifting    = "linear"
+	AllAtOnceTrafficShifting = "all_at_once"
+)
+
+var (
+	deploymentStrategies = []string{RollingDeploymentStrategy, BlueGreenDeploymentStrategy}
+	trafficShiftings     = []string{CanaryTrafficShifting, LinearTrafficShifting, AllAtOnceTrafficShifting}
+)
+
+// DeploymentConfig holds the configuration of the deployments of the service.
+// See https://docs.aws.amazon.com/AmazonECS/latest/developerguide/deployment-types.html
+type DeploymentConfig struct {
+	Strategy          *string         `yaml:"strategy"`                // Defaults to "rolling".
+	MinHealthyPercent *int            `yaml:"minimum_healthy_percent"` // Defaults to 100.
+	MaxPercent        *int            `yaml:"maximum_percent"`         // Defaults to 200.
+	CircuitBreaker    CircuitBreaker  `yaml:"circuit_breaker"`         // Only applies to rolling deployments.
+	BlueGreen         BlueGreenConfig `yaml:"blue_green"`              // Only applies to blue/green deployments.
+}
+
+// IsBlueGreen returns whether the tasks of the service are replaced by CodeDeploy blue/green deployments.
+func (d DeploymentConfig) IsBlueGreen() bool {
+	return d.Strategy != nil && *d.Strategy == BlueGreenDeploymentStrategy
+}
+
+// CircuitBreaker holds the configuration of the ECS deployment circuit breaker, which stops
+// a deployment whose tasks fail to reach a steady state.
+type CircuitBreaker struct {
+	Enable   *bool `yaml:"enable"`
+	Rollback *bool `yaml:"rollback"` // Whether to roll back to the last completed deployment once the deployment fails.
+}
+
+// IsEmpty returns whether CircuitBreaker is empty.
+func (c CircuitBreaker) IsEmpty() bool {
+	return c.Enable == nil && c.Rollback == nil
+}
+
+// BlueGreenConfig holds the configuration of the CodeDeploy blue/green deployments of a load balanced web service.
+// See https://docs.aws.amazon.com/AmazonECS/latest/developerguide/deployment-type-bluegreen.html
+type BlueGreenConfig struct {
+	TrafficShifting *string        `yaml:"traffic_shifting"`         // Defaults to "all_at_once".
+	Percentage      *int           `yaml:"percentage"`               // Traffic shifted at each step of a canary or linear deployment, defaults to 10.
+	Interval        *time.Duration `yaml:"interval"`                 // Time between the steps of a canary or linear deployment in whole minutes.
+	Alarms          []string       `yaml:"alarms" override:"append"` // Names of CloudWatch alarms that roll back the deployment, environments add to them.
+	TestPort        *int           `yaml:"test_port"`                // Port of the load balancer's test listener, defaults to the first port from 8080 that the environment's load balancer doesn't listen on.
+}
+
+// IsEmpty returns whether BlueGreenConfig is empty.
+func (b BlueGreenConfig) IsEmpty() bool {
+	return b.TrafficShifting == nil && b.Percentage == nil && b.Interval == nil && b.Alarms == nil && b.TestPort == nil
+}
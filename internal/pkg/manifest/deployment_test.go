@@ -0,0 +1,106 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package manifest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeploymentConfig_applyOverride(t *testing.T) {
+	testCases := map[string]struct {
+		inDeployment DeploymentConfig
+		inOther      DeploymentConfig
+
+		wanted DeploymentConfig
+	}{
+		"no overrides": {
+			inDeployment: DeploymentConfig{
+				MinHealthyPercent: intp(50),
+				CircuitBreaker: CircuitBreaker{
+					Enable: boolp(true),
+				},
+			},
+			wanted: DeploymentConfig{
+				MinHealthyPercent: intp(50),
+				CircuitBreaker: CircuitBreaker{
+					Enable: boolp(true),
+				},
+			},
+		},
+		"overrides field by field": {
+			inDeployment: DeploymentConfig{
+				MinHealthyPercent: intp(50),
+				MaxPercent:        intp(200),
+				CircuitBreaker: CircuitBreaker{
+					Enable: boolp(true),
+				},
+			},
+			inOther: DeploymentConfig{
+				MaxPercent: intp(150),
+				CircuitBreaker: CircuitBreaker{
+					Rollback: boolp(true),
+				},
+			},
+			wanted: DeploymentConfig{
+				MinHealthyPercent: intp(50),
+				MaxPercent:        intp(150),
+				CircuitBreaker: CircuitBreaker{
+					Enable:   boolp(true),
+					Rollback: boolp(true),
+				},
+			},
+		},
+		"overrides the blue/green deployments field by field": {
+			inDeployment: DeploymentConfig{
+				Strategy: stringp(BlueGreenDeploymentStrategy),
+				BlueGreen: BlueGreenConfig{
+					TrafficShifting: stringp(CanaryTrafficShifting),
+					Percentage:      intp(10),
+					Alarms:          []string{"5xx"},
+				},
+			},
+			inOther: DeploymentConfig{
+				BlueGreen: BlueGreenConfig{
+					Interval: durationp(10 * time.Minute),
+					Alarms:   []string{"5xx", "latency"},
+					TestPort: intp(9000),
+				},
+			},
+			wanted: DeploymentConfig{
+				Strategy: stringp(BlueGreenDeploymentStrategy),
+				BlueGreen: BlueGreenConfig{
+					TrafficShifting: stringp(CanaryTrafficShifting),
+					Percentage:      intp(10),
+					Interval:        durationp(10 * time.Minute),
+					Alarms:          []string{"5xx", "latency"},
+					TestPort:        intp(9000),
+				},
+			},
+		},
+		"overrides the strategy": {
+			inDeployment: DeploymentConfig{
+				Strategy: stringp(BlueGreenDeploymentStrategy),
+			},
+			inOther: DeploymentConfig{
+				Strategy: stringp(RollingDeploymentStrategy),
+			},
+			wanted: DeploymentConfig{
+				Strategy: stringp(RollingDeploymentStrategy),
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// WHEN
+			got := applyOverride(tc.inDeployment, tc.inOther)
+
+			// THEN
+			require.Equal(t, tc.wanted, got)
+		})
+	}
+}
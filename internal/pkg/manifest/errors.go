@@ -5,6 +5,7 @@ package manifest
 
 import (
 	"fmt"
+	"strings"
 )
 
 // ErrInvalidSvcManifestType occurs when a user requested a manifest template type that doesn't exist.
@@ -16,6 +17,62 @@ func (e *ErrInvalidSvcManifestType) Error() string {
 	return fmt.Sprintf("invalid manifest type: %s", e.Type)
 }
 
+// ErrInvalidManifest occurs when a manifest doesn't conform to the schema of its type.
+type ErrInvalidManifest struct {
+	Path   string // Path of the manifest file, set by the callers that read it to locate each error in the file.
+	Errors []*ErrInvalidField
+}
+
+func (e *ErrInvalidManifest) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		msgs[i] = err.Error()
+		if e.Path != "" {
+			msgs[i] = fmt.Sprintf("%s:%d:%d: %s", e.Path, err.Line, err.Column, err.Reason)
+		}
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// ErrInvalidField occurs when a field in a manifest is unknown or has an invalid value.
+type ErrInvalidField struct {
+	Field  string // Path to the field, for example "environments.prod.image.port".
+	Line   int
+	Column int
+	Reason string
+}
+
+func (e *ErrInvalidField) Error() string {
+	return fmt.Sprintf("line %d, column %d: %s", e.Line, e.Column, e.Reason)
+}
+
+// ErrUnsetEnvVar occurs when a manifest references an environment variable that is not set and has no default value.
+type ErrUnsetEnvVar struct {
+	Name   string
+	Line   int
+	Column int
+}
+
+func (e *ErrUnsetEnvVar) Error() string {
+	return fmt.Sprintf(`line %d, column %d: environment variable %s is not set, set it or provide a default with "${%s:-default}"`,
+		e.Line, e.Column, e.Name, e.Name)
+}
+
+// ErrInvalidSvcManifestVersion occurs when the "version" field of a service manifest isn't a version
+// known to this release of the CLI.
+type ErrInvalidSvcManifestVersion struct {
+	Version string
+	Latest  int
+	isNewer bool
+}
+
+func (e *ErrInvalidSvcManifestVersion) Error() string {
+	if e.isNewer {
+		return fmt.Sprintf("manifest version %s is newer than the latest version %d supported by this release, upgrade copilot to use this manifest", e.Version, e.Latest)
+	}
+	return fmt.Sprintf("invalid manifest version %s, must be an integer between 1 and %d", e.Version, e.Latest)
+}
+
 // ErrInvalidPipelineManifestVersion occurs when the pipeline.yml file
 // contains invalid schema version during unmarshalling.
 type ErrInvalidPipelineManifestVersion struct {
@@ -0,0 +1,98 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package manifest
+
+import (
+	"os"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// envVarRegexp matches an escaped "$", or a reference to an environment variable with an optional default value.
+var envVarRegexp = regexp.MustCompile(`\$\$|\$\{([A-Za-z_][A-Za-z0-9_]*)(:-([^}]*))?\}`)
+
+// unmarshalInterpolated parses the YAML input stream into a document node, and expands the
+// environment variables referenced in its values.
+func unmarshalInterpolated(in []byte) (*yaml.Node, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(in, &doc); err != nil {
+		return nil, err
+	}
+	if len(doc.Content) == 0 {
+		// An empty input stream is decoded like an empty map.
+		doc = yaml.Node{
+			Kind:    yaml.DocumentNode,
+			Content: []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}},
+		}
+	}
+	if err := interpolate(&doc); err != nil {
+		return nil, err
+	}
+	return &doc, nil
+}
+
+// interpolate expands the environment variables referenced in the scalar values of the node and its children.
+// A reference is either "${VAR}", or "${VAR:-default}" which falls back to "default" if VAR is unset or empty.
+// "$$" is an escaped "$". Keys of maps and comments are left untouched.
+func interpolate(node *yaml.Node) error {
+	switch node.Kind {
+	case yaml.DocumentNode, yaml.SequenceNode:
+		for _, child := range node.Content {
+			if err := interpolate(child); err != nil {
+				return err
+			}
+		}
+	case yaml.MappingNode:
+		for i := 1; i < len(node.Content); i += 2 {
+			if err := interpolate(node.Content[i]); err != nil {
+				return err
+			}
+		}
+	case yaml.ScalarNode:
+		return interpolateScalar(node)
+	}
+	return nil
+}
+
+func interpolateScalar(node *yaml.Node) error {
+	if !strings.Contains(node.Value, "$") {
+		return nil
+	}
+	var err error
+	value := envVarRegexp.ReplaceAllStringFunc(node.Value, func(match string) string {
+		if match == "$$" {
+			return "$"
+		}
+		groups := envVarRegexp.FindStringSubmatch(match)
+		name, hasDefault, defaultValue := groups[1], groups[2] != "", groups[3]
+		if v, ok := os.LookupEnv(name); ok && (v != "" || !hasDefault) {
+			return v
+		}
+		if hasDefault {
+			return defaultValue
+		}
+		if err == nil {
+			err = &ErrUnsetEnvVar{
+				Name:   name,
+				Line:   node.Line,
+				Column: node.Column,
+			}
+		}
+		return match
+	})
+	if err != nil {
+		return err
+	}
+	if value == node.Value {
+		return nil
+	}
+	node.Value = value
+	if node.Style == 0 {
+		// Let the decoder resolve the type of the expanded plain value, for example "${PORT}" to an integer.
+		node.Tag = ""
+	}
+	return nil
+}
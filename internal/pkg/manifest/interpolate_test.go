@@ -0,0 +1,176 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package manifest
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnmarshalInterpolated(t *testing.T) {
+	testCases := map[string]struct {
+		inEnvVars map[string]string
+		inContent string
+
+		wanted    map[string]interface{}
+		wantedErr error
+	}{
+		"expands set variables and resolves the type of plain values": {
+			inEnvVars: map[string]string{
+				"COPILOT_TEST_ACCOUNT": "123456789012",
+				"COPILOT_TEST_TAG":     "v1.0.0",
+				"COPILOT_TEST_PORT":    "8080",
+			},
+			inContent: `image:
+  location: ${COPILOT_TEST_ACCOUNT}.dkr.ecr.us-west-2.amazonaws.com/api:${COPILOT_TEST_TAG}
+  port: ${COPILOT_TEST_PORT}
+variables:
+  PORT: "${COPILOT_TEST_PORT}"
+`,
+			wanted: map[string]interface{}{
+				"image": map[string]interface{}{
+					"location": "123456789012.dkr.ecr.us-west-2.amazonaws.com/api:v1.0.0",
+					"port":     8080,
+				},
+				"variables": map[string]interface{}{
+					"PORT": "8080",
+				},
+			},
+		},
+		"falls back to the default value if the variable is unset or empty": {
+			inEnvVars: map[string]string{
+				"COPILOT_TEST_EMPTY": "",
+			},
+			inContent: `unset: ${COPILOT_TEST_UNSET:-info}
+empty: ${COPILOT_TEST_EMPTY:-debug}
+emptyDefault: ${COPILOT_TEST_UNSET:-}
+emptyWithoutDefault: ${COPILOT_TEST_EMPTY}
+`,
+			wanted: map[string]interface{}{
+				"unset":               "info",
+				"empty":               "debug",
+				"emptyDefault":        nil,
+				"emptyWithoutDefault": nil,
+			},
+		},
+		"leaves keys, comments and escaped values untouched": {
+			inContent: `# Set ${COPILOT_TEST_UNSET} to change the command.
+${COPILOT_TEST_UNSET}: value
+command: echo $${HOME} $$ $HOME
+`,
+			wanted: map[string]interface{}{
+				"${COPILOT_TEST_UNSET}": "value",
+				"command":               "echo ${HOME} $ $HOME",
+			},
+		},
+		"expands variables in environment overrides": {
+			inEnvVars: map[string]string{
+				"COPILOT_TEST_TAG": "v1.0.0",
+			},
+			inContent: `environments:
+  prod:
+    image:
+      location: api:${COPILOT_TEST_TAG}
+`,
+			wanted: map[string]interface{}{
+				"environments": map[string]interface{}{
+					"prod": map[string]interface{}{
+						"image": map[string]interface{}{
+							"location": "api:v1.0.0",
+						},
+					},
+				},
+			},
+		},
+		"error if a variable is unset and has no default value": {
+			inContent: `image:
+  build: ./Dockerfile
+environments:
+  prod:
+    image:
+      location: api:${COPILOT_TEST_UNSET}
+`,
+			wantedErr: &ErrUnsetEnvVar{
+				Name:   "COPILOT_TEST_UNSET",
+				Line:   6,
+				Column: 17,
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			for k, v := range tc.inEnvVars {
+				os.Setenv(k, v)
+				defer os.Unsetenv(k)
+			}
+
+			// WHEN
+			doc, err := unmarshalInterpolated([]byte(tc.inContent))
+
+			// THEN
+			if tc.wantedErr != nil {
+				require.Equal(t, tc.wantedErr, err)
+				return
+			}
+			require.NoError(t, err)
+			var got map[string]interface{}
+			require.NoError(t, doc.Decode(&got))
+			require.Equal(t, tc.wanted, got)
+		})
+	}
+}
+
+func TestUnmarshalService_Interpolation(t *testing.T) {
+	// GIVEN
+	os.Setenv("COPILOT_TEST_ACCOUNT", "123456789012")
+	defer os.Unsetenv("COPILOT_TEST_ACCOUNT")
+	in := `name: api
+type: Backend Service
+image:
+  build: ./api/Dockerfile
+  port: ${COPILOT_TEST_PORT:-8080}
+environments:
+  prod:
+    image:
+      location: ${COPILOT_TEST_ACCOUNT}.dkr.ecr.us-west-2.amazonaws.com/api:latest
+`
+
+	// WHEN
+	m, err := UnmarshalService([]byte(in))
+
+	// THEN
+	require.NoError(t, err)
+	svc, ok := m.(*BackendService)
+	require.True(t, ok)
+	require.Equal(t, uint16(8080), svc.Image.Port)
+	require.Equal(t, "123456789012.dkr.ecr.us-west-2.amazonaws.com/api:latest", svc.ImageLocation("prod"))
+}
+
+func TestUnmarshalPipeline_Interpolation(t *testing.T) {
+	// GIVEN
+	os.Setenv("COPILOT_TEST_BRANCH", "mainline")
+	defer os.Unsetenv("COPILOT_TEST_BRANCH")
+	in := `name: pipepiper
+version: 1
+source:
+  provider: GitHub
+  properties:
+    repository: aws/somethingCool
+    branch: ${COPILOT_TEST_BRANCH}
+stages:
+  - name: ${COPILOT_TEST_STAGE:-test}
+`
+
+	// WHEN
+	pm, err := UnmarshalPipeline([]byte(in))
+
+	// THEN
+	require.NoError(t, err)
+	require.Equal(t, "mainline", pm.Source.Properties["branch"])
+	require.Equal(t, "test", pm.Stages[0].Name)
+}
@@ -4,7 +4,13 @@
 package manifest
 
 import (
+	"errors"
+	"fmt"
+	"time"
+
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/template"
+	"github.com/aws/aws-sdk-go/aws"
+	"gopkg.in/yaml.v3"
 )
 
 const (
@@ -12,28 +18,43 @@ const (
 
 	// LogRetentionInDays is the default log retention time in days.
 	LogRetentionInDays = 30
+
+	defaultHealthCheckPath = "/"
 )
 
 // LoadBalancedWebService holds the configuration to build a container image with an exposed port that receives
 // requests through a load balancer with AWS Fargate as the compute engine.
 type LoadBalancedWebService struct {
-	Service      `yaml:",inline"`
-	Image        ServiceImageWithPort `yaml:",flow"`
-	RoutingRule  `yaml:"http,flow"`
-	TaskConfig   `yaml:",inline"`
-	LogsConfig   `yaml:",flow"`
-	Sidecar      `yaml:",inline"`
-	Environments map[string]loadBalancedWebServiceOverrideConfig `yaml:",flow"` // Fields to override per environment.
+	Service       `yaml:",inline"`
+	Image         ServiceImageWithPort `yaml:",flow"`
+	ImageOverride `yaml:",inline"`
+	RoutingRule   `yaml:"http,flow"`
+	TaskConfig    `yaml:",inline"`
+	LogsConfig    `yaml:",flow"`
+	Sidecar       `yaml:",inline"`
+	NLB           NetworkLoadBalancerConfiguration                `yaml:"nlb"` // Exposes TCP and UDP ports in addition to the HTTP routing rule.
+	Logging       Logging                                         `yaml:"logging,flow"`
+	Platform      Platform                                        `yaml:"platform,flow"`
+	Network       NetworkConfig                                   `yaml:"network"`
+	Deployment    DeploymentConfig                                `yaml:"deployment"`
+	Environments  map[string]loadBalancedWebServiceOverrideConfig `yaml:",flow"` // Fields to override per environment.
 
-	parser template.Parser
+	envNulls map[string][][]string // Paths of the fields set to null in each environment override.
+	parser   template.Parser
 }
 
 type loadBalancedWebServiceOverrideConfig struct {
-	Image       ServiceImageWithPort `yaml:",flow"`
-	RoutingRule `yaml:"http,flow"`
-	TaskConfig  `yaml:",inline"`
-	LogsConfig  `yaml:",flow"`
-	Sidecar     `yaml:"sidecar,flow"`
+	Image         ServiceImageWithPort `yaml:",flow"`
+	ImageOverride `yaml:",inline"`
+	RoutingRule   `yaml:"http,flow"`
+	TaskConfig    `yaml:",inline"`
+	LogsConfig    `yaml:",flow"`
+	Sidecar       `yaml:",inline"`
+	NLB           NetworkLoadBalancerConfiguration `yaml:"nlb"`
+	Logging       Logging                          `yaml:"logging,flow"`
+	Platform      Platform                         `yaml:"platform,flow"`
+	Network       NetworkConfig                    `yaml:"network"`
+	Deployment    DeploymentConfig                 `yaml:"deployment"`
 }
 
 // LogsConfig is the configuration to the ECS logs.
@@ -41,20 +62,108 @@ type LogsConfig struct {
 	LogRetention int `yaml:"logRetention"`
 }
 
-// RoutingRule holds the path to route requests to the service.
+// Protocol versions of the requests sent to the targets of the load balancer.
+var targetProtocolVersions = []string{"HTTP1", "HTTP2", "GRPC"}
+
+var (
+	errUnmarshalHealthCheckArgs = errors.New(`unable to unmarshal "healthcheck" field to a string or health check configuration`)
+)
+
+// RoutingRule holds the path to route requests to the service and the configuration of its target group.
 type RoutingRule struct {
-	Path            string `yaml:"path"`
-	HealthCheckPath string `yaml:"healthcheck"`
+	Path                string                  `yaml:"path"`
+	Alias               *string                 `yaml:"alias"` // Domain name in the application's hosted zone that the service answers on.
+	HealthCheck         HealthCheckArgsOrString `yaml:"healthcheck"`
+	DeregistrationDelay *time.Duration          `yaml:"deregistration_delay"` // How long to drain the connections of a stopped task.
+	Stickiness          *bool                   `yaml:"stickiness"`           // Whether to route the requests of a client to the same task.
+	ProtocolVersion     *string                 `yaml:"protocol_version"`     // One of HTTP1, HTTP2 or GRPC.
+}
+
+// HealthCheckArgsOrString is a custom type which supports unmarshaling yaml which
+// can either be of type string or type HTTPHealthCheckArgs.
+type HealthCheckArgsOrString struct {
+	HealthCheckPath *string
+	HealthCheckArgs HTTPHealthCheckArgs // Mutually exclusive with HealthCheckPath.
+}
+
+// HTTPHealthCheckArgs holds the configuration of the health check of the target group.
+// See https://docs.aws.amazon.com/elasticloadbalancing/latest/application/target-group-health-checks.html
+type HTTPHealthCheckArgs struct {
+	Path               *string        `yaml:"path,omitempty"`
+	HealthyThreshold   *int           `yaml:"healthy_threshold,omitempty"`
+	UnhealthyThreshold *int           `yaml:"unhealthy_threshold,omitempty"`
+	SuccessCodes       *string        `yaml:"success_codes,omitempty"`
+	Interval           *time.Duration `yaml:"interval,omitempty"`
+	Timeout            *time.Duration `yaml:"timeout,omitempty"`
+}
+
+// IsEmpty returns whether HTTPHealthCheckArgs is empty.
+func (a HTTPHealthCheckArgs) IsEmpty() bool {
+	return a.Path == nil && a.HealthyThreshold == nil && a.UnhealthyThreshold == nil &&
+		a.SuccessCodes == nil && a.Interval == nil && a.Timeout == nil
+}
+
+// UnmarshalYAML overrides the default YAML unmarshaling logic for the HealthCheckArgsOrString
+// struct, allowing it to perform more complex unmarshaling behavior.
+// This method implements the yaml.Unmarshaler (v3) interface.
+func (h *HealthCheckArgsOrString) UnmarshalYAML(value *yaml.Node) error {
+	switch value.Kind {
+	case yaml.ScalarNode:
+		var path string
+		if err := value.Decode(&path); err != nil {
+			return errUnmarshalHealthCheckArgs
+		}
+		h.HealthCheckPath = &path
+		h.HealthCheckArgs = HTTPHealthCheckArgs{}
+		return nil
+	case yaml.MappingNode:
+		var args HTTPHealthCheckArgs
+		if err := value.Decode(&args); err != nil {
+			return fmt.Errorf("unmarshal health check configuration: %w", err)
+		}
+		h.HealthCheckPath = nil
+		h.HealthCheckArgs = args
+		return nil
+	default:
+		return errUnmarshalHealthCheckArgs
+	}
+}
+
+// MarshalYAML serializes the healthcheck field back into either its string or map form.
+// This method implements the yaml.Marshaler (v3) interface.
+func (h HealthCheckArgsOrString) MarshalYAML() (interface{}, error) {
+	if h.HealthCheckPath != nil {
+		return *h.HealthCheckPath, nil
+	}
+	if h.HealthCheckArgs.IsEmpty() {
+		return nil, nil
+	}
+	return h.HealthCheckArgs, nil
+}
+
+// IsEmpty returns whether HealthCheckArgsOrString is empty.
+func (h HealthCheckArgsOrString) IsEmpty() bool {
+	return h.HealthCheckPath == nil && h.HealthCheckArgs.IsEmpty()
 }
 
-func (r RoutingRule) copyAndApply(other RoutingRule) RoutingRule {
-	if other.Path != "" {
-		r.Path = other.Path
+// Path returns the path that the target group sends health check requests to, defaults to "/".
+func (h HealthCheckArgsOrString) Path() string {
+	if h.HealthCheckPath != nil {
+		return *h.HealthCheckPath
 	}
-	if other.HealthCheckPath != "" {
-		r.HealthCheckPath = other.HealthCheckPath
+	if h.HealthCheckArgs.Path != nil {
+		return *h.HealthCheckArgs.Path
 	}
-	return r
+	return defaultHealthCheckPath
+}
+
+func (h HealthCheckArgsOrString) args() HTTPHealthCheckArgs {
+	if h.HealthCheckPath != nil {
+		return HTTPHealthCheckArgs{
+			Path: stringp(*h.HealthCheckPath),
+		}
+	}
+	return h.HealthCheckArgs
 }
 
 // LoadBalancedWebServiceProps contains properties for creating a new load balanced fargate service manifest.
@@ -69,12 +178,13 @@ type LoadBalancedWebServiceProps struct {
 func NewLoadBalancedWebService(input *LoadBalancedWebServiceProps) *LoadBalancedWebService {
 	defaultLbManifest := newDefaultLoadBalancedWebService()
 	defaultLbManifest.Service = Service{
-		Name: input.Name,
-		Type: LoadBalancedWebServiceType,
+		Name:    input.Name,
+		Type:    LoadBalancedWebServiceType,
+		Version: LatestServiceManifestVersion(),
 	}
 	defaultLbManifest.Image = ServiceImageWithPort{
 		ServiceImage: ServiceImage{
-			Build: input.Dockerfile,
+			Build: BuildArgsOrString{BuildString: stringp(input.Dockerfile)},
 		},
 		Port: input.Port,
 	}
@@ -89,12 +199,16 @@ func newDefaultLoadBalancedWebService() *LoadBalancedWebService {
 		Service: Service{},
 		Image:   ServiceImageWithPort{},
 		RoutingRule: RoutingRule{
-			HealthCheckPath: "/",
+			HealthCheck: HealthCheckArgsOrString{
+				HealthCheckPath: stringp(defaultHealthCheckPath),
+			},
 		},
 		TaskConfig: TaskConfig{
 			CPU:    256,
 			Memory: 512,
-			Count:  intp(1),
+			Count: Count{
+				Value: intp(1),
+			},
 		},
 		LogsConfig: LogsConfig{
 			LogRetention: LogRetentionInDays,
@@ -112,9 +226,25 @@ func (s *LoadBalancedWebService) MarshalBinary() ([]byte, error) {
 	return content.Bytes(), nil
 }
 
-// DockerfilePath returns the image build path.
+// DockerfilePath returns the path to the image's Dockerfile.
 func (s *LoadBalancedWebService) DockerfilePath() string {
-	return s.Image.Build
+	return aws.StringValue(s.Image.BuildConfig().Dockerfile)
+}
+
+// BuildArgs returns the docker build options of the image in the environment.
+func (s *LoadBalancedWebService) BuildArgs(envName string) DockerBuildArgs {
+	return s.ApplyEnv(envName).Image.BuildConfig()
+}
+
+// ImageLocation returns the location of the pre-built container image in the environment, if any.
+func (s *LoadBalancedWebService) ImageLocation(envName string) string {
+	return s.ApplyEnv(envName).Image.Location
+}
+
+// EnvFiles returns the paths of the environment files of the containers in the environment, keyed by the container name.
+func (s *LoadBalancedWebService) EnvFiles(envName string) map[string]string {
+	mft := s.ApplyEnv(envName)
+	return envFiles(mft.Name, mft.TaskConfig, mft.Sidecars)
 }
 
 // ApplyEnv returns the service manifest with environment overrides.
@@ -124,15 +254,20 @@ func (s *LoadBalancedWebService) ApplyEnv(envName string) *LoadBalancedWebServic
 	if !ok {
 		return s
 	}
+	out := &LoadBalancedWebService{}
+	applyEnvOverride(out, s, target, s.envNulls[envName])
+	return out
+}
 
-	return &LoadBalancedWebService{
-		Service:     s.Service,
-		Image:       target.Image,
-		RoutingRule: s.RoutingRule.copyAndApply(target.RoutingRule),
-		TaskConfig:  s.TaskConfig.copyAndApply(target.TaskConfig),
-		Sidecar:     s.Sidecar.copyAndApply(target.Sidecar),
-		LogsConfig: LogsConfig{
-			LogRetention: target.LogRetention,
-		},
+// validate returns an error if the manifest or any of its environment overrides are invalid.
+func (s *LoadBalancedWebService) validate() error {
+	if err := s.Image.validate(); err != nil {
+		return err
+	}
+	for env, override := range s.Environments {
+		if err := override.Image.validate(); err != nil {
+			return fmt.Errorf("environment %s: %w", env, err)
+		}
 	}
+	return nil
 }
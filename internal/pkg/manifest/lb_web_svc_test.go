@@ -7,11 +7,13 @@ import (
 	"bytes"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/template"
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/template/mocks"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
 )
 
 func TestLoadBalancedWebSvc_MarshalBinary(t *testing.T) {
@@ -75,18 +77,20 @@ func TestLoadBalancedWebSvc_ApplyEnv(t *testing.T) {
 				},
 				Image: ServiceImageWithPort{
 					ServiceImage: ServiceImage{
-						Build: "./Dockerfile",
+						Build: BuildArgsOrString{BuildString: stringp("./Dockerfile")},
 					},
 					Port: 80,
 				},
 				RoutingRule: RoutingRule{
-					Path:            "/awards/*",
-					HealthCheckPath: "/",
+					Path: "/awards/*",
+					HealthCheck: HealthCheckArgsOrString{
+						HealthCheckPath: stringp("/"),
+					},
 				},
 				TaskConfig: TaskConfig{
 					CPU:    1024,
 					Memory: 1024,
-					Count:  intp(1),
+					Count:  Count{Value: intp(1)},
 				},
 			},
 			envToApply: "prod-iad",
@@ -98,18 +102,20 @@ func TestLoadBalancedWebSvc_ApplyEnv(t *testing.T) {
 				},
 				Image: ServiceImageWithPort{
 					ServiceImage: ServiceImage{
-						Build: "./Dockerfile",
+						Build: BuildArgsOrString{BuildString: stringp("./Dockerfile")},
 					},
 					Port: 80,
 				},
 				RoutingRule: RoutingRule{
-					Path:            "/awards/*",
-					HealthCheckPath: "/",
+					Path: "/awards/*",
+					HealthCheck: HealthCheckArgsOrString{
+						HealthCheckPath: stringp("/"),
+					},
 				},
 				TaskConfig: TaskConfig{
 					CPU:    1024,
 					Memory: 1024,
-					Count:  intp(1),
+					Count:  Count{Value: intp(1)},
 				},
 			},
 		},
@@ -121,25 +127,27 @@ func TestLoadBalancedWebSvc_ApplyEnv(t *testing.T) {
 				},
 				Image: ServiceImageWithPort{
 					ServiceImage: ServiceImage{
-						Build: "./Dockerfile",
+						Build: BuildArgsOrString{BuildString: stringp("./Dockerfile")},
 					},
 					Port: 80,
 				},
 				RoutingRule: RoutingRule{
-					Path:            "/awards/*",
-					HealthCheckPath: "/",
+					Path: "/awards/*",
+					HealthCheck: HealthCheckArgsOrString{
+						HealthCheckPath: stringp("/"),
+					},
 				},
 				TaskConfig: TaskConfig{
 					CPU:    1024,
 					Memory: 1024,
-					Count:  intp(1),
+					Count:  Count{Value: intp(1)},
 					Variables: map[string]string{
 						"LOG_LEVEL":      "DEBUG",
 						"DDB_TABLE_NAME": "awards",
 					},
-					Secrets: map[string]string{
-						"GITHUB_TOKEN": "1111",
-						"TWILIO_TOKEN": "1111",
+					Secrets: map[string]SecretArgsOrString{
+						"GITHUB_TOKEN": {SecretString: stringp("1111")},
+						"TWILIO_TOKEN": {SecretString: stringp("1111")},
 					},
 				},
 				Sidecar: Sidecar{
@@ -155,13 +163,13 @@ func TestLoadBalancedWebSvc_ApplyEnv(t *testing.T) {
 					"prod-iad": {
 						Image: ServiceImageWithPort{
 							ServiceImage: ServiceImage{
-								Build: "./RealDockerfile",
+								Build: BuildArgsOrString{BuildString: stringp("./RealDockerfile")},
 							},
 							Port: 5000,
 						},
 						TaskConfig: TaskConfig{
 							CPU:   2046,
-							Count: intp(0),
+							Count: Count{Value: intp(0)},
 							Variables: map[string]string{
 								"DDB_TABLE_NAME": "awards-prod",
 							},
@@ -185,25 +193,27 @@ func TestLoadBalancedWebSvc_ApplyEnv(t *testing.T) {
 				},
 				Image: ServiceImageWithPort{
 					ServiceImage: ServiceImage{
-						Build: "./RealDockerfile",
+						Build: BuildArgsOrString{BuildString: stringp("./RealDockerfile")},
 					},
 					Port: 5000,
 				},
 				RoutingRule: RoutingRule{
-					Path:            "/awards/*",
-					HealthCheckPath: "/",
+					Path: "/awards/*",
+					HealthCheck: HealthCheckArgsOrString{
+						HealthCheckPath: stringp("/"),
+					},
 				},
 				TaskConfig: TaskConfig{
 					CPU:    2046,
 					Memory: 1024,
-					Count:  intp(0),
+					Count:  Count{Value: intp(0)},
 					Variables: map[string]string{
 						"LOG_LEVEL":      "DEBUG",
 						"DDB_TABLE_NAME": "awards-prod",
 					},
-					Secrets: map[string]string{
-						"GITHUB_TOKEN": "1111",
-						"TWILIO_TOKEN": "1111",
+					Secrets: map[string]SecretArgsOrString{
+						"GITHUB_TOKEN": {SecretString: stringp("1111")},
+						"TWILIO_TOKEN": {SecretString: stringp("1111")},
 					},
 				},
 				Sidecar: Sidecar{
@@ -231,3 +241,142 @@ func TestLoadBalancedWebSvc_ApplyEnv(t *testing.T) {
 		})
 	}
 }
+
+func TestHealthCheckArgsOrString_UnmarshalYAML(t *testing.T) {
+	testCases := map[string]struct {
+		inContent []byte
+
+		wantedStruct HealthCheckArgsOrString
+		wantedError  error
+	}{
+		"health check path": {
+			inContent: []byte(`healthcheck: /ping`),
+			wantedStruct: HealthCheckArgsOrString{
+				HealthCheckPath: stringp("/ping"),
+			},
+		},
+		"health check configuration": {
+			inContent: []byte(`healthcheck:
+  path: /ping
+  healthy_threshold: 3
+  unhealthy_threshold: 4
+  success_codes: 200-299
+  interval: 15s
+  timeout: 10s`),
+			wantedStruct: HealthCheckArgsOrString{
+				HealthCheckArgs: HTTPHealthCheckArgs{
+					Path:               stringp("/ping"),
+					HealthyThreshold:   intp(3),
+					UnhealthyThreshold: intp(4),
+					SuccessCodes:       stringp("200-299"),
+					Interval:           durationp(15 * time.Second),
+					Timeout:            durationp(10 * time.Second),
+				},
+			},
+		},
+		"error if the health check is a list": {
+			inContent:   []byte(`healthcheck: [/ping]`),
+			wantedError: errUnmarshalHealthCheckArgs,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			var r RoutingRule
+
+			// WHEN
+			err := yaml.Unmarshal(tc.inContent, &r)
+
+			// THEN
+			if tc.wantedError != nil {
+				require.EqualError(t, err, tc.wantedError.Error())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.wantedStruct, r.HealthCheck)
+		})
+	}
+}
+
+func TestRoutingRule_applyOverride(t *testing.T) {
+	testCases := map[string]struct {
+		inRule  RoutingRule
+		inOther RoutingRule
+
+		wanted RoutingRule
+	}{
+		"overrides the health check path": {
+			inRule: RoutingRule{
+				Path: "/",
+				HealthCheck: HealthCheckArgsOrString{
+					HealthCheckPath: stringp("/"),
+				},
+			},
+			inOther: RoutingRule{
+				HealthCheck: HealthCheckArgsOrString{
+					HealthCheckPath: stringp("/ping"),
+				},
+			},
+			wanted: RoutingRule{
+				Path: "/",
+				HealthCheck: HealthCheckArgsOrString{
+					HealthCheckPath: stringp("/ping"),
+				},
+			},
+		},
+		"overrides the alias": {
+			inRule: RoutingRule{
+				Path:  "/",
+				Alias: stringp("api.phonetool.example.com"),
+			},
+			inOther: RoutingRule{
+				Alias: stringp("test.phonetool.example.com"),
+			},
+			wanted: RoutingRule{
+				Path:  "/",
+				Alias: stringp("test.phonetool.example.com"),
+			},
+		},
+		"overrides the health check and target group settings field by field": {
+			inRule: RoutingRule{
+				Path: "/",
+				HealthCheck: HealthCheckArgsOrString{
+					HealthCheckPath: stringp("/ping"),
+				},
+				Stickiness: boolp(true),
+			},
+			inOther: RoutingRule{
+				HealthCheck: HealthCheckArgsOrString{
+					HealthCheckArgs: HTTPHealthCheckArgs{
+						HealthyThreshold: intp(3),
+					},
+				},
+				DeregistrationDelay: durationp(30 * time.Second),
+				ProtocolVersion:     stringp("HTTP2"),
+			},
+			wanted: RoutingRule{
+				Path: "/",
+				HealthCheck: HealthCheckArgsOrString{
+					HealthCheckArgs: HTTPHealthCheckArgs{
+						Path:             stringp("/ping"),
+						HealthyThreshold: intp(3),
+					},
+				},
+				DeregistrationDelay: durationp(30 * time.Second),
+				Stickiness:          boolp(true),
+				ProtocolVersion:     stringp("HTTP2"),
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// WHEN
+			got := applyOverride(tc.inRule, tc.inOther)
+
+			// THEN
+			require.Equal(t, tc.wanted, got)
+		})
+	}
+}
@@ -0,0 +1,51 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package manifest
+
+// Logging holds the configuration to route the logs of the main container with a FireLens sidecar.
+// See https://docs.aws.amazon.com/AmazonECS/latest/developerguide/using_firelens.html
+type Logging struct {
+	Image          *string        `yaml:"image"`           // Defaults to "amazon/aws-for-fluent-bit:latest".
+	Destination    LogDestination `yaml:"destination"`     // Where to send the logs, mutually exclusive destinations.
+	EnableMetadata *bool          `yaml:"enable_metadata"` // Whether to add the ECS metadata to the logs, defaults to true.
+	ConfigFile     *string        `yaml:"config_file"`     // Path to a custom Fluent Bit configuration file in the image.
+}
+
+// IsEmpty returns whether Logging is empty.
+func (l Logging) IsEmpty() bool {
+	return l.Image == nil && l.Destination.IsEmpty() && l.EnableMetadata == nil && l.ConfigFile == nil
+}
+
+// LogDestination holds the options of the Fluent Bit output plugin that receives the logs.
+type LogDestination struct {
+	Firehose      *FirehoseDestination      `yaml:"firehose"`
+	CloudWatch    *CloudWatchDestination    `yaml:"cloudwatch"`
+	Elasticsearch *ElasticsearchDestination `yaml:"elasticsearch"`
+}
+
+// IsEmpty returns whether LogDestination is empty.
+func (d LogDestination) IsEmpty() bool {
+	return d.Firehose == nil && d.CloudWatch == nil && d.Elasticsearch == nil
+}
+
+// FirehoseDestination sends the logs to a Kinesis Data Firehose delivery stream.
+type FirehoseDestination struct {
+	DeliveryStream *string `yaml:"delivery_stream"`
+	Region         *string `yaml:"region"` // Defaults to the region of the environment.
+}
+
+// CloudWatchDestination sends the logs to a CloudWatch log group other than the service's.
+type CloudWatchDestination struct {
+	LogGroup     *string `yaml:"log_group"`
+	StreamPrefix *string `yaml:"stream_prefix"`
+	Region       *string `yaml:"region"` // Defaults to the region of the environment.
+}
+
+// ElasticsearchDestination sends the logs to an Amazon Elasticsearch Service (OpenSearch) domain.
+type ElasticsearchDestination struct {
+	Host   *string `yaml:"host"` // Endpoint of the domain without the scheme.
+	Port   *int    `yaml:"port"` // Defaults to 443.
+	Index  *string `yaml:"index"`
+	Region *string `yaml:"region"` // Defaults to the region of the environment.
+}
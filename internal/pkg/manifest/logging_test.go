@@ -0,0 +1,73 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package manifest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogging_applyOverride(t *testing.T) {
+	testCases := map[string]struct {
+		inLogging Logging
+		inOther   Logging
+
+		wanted Logging
+	}{
+		"no overrides": {
+			inLogging: Logging{
+				Destination: LogDestination{
+					Firehose: &FirehoseDestination{
+						DeliveryStream: stringp("my-stream"),
+					},
+				},
+			},
+			wanted: Logging{
+				Destination: LogDestination{
+					Firehose: &FirehoseDestination{
+						DeliveryStream: stringp("my-stream"),
+					},
+				},
+			},
+		},
+		"overrides fields and replaces the destination": {
+			inLogging: Logging{
+				Image: stringp("amazon/aws-for-fluent-bit:latest"),
+				Destination: LogDestination{
+					Firehose: &FirehoseDestination{
+						DeliveryStream: stringp("my-stream"),
+					},
+				},
+			},
+			inOther: Logging{
+				EnableMetadata: boolp(false),
+				Destination: LogDestination{
+					CloudWatch: &CloudWatchDestination{
+						LogGroup: stringp("my-group"),
+					},
+				},
+			},
+			wanted: Logging{
+				Image:          stringp("amazon/aws-for-fluent-bit:latest"),
+				EnableMetadata: boolp(false),
+				Destination: LogDestination{
+					CloudWatch: &CloudWatchDestination{
+						LogGroup: stringp("my-group"),
+					},
+				},
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// WHEN
+			got := applyOverride(tc.inLogging, tc.inOther)
+
+			// THEN
+			require.Equal(t, tc.wanted, got)
+		})
+	}
+}
@@ -0,0 +1,41 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package manifest
+
+// Subnets of the environment that the tasks of a service can be placed in.
+const (
+	PublicSubnetPlacement  = "public"
+	PrivateSubnetPlacement = "private"
+)
+
+var subnetPlacements = []string{PublicSubnetPlacement, PrivateSubnetPlacement}
+
+// NetworkConfig holds the configuration of the network interfaces of the tasks of the service.
+type NetworkConfig struct {
+	VPC VPCConfig `yaml:"vpc"`
+}
+
+// VPCConfig holds the configuration of the tasks within the VPC of the environment.
+type VPCConfig struct {
+	// Subnets to place the tasks in, defaults to "public".
+	// Tasks in private subnets don't get a public IP and reach the internet through the NAT gateways of the environment,
+	// which are only created by "env init --nat-gateways" unless the environment imports its VPC.
+	Placement *string `yaml:"placement"`
+	// IDs of existing security groups to attach to the tasks in addition to the ones created by Copilot.
+	SecurityGroups []string `yaml:"security_groups"`
+	// Traffic allowed into the security group of the service.
+	Ingress VPCIngress `yaml:"ingress"`
+}
+
+// VPCIngress holds the sources of the traffic allowed into the tasks of the service.
+// If set, the tasks leave the security group shared by the environment, so only the load balancer
+// and the listed services can reach them.
+type VPCIngress struct {
+	Services []string `yaml:"services"` // Names of other services in the environment.
+}
+
+// IsEmpty returns whether NetworkConfig is empty.
+func (n NetworkConfig) IsEmpty() bool {
+	return n.VPC.Placement == nil && n.VPC.SecurityGroups == nil && n.VPC.Ingress.Services == nil
+}
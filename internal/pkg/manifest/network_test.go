@@ -0,0 +1,84 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package manifest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNetworkConfig_applyOverride(t *testing.T) {
+	testCases := map[string]struct {
+		inNetwork NetworkConfig
+		inOther   NetworkConfig
+
+		wanted NetworkConfig
+	}{
+		"no overrides": {
+			inNetwork: NetworkConfig{
+				VPC: VPCConfig{
+					Placement: stringp(PrivateSubnetPlacement),
+				},
+			},
+			wanted: NetworkConfig{
+				VPC: VPCConfig{
+					Placement: stringp(PrivateSubnetPlacement),
+				},
+			},
+		},
+		"overrides the placement": {
+			inNetwork: NetworkConfig{
+				VPC: VPCConfig{
+					Placement: stringp(PrivateSubnetPlacement),
+				},
+			},
+			inOther: NetworkConfig{
+				VPC: VPCConfig{
+					Placement: stringp(PublicSubnetPlacement),
+				},
+			},
+			wanted: NetworkConfig{
+				VPC: VPCConfig{
+					Placement: stringp(PublicSubnetPlacement),
+				},
+			},
+		},
+		"replaces the security groups and the services allowed to reach the tasks": {
+			inNetwork: NetworkConfig{
+				VPC: VPCConfig{
+					Placement:      stringp(PrivateSubnetPlacement),
+					SecurityGroups: []string{"sg-1234", "sg-5678"},
+					Ingress: VPCIngress{
+						Services: []string{"frontend"},
+					},
+				},
+			},
+			inOther: NetworkConfig{
+				VPC: VPCConfig{
+					SecurityGroups: []string{"sg-abcd"},
+				},
+			},
+			wanted: NetworkConfig{
+				VPC: VPCConfig{
+					Placement:      stringp(PrivateSubnetPlacement),
+					SecurityGroups: []string{"sg-abcd"},
+					Ingress: VPCIngress{
+						Services: []string{"frontend"},
+					},
+				},
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// WHEN
+			got := applyOverride(tc.inNetwork, tc.inOther)
+
+			// THEN
+			require.Equal(t, tc.wanted, got)
+		})
+	}
+}
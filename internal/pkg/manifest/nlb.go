@@ -0,0 +1,76 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package manifest
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Protocols of the listeners of a network load balancer.
+const (
+	TCPProtocol    = "tcp"
+	UDPProtocol    = "udp"
+	TCPUDPProtocol = "tcp_udp" // Accepts both TCP and UDP traffic on the same port.
+	TLSProtocol    = "tls"     // The load balancer terminates the TLS connections and forwards TCP traffic to the targets.
+)
+
+var nlbProtocols = []string{TCPProtocol, UDPProtocol, TCPUDPProtocol, TLSProtocol}
+
+// NetworkLoadBalancerConfiguration holds the configuration of a network load balancer dedicated to the service,
+// which exposes ports of its containers to the internet over TCP, UDP or TLS.
+// See https://docs.aws.amazon.com/elasticloadbalancing/latest/network/introduction.html
+type NetworkLoadBalancerConfiguration struct {
+	Listeners []NetworkLoadBalancerListener `yaml:"listeners"`
+	Alias     *string                       `yaml:"alias"` // Domain name in the application's hosted zone that resolves to the load balancer.
+}
+
+// IsEmpty returns whether NetworkLoadBalancerConfiguration is empty.
+func (c NetworkLoadBalancerConfiguration) IsEmpty() bool {
+	return len(c.Listeners) == 0 && c.Alias == nil
+}
+
+// NetworkLoadBalancerListener holds the configuration of a listener of the network load balancer and of its target group.
+type NetworkLoadBalancerListener struct {
+	Port            string             `yaml:"port"`             // Port and protocol of the listener, for example "1883/tcp".
+	TargetContainer *string            `yaml:"target_container"` // Defaults to the main container.
+	TargetPort      *uint16            `yaml:"target_port"`      // Defaults to the port of the listener.
+	Certificate     *string            `yaml:"certificate"`      // ARN of the ACM certificate of a TLS listener.
+	HealthCheck     NLBHealthCheckArgs `yaml:"healthcheck"`
+}
+
+// NLBHealthCheckArgs holds the configuration of the TCP health check of the listener's target group.
+// See https://docs.aws.amazon.com/elasticloadbalancing/latest/network/target-group-health-checks.html
+type NLBHealthCheckArgs struct {
+	Port               *uint16        `yaml:"port"` // TCP port, defaults to the target port and required by UDP listeners.
+	HealthyThreshold   *int           `yaml:"healthy_threshold"`
+	UnhealthyThreshold *int           `yaml:"unhealthy_threshold"`
+	Interval           *time.Duration `yaml:"interval"` // Either 10s or 30s.
+}
+
+// PortAndProtocol returns the port and the protocol of the listener.
+// The protocol is one of "tcp", "udp", "tcp_udp" or "tls".
+func (l NetworkLoadBalancerListener) PortAndProtocol() (uint16, string, error) {
+	parts := strings.Split(l.Port, "/")
+	if len(parts) != 2 || !contains(nlbProtocols, parts[1]) {
+		return 0, "", fmt.Errorf(`listener port %q must be a port followed by one of the protocols %s, such as "1883/tcp"`,
+			l.Port, strings.Join(nlbProtocols, ", "))
+	}
+	port, err := strconv.ParseUint(parts[0], 10, 16)
+	if err != nil || port == 0 {
+		return 0, "", fmt.Errorf("listener port %q must be a port between 1 and 65535", l.Port)
+	}
+	return uint16(port), parts[1], nil
+}
+
+// TargetPortOrDefault returns the port of the container that receives the traffic of the listener.
+func (l NetworkLoadBalancerListener) TargetPortOrDefault() (uint16, error) {
+	if l.TargetPort != nil {
+		return *l.TargetPort, nil
+	}
+	port, _, err := l.PortAndProtocol()
+	return port, err
+}
@@ -0,0 +1,92 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package manifest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNetworkLoadBalancerListener_PortAndProtocol(t *testing.T) {
+	testCases := map[string]struct {
+		in NetworkLoadBalancerListener
+
+		wantedPort       uint16
+		wantedProtocol   string
+		wantedTargetPort uint16
+		wantedErr        error
+	}{
+		"target port defaults to the port of the listener": {
+			in:               NetworkLoadBalancerListener{Port: "1883/tcp"},
+			wantedPort:       1883,
+			wantedProtocol:   TCPProtocol,
+			wantedTargetPort: 1883,
+		},
+		"TLS listener with a target port": {
+			in: NetworkLoadBalancerListener{
+				Port:       "8883/tls",
+				TargetPort: uint16p(1883),
+			},
+			wantedPort:       8883,
+			wantedProtocol:   TLSProtocol,
+			wantedTargetPort: 1883,
+		},
+		"error if the protocol is missing": {
+			in:        NetworkLoadBalancerListener{Port: "53"},
+			wantedErr: errors.New(`listener port "53" must be a port followed by one of the protocols tcp, udp, tcp_udp, tls, such as "1883/tcp"`),
+		},
+		"error if the port is out of range": {
+			in:        NetworkLoadBalancerListener{Port: "0/udp"},
+			wantedErr: errors.New(`listener port "0/udp" must be a port between 1 and 65535`),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// WHEN
+			port, protocol, err := tc.in.PortAndProtocol()
+			targetPort, targetErr := tc.in.TargetPortOrDefault()
+
+			// THEN
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+				require.EqualError(t, targetErr, tc.wantedErr.Error())
+				return
+			}
+			require.NoError(t, err)
+			require.NoError(t, targetErr)
+			require.Equal(t, tc.wantedPort, port)
+			require.Equal(t, tc.wantedProtocol, protocol)
+			require.Equal(t, tc.wantedTargetPort, targetPort)
+		})
+	}
+}
+
+func TestNetworkLoadBalancerConfiguration_applyOverride(t *testing.T) {
+	// GIVEN
+	in := NetworkLoadBalancerConfiguration{
+		Listeners: []NetworkLoadBalancerListener{
+			{Port: "1883/tcp"},
+		},
+		Alias: stringp("mqtt.phonetool.example.com"),
+	}
+	other := NetworkLoadBalancerConfiguration{
+		Listeners: []NetworkLoadBalancerListener{
+			{Port: "8883/tls", TargetPort: uint16p(1883), Certificate: stringp("arn:aws:acm:us-west-2:123456789012:certificate/abc")},
+		},
+	}
+
+	// WHEN
+	got := applyOverride(in, other)
+
+	// THEN
+	require.Equal(t, NetworkLoadBalancerConfiguration{
+		Listeners: []NetworkLoadBalancerListener{
+			{Port: "8883/tls", TargetPort: uint16p(1883), Certificate: stringp("arn:aws:acm:us-west-2:123456789012:certificate/abc")},
+		},
+		Alias: stringp("mqtt.phonetool.example.com"),
+	}, got)
+}
@@ -0,0 +1,326 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package manifest
+
+import (
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	overrideTag    = "override"
+	appendOverride = "append" // Lists tagged `override:"append"` get the items of the override appended instead of replaced.
+)
+
+// exclusiveFields are the structs whose fields are mutually exclusive in addition to the union types:
+// setting one of the fields in an override unsets the others.
+var exclusiveFields = map[reflect.Type]bool{
+	reflect.TypeOf(ServiceImage{}):   true, // "build" and "location".
+	reflect.TypeOf(LogDestination{}): true,
+}
+
+// applyEnvOverride sets out to a deep copy of the manifest without its environment overrides, and applies
+// the environment override on top of it. The fields of the override are matched with the fields of the
+// manifest that have the same name. The paths of the fields explicitly set to null in the override are unset.
+//
+// The fields are overridden according to the following rules:
+//   - Structs are merged field by field, and maps key by key.
+//   - Scalars replace the value of the manifest if they're set.
+//   - Lists replace the list of the manifest, unless they're tagged `override:"append"` in which case
+//     the items that aren't in the list yet are appended to it.
+//   - The forms of union types, such as "count", are mutually exclusive: overriding with one form
+//     unsets the other, whereas overriding with the same form merges them.
+//   - A field set to null unsets the field, and a key of a map set to null removes the key.
+func applyEnvOverride(out, manifest, override interface{}, nulls [][]string) {
+	dst := reflect.ValueOf(out).Elem()
+	dst.Set(deepcopy(reflect.ValueOf(manifest).Elem()))
+	dst.FieldByName("Environments").Set(reflect.Zero(dst.FieldByName("Environments").Type()))
+
+	src := reflect.ValueOf(override)
+	for i := 0; i < src.NumField(); i++ {
+		field := src.Type().Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+		mergeOverride(dst.FieldByName(field.Name), src.Field(i), field.Tag)
+	}
+	for _, path := range nulls {
+		unsetField(dst, path)
+	}
+}
+
+// applyOverride returns a deep copy of base with the override of the same type applied on top of it.
+func applyOverride(base, override interface{}) interface{} {
+	out := deepcopy(reflect.ValueOf(base))
+	mergeOverride(out, reflect.ValueOf(override), "")
+	return out.Interface()
+}
+
+// mergeOverride applies the fields set in src to dst, which must be settable and not shared with src.
+func mergeOverride(dst, src reflect.Value, tag reflect.StructTag) {
+	if _, ok := unionTypes[src.Type()]; ok {
+		mergeUnion(dst, src)
+		return
+	}
+	switch src.Kind() {
+	case reflect.Struct:
+		exclusive := exclusiveFields[src.Type()]
+		for i := 0; i < src.NumField(); i++ {
+			field := src.Type().Field(i)
+			if field.PkgPath != "" {
+				continue
+			}
+			if exclusive && !src.Field(i).IsZero() {
+				unsetOtherFields(dst, i)
+			}
+			mergeOverride(dst.Field(i), src.Field(i), field.Tag)
+		}
+	case reflect.Ptr:
+		if src.IsNil() {
+			return
+		}
+		if dst.IsNil() || src.Elem().Kind() != reflect.Struct {
+			dst.Set(deepcopy(src))
+			return
+		}
+		mergeOverride(dst.Elem(), src.Elem(), tag)
+	case reflect.Map:
+		if src.IsNil() {
+			return
+		}
+		if dst.IsNil() {
+			dst.Set(reflect.MakeMapWithSize(src.Type(), src.Len()))
+		}
+		iter := src.MapRange()
+		for iter.Next() {
+			elem := reflect.New(src.Type().Elem()).Elem()
+			existing := dst.MapIndex(iter.Key())
+			switch kind := elem.Kind(); {
+			case existing.IsValid() && (kind == reflect.Struct || kind == reflect.Ptr || kind == reflect.Map):
+				elem.Set(existing)
+				mergeOverride(elem, iter.Value(), "")
+			default:
+				// The key is set explicitly, so even a zero value replaces the value of the manifest.
+				elem.Set(deepcopy(iter.Value()))
+			}
+			dst.SetMapIndex(iter.Key(), elem)
+		}
+	case reflect.Slice:
+		if src.IsNil() {
+			return
+		}
+		if tag.Get(overrideTag) != appendOverride {
+			dst.Set(deepcopy(src))
+			return
+		}
+		for i := 0; i < src.Len(); i++ {
+			if !containsValue(dst, src.Index(i)) {
+				dst.Set(reflect.Append(dst, deepcopy(src.Index(i))))
+			}
+		}
+	default:
+		if !src.IsZero() {
+			dst.Set(src)
+		}
+	}
+}
+
+// mergeUnion applies the form of the union type set in src to dst, and unsets the other forms of dst.
+func mergeUnion(dst, src reflect.Value) {
+	if hc, ok := src.Interface().(HealthCheckArgsOrString); ok && !hc.IsEmpty() {
+		// The path form is a shorthand for the configuration of the path, so the health checks are merged
+		// field by field whatever their forms.
+		base := dst.Interface().(HealthCheckArgsOrString)
+		if hc.HealthCheckPath != nil && base.HealthCheckArgs.IsEmpty() {
+			dst.Set(deepcopy(src))
+			return
+		}
+		args := reflect.New(reflect.TypeOf(HTTPHealthCheckArgs{})).Elem()
+		args.Set(reflect.ValueOf(base.args()))
+		mergeOverride(args, reflect.ValueOf(hc.args()), "")
+		dst.Set(reflect.ValueOf(HealthCheckArgsOrString{
+			HealthCheckArgs: args.Interface().(HTTPHealthCheckArgs),
+		}))
+		return
+	}
+	for i := 0; i < src.NumField(); i++ {
+		if src.Field(i).IsZero() {
+			continue
+		}
+		unsetOtherFields(dst, i)
+		mergeOverride(dst.Field(i), src.Field(i), src.Type().Field(i).Tag)
+		return
+	}
+}
+
+// unsetOtherFields sets every field of the struct but the i-th one to its zero value.
+func unsetOtherFields(v reflect.Value, i int) {
+	for j := 0; j < v.NumField(); j++ {
+		if j != i && v.Type().Field(j).PkgPath == "" {
+			v.Field(j).Set(reflect.Zero(v.Field(j).Type()))
+		}
+	}
+}
+
+// unsetField sets the field at the path of YAML keys to its zero value, or removes the key from its map.
+func unsetField(v reflect.Value, path []string) {
+	if len(path) == 0 {
+		return
+	}
+	switch v.Kind() {
+	case reflect.Ptr:
+		if !v.IsNil() {
+			unsetField(v.Elem(), path)
+		}
+	case reflect.Map:
+		key := reflect.ValueOf(path[0]).Convert(v.Type().Key())
+		elem := v.MapIndex(key)
+		if !elem.IsValid() {
+			return
+		}
+		if len(path) == 1 {
+			v.SetMapIndex(key, reflect.Value{})
+			return
+		}
+		copied := reflect.New(elem.Type()).Elem()
+		copied.Set(elem)
+		unsetField(copied, path[1:])
+		v.SetMapIndex(key, copied)
+	case reflect.Struct:
+		if union, ok := unionTypes[v.Type()]; ok {
+			// The keys under a union type belong to its map form.
+			for i := 0; i < v.NumField(); i++ {
+				if v.Field(i).Type() == union.mapping {
+					unsetField(v.Field(i), path)
+				}
+			}
+			return
+		}
+		field, ok := fieldByYAMLKey(v, path[0])
+		if !ok {
+			return
+		}
+		if len(path) == 1 {
+			field.Set(reflect.Zero(field.Type()))
+			return
+		}
+		unsetField(field, path[1:])
+	}
+}
+
+// fieldByYAMLKey returns the field of the struct decoded from the YAML key, following the yaml.v3 conventions.
+func fieldByYAMLKey(v reflect.Value, key string) (reflect.Value, bool) {
+	for i := 0; i < v.NumField(); i++ {
+		f := v.Type().Field(i)
+		if f.PkgPath != "" && !f.Anonymous {
+			continue
+		}
+		opts := strings.Split(f.Tag.Get("yaml"), ",")
+		name, flags := opts[0], opts[1:]
+		if contains(flags, "inline") {
+			if field, ok := fieldByYAMLKey(v.Field(i), key); ok {
+				return field, true
+			}
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(f.Name)
+		}
+		if name == key {
+			return v.Field(i), true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// environmentNulls returns the paths of the fields explicitly set to null in each environment override of the
+// manifest. Empty values such as "image:" aren't considered explicit.
+func environmentNulls(doc *yaml.Node) map[string][][]string {
+	envs := mappingValue(doc.Content[0], environmentsKey)
+	if envs == nil || envs.Kind != yaml.MappingNode {
+		return nil
+	}
+	var nulls map[string][][]string
+	for i := 0; i < len(envs.Content)-1; i += 2 {
+		paths := nullPaths(envs.Content[i+1], nil)
+		if len(paths) == 0 {
+			continue
+		}
+		if nulls == nil {
+			nulls = make(map[string][][]string)
+		}
+		nulls[envs.Content[i].Value] = paths
+	}
+	return nulls
+}
+
+func nullPaths(node *yaml.Node, path []string) [][]string {
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+	var paths [][]string
+	for i := 0; i < len(node.Content)-1; i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		keyPath := append(append([]string{}, path...), key.Value)
+		if value.Kind == yaml.ScalarNode && value.ShortTag() == "!!null" && value.Value != "" {
+			paths = append(paths, keyPath)
+			continue
+		}
+		paths = append(paths, nullPaths(value, keyPath)...)
+	}
+	return paths
+}
+
+// deepcopy returns a copy of v that shares no pointers, maps or slices with it.
+// Unexported fields, such as the template parser of a manifest, aren't copied.
+func deepcopy(v reflect.Value) reflect.Value {
+	out := reflect.New(v.Type()).Elem()
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return out
+		}
+		p := reflect.New(v.Type().Elem())
+		p.Elem().Set(deepcopy(v.Elem()))
+		out.Set(p)
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).PkgPath != "" {
+				continue
+			}
+			out.Field(i).Set(deepcopy(v.Field(i)))
+		}
+	case reflect.Map:
+		if v.IsNil() {
+			return out
+		}
+		out.Set(reflect.MakeMapWithSize(v.Type(), v.Len()))
+		iter := v.MapRange()
+		for iter.Next() {
+			out.SetMapIndex(iter.Key(), deepcopy(iter.Value()))
+		}
+	case reflect.Slice:
+		if v.IsNil() {
+			return out
+		}
+		out.Set(reflect.MakeSlice(v.Type(), v.Len(), v.Len()))
+		for i := 0; i < v.Len(); i++ {
+			out.Index(i).Set(deepcopy(v.Index(i)))
+		}
+	default:
+		out.Set(v)
+	}
+	return out
+}
+
+func containsValue(list, v reflect.Value) bool {
+	for i := 0; i < list.Len(); i++ {
+		if reflect.DeepEqual(list.Index(i).Interface(), v.Interface()) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,648 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package manifest
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	lbWebSvcHeader = `name: frontend
+type: Load Balanced Web Service
+`
+	backendSvcHeader = `name: api
+type: Backend Service
+`
+	workerSvcHeader = `name: processor
+type: Worker Service
+`
+	scheduledJobHeader = `name: report
+type: Scheduled Job
+`
+)
+
+func TestApplyEnv_Overrides(t *testing.T) {
+	testCases := map[string]struct {
+		inHeader   string
+		inManifest string
+		inOverride string // Fields under "environments.test".
+
+		wantedManifest string // The manifest without environments that the override results in.
+	}{
+		"overriding the count keeps the image and the log retention": {
+			inHeader: lbWebSvcHeader,
+			inManifest: `image:
+  build: frontend/Dockerfile
+  port: 80
+http:
+  path: '/'
+`,
+			inOverride: `count: 2`,
+			wantedManifest: `image:
+  build: frontend/Dockerfile
+  port: 80
+http:
+  path: '/'
+count: 2
+`,
+		},
+		"image.location replaces image.build": {
+			inHeader: lbWebSvcHeader,
+			inManifest: `image:
+  build: frontend/Dockerfile
+  port: 80
+http:
+  path: '/'
+`,
+			inOverride: `image:
+  location: nginx:latest`,
+			wantedManifest: `image:
+  location: nginx:latest
+  port: 80
+http:
+  path: '/'
+`,
+		},
+		"image.build is merged field by field": {
+			inHeader: lbWebSvcHeader,
+			inManifest: `image:
+  build:
+    dockerfile: frontend/Dockerfile
+    args:
+      GO_VERSION: "1.14"
+    cache_from: [frontend:latest]
+  port: 80
+http:
+  path: '/'
+`,
+			inOverride: `image:
+  build:
+    args:
+      STAGE: test
+    cache_from: [frontend:test]`,
+			wantedManifest: `image:
+  build:
+    dockerfile: frontend/Dockerfile
+    args:
+      GO_VERSION: "1.14"
+      STAGE: test
+    cache_from: [frontend:test]
+  port: 80
+http:
+  path: '/'
+`,
+		},
+		"image.build string replaces the build map": {
+			inHeader: lbWebSvcHeader,
+			inManifest: `image:
+  build:
+    dockerfile: frontend/Dockerfile
+    target: prod
+  port: 80
+http:
+  path: '/'
+`,
+			inOverride: `image:
+  build: frontend/Dockerfile.test
+  port: 8080`,
+			wantedManifest: `image:
+  build: frontend/Dockerfile.test
+  port: 8080
+http:
+  path: '/'
+`,
+		},
+		"http fields are overridden field by field": {
+			inHeader: lbWebSvcHeader,
+			inManifest: `image:
+  build: frontend/Dockerfile
+  port: 80
+http:
+  path: '/'
+  alias: frontend.example.com
+  stickiness: true
+`,
+			inOverride: `http:
+  path: 'app'
+  alias: test.example.com
+  deregistration_delay: 30s
+  protocol_version: HTTP2`,
+			wantedManifest: `image:
+  build: frontend/Dockerfile
+  port: 80
+http:
+  path: 'app'
+  alias: test.example.com
+  stickiness: true
+  deregistration_delay: 30s
+  protocol_version: HTTP2
+`,
+		},
+		"http.healthcheck path keeps the thresholds of the health check": {
+			inHeader: lbWebSvcHeader,
+			inManifest: `image:
+  build: frontend/Dockerfile
+  port: 80
+http:
+  path: '/'
+  healthcheck:
+    path: /ping
+    healthy_threshold: 3
+`,
+			inOverride: `http:
+  healthcheck: /healthz`,
+			wantedManifest: `image:
+  build: frontend/Dockerfile
+  port: 80
+http:
+  path: '/'
+  healthcheck:
+    path: /healthz
+    healthy_threshold: 3
+`,
+		},
+		"task size is overridden": {
+			inHeader: lbWebSvcHeader,
+			inManifest: `image:
+  build: frontend/Dockerfile
+  port: 80
+http:
+  path: '/'
+cpu: 256
+memory: 512
+`,
+			inOverride: `cpu: 1024
+memory: 2048`,
+			wantedManifest: `image:
+  build: frontend/Dockerfile
+  port: 80
+http:
+  path: '/'
+cpu: 1024
+memory: 2048
+`,
+		},
+		"count.range replaces the count and autoscaling is merged field by field": {
+			inHeader: backendSvcHeader,
+			inManifest: `image:
+  build: api/Dockerfile
+count:
+  range: 1-10
+  cpu_percentage: 70
+`,
+			inOverride: `count:
+  range: 2-20
+  memory_percentage: 80`,
+			wantedManifest: `image:
+  build: api/Dockerfile
+count:
+  range: 2-20
+  cpu_percentage: 70
+  memory_percentage: 80
+`,
+		},
+		"count replaces autoscaling": {
+			inHeader: backendSvcHeader,
+			inManifest: `image:
+  build: api/Dockerfile
+count:
+  range: 1-10
+`,
+			inOverride: `count: 0`,
+			wantedManifest: `image:
+  build: api/Dockerfile
+count: 0
+`,
+		},
+		"variables and secrets are merged key by key and null keys are removed": {
+			inHeader: backendSvcHeader,
+			inManifest: `image:
+  build: api/Dockerfile
+variables:
+  LOG_LEVEL: info
+  DEBUG_PORT: "9000"
+secrets:
+  DB_PASSWORD: /db/password
+`,
+			inOverride: `variables:
+  LOG_LEVEL: debug
+  DEBUG_PORT: null
+secrets:
+  API_KEY: /api/key`,
+			wantedManifest: `image:
+  build: api/Dockerfile
+variables:
+  LOG_LEVEL: debug
+secrets:
+  DB_PASSWORD: /db/password
+  API_KEY: /api/key
+`,
+		},
+		"storage volumes are merged by name": {
+			inHeader: backendSvcHeader,
+			inManifest: `image:
+  build: api/Dockerfile
+storage:
+  volumes:
+    data:
+      efs:
+        id: fs-1234
+        root_dir: /data
+      path: /var/data
+`,
+			inOverride: `storage:
+  volumes:
+    data:
+      efs:
+        id: fs-5678
+      read_only: false
+    scratch:
+      efs: true
+      path: /var/scratch`,
+			wantedManifest: `image:
+  build: api/Dockerfile
+storage:
+  volumes:
+    data:
+      efs:
+        id: fs-5678
+        root_dir: /data
+      path: /var/data
+      read_only: false
+    scratch:
+      efs: true
+      path: /var/scratch
+`,
+		},
+		"sidecars are merged by name, their lists are replaced, and null sidecars are removed": {
+			inHeader: backendSvcHeader,
+			inManifest: `image:
+  build: api/Dockerfile
+sidecars:
+  nginx:
+    image: nginx
+    port: "80"
+    command: [nginx, -g, daemon off;]
+    variables:
+      WORKERS: "2"
+  xray:
+    image: amazon/aws-xray-daemon
+`,
+			inOverride: `sidecars:
+  nginx:
+    command: nginx
+    variables:
+      WORKERS: "4"
+  xray: null`,
+			wantedManifest: `image:
+  build: api/Dockerfile
+sidecars:
+  nginx:
+    image: nginx
+    port: "80"
+    command: nginx
+    variables:
+      WORKERS: "4"
+`,
+		},
+		"command and entrypoint replace the ones of the manifest whatever their forms": {
+			inHeader: workerSvcHeader,
+			inManifest: `image:
+  build: processor/Dockerfile
+entrypoint: [/bin/sh, -c]
+command: [./process, --verbose]
+`,
+			inOverride: `entrypoint: /app/entrypoint.sh
+command: [./process, --dry-run]`,
+			wantedManifest: `image:
+  build: processor/Dockerfile
+entrypoint: /app/entrypoint.sh
+command: [./process, --dry-run]
+`,
+		},
+		"image.healthcheck is merged field by field": {
+			inHeader: backendSvcHeader,
+			inManifest: `image:
+  build: api/Dockerfile
+  port: 8080
+  healthcheck:
+    command: [CMD, /bin/check]
+    retries: 5
+`,
+			inOverride: `image:
+  healthcheck:
+    interval: 20s`,
+			wantedManifest: `image:
+  build: api/Dockerfile
+  port: 8080
+  healthcheck:
+    command: [CMD, /bin/check]
+    retries: 5
+    interval: 20s
+`,
+		},
+		"logging fields are overridden and the destination is replaced": {
+			inHeader: backendSvcHeader,
+			inManifest: `image:
+  build: api/Dockerfile
+logging:
+  image: amazon/aws-for-fluent-bit:2.10.0
+  destination:
+    firehose:
+      delivery_stream: api-logs
+`,
+			inOverride: `logging:
+  enable_metadata: false
+  destination:
+    cloudwatch:
+      log_group: api-test`,
+			wantedManifest: `image:
+  build: api/Dockerfile
+logging:
+  image: amazon/aws-for-fluent-bit:2.10.0
+  enable_metadata: false
+  destination:
+    cloudwatch:
+      log_group: api-test
+`,
+		},
+		"platform capacity providers are replaced": {
+			inHeader: backendSvcHeader,
+			inManifest: `image:
+  build: api/Dockerfile
+platform:
+  capacity_providers:
+    - name: FARGATE
+      base: 1
+    - name: FARGATE_SPOT
+      weight: 2
+`,
+			inOverride: `platform:
+  capacity_providers:
+    - name: FARGATE_SPOT`,
+			wantedManifest: `image:
+  build: api/Dockerfile
+platform:
+  capacity_providers:
+    - name: FARGATE_SPOT
+`,
+		},
+		"network placement is overridden and security groups are replaced": {
+			inHeader: backendSvcHeader,
+			inManifest: `image:
+  build: api/Dockerfile
+network:
+  vpc:
+    placement: private
+    security_groups: [sg-1234, sg-5678]
+    ingress:
+      services: [frontend]
+`,
+			inOverride: `network:
+  vpc:
+    placement: public
+    security_groups: [sg-abcd]`,
+			wantedManifest: `image:
+  build: api/Dockerfile
+network:
+  vpc:
+    placement: public
+    security_groups: [sg-abcd]
+    ingress:
+      services: [frontend]
+`,
+		},
+		"deployment fields are overridden and alarms are appended": {
+			inHeader: lbWebSvcHeader,
+			inManifest: `image:
+  build: frontend/Dockerfile
+  port: 80
+http:
+  path: '/'
+deployment:
+  strategy: blue_green
+  blue_green:
+    traffic_shifting: canary
+    alarms: [frontend-5xx]
+`,
+			inOverride: `deployment:
+  blue_green:
+    interval: 10m
+    alarms: [frontend-5xx, frontend-latency]`,
+			wantedManifest: `image:
+  build: frontend/Dockerfile
+  port: 80
+http:
+  path: '/'
+deployment:
+  strategy: blue_green
+  blue_green:
+    traffic_shifting: canary
+    interval: 10m
+    alarms: [frontend-5xx, frontend-latency]
+`,
+		},
+		"null unsets a field": {
+			inHeader: lbWebSvcHeader,
+			inManifest: `image:
+  build: frontend/Dockerfile
+  port: 80
+http:
+  path: '/'
+  alias: frontend.example.com
+deployment:
+  circuit_breaker:
+    enable: true
+    rollback: true
+`,
+			inOverride: `http:
+  alias: null
+deployment:
+  circuit_breaker:
+    rollback: ~`,
+			wantedManifest: `image:
+  build: frontend/Dockerfile
+  port: 80
+http:
+  path: '/'
+deployment:
+  circuit_breaker:
+    enable: true
+`,
+		},
+		"null unsets a field of a union type": {
+			inHeader: backendSvcHeader,
+			inManifest: `image:
+  build: api/Dockerfile
+count:
+  range: 1-10
+  cpu_percentage: 70
+  memory_percentage: 80
+`,
+			inOverride: `count:
+  memory_percentage: null`,
+			wantedManifest: `image:
+  build: api/Dockerfile
+count:
+  range: 1-10
+  cpu_percentage: 70
+`,
+		},
+		"queue fields are overridden field by field": {
+			inHeader: workerSvcHeader,
+			inManifest: `image:
+  build: processor/Dockerfile
+queue:
+  retention: 96h
+  timeout: 30s
+  dead_letter:
+    tries: 5
+`,
+			inOverride: `queue:
+  delay: 10s
+  dead_letter:
+    tries: 10`,
+			wantedManifest: `image:
+  build: processor/Dockerfile
+queue:
+  retention: 96h
+  timeout: 30s
+  delay: 10s
+  dead_letter:
+    tries: 10
+`,
+		},
+		"schedule, timeout and retries are overridden": {
+			inHeader: scheduledJobHeader,
+			inManifest: `image:
+  build: report/Dockerfile
+on:
+  schedule: '@daily'
+timeout: 1h
+retries: 3
+`,
+			inOverride: `on:
+  schedule: '@hourly'
+retries: 1
+variables:
+  STAGE: test`,
+			wantedManifest: `image:
+  build: report/Dockerfile
+on:
+  schedule: '@hourly'
+timeout: 1h
+retries: 1
+variables:
+  STAGE: test
+`,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			in := tc.inHeader + tc.inManifest + "environments:\n  test:\n    " +
+				strings.ReplaceAll(tc.inOverride, "\n", "\n    ") + "\n"
+			mft, err := UnmarshalService([]byte(in))
+			require.NoError(t, err)
+			wanted, err := UnmarshalService([]byte(tc.inHeader + tc.wantedManifest))
+			require.NoError(t, err)
+
+			// WHEN
+			var got interface{}
+			switch m := mft.(type) {
+			case *LoadBalancedWebService:
+				got = m.ApplyEnv("test")
+			case *BackendService:
+				got = m.ApplyEnv("test")
+			case *WorkerService:
+				got = m.ApplyEnv("test")
+			case *ScheduledJob:
+				got = m.ApplyEnv("test")
+			}
+
+			// THEN
+			require.Equal(t, wanted, got)
+		})
+	}
+}
+
+func TestApplyEnv_DoesNotModifyTheManifest(t *testing.T) {
+	// GIVEN
+	mft, err := UnmarshalService([]byte(`name: api
+type: Backend Service
+image:
+  build: api/Dockerfile
+variables:
+  LOG_LEVEL: info
+sidecars:
+  nginx:
+    image: nginx
+environments:
+  test:
+    variables:
+      LOG_LEVEL: debug
+    sidecars:
+      nginx:
+        image: nginx:test
+`))
+	require.NoError(t, err)
+	svc := mft.(*BackendService)
+
+	// WHEN
+	svc.ApplyEnv("test")
+
+	// THEN
+	require.Equal(t, map[string]string{"LOG_LEVEL": "info"}, svc.Variables)
+	require.Equal(t, "nginx", svc.Sidecars["nginx"].Image)
+}
+
+func TestEnvironmentNulls(t *testing.T) {
+	testCases := map[string]struct {
+		inManifest string
+
+		wanted map[string][][]string
+	}{
+		"no environments": {
+			inManifest: `name: api`,
+		},
+		"only explicit nulls are collected": {
+			inManifest: `name: api
+environments:
+  test:
+    image:
+    http:
+      alias: null
+    variables:
+      LOG_LEVEL: ~
+  prod:
+    count: 2
+`,
+			wanted: map[string][][]string{
+				"test": {
+					{"http", "alias"},
+					{"variables", "LOG_LEVEL"},
+				},
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			var doc yaml.Node
+			require.NoError(t, yaml.Unmarshal([]byte(tc.inManifest), &doc))
+
+			// WHEN
+			got := environmentNulls(&doc)
+
+			// THEN
+			require.Equal(t, tc.wanted, got)
+		})
+	}
+}
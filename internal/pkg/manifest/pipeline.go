@@ -9,7 +9,6 @@ import (
 
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/template"
 	"github.com/fatih/structs"
-	"gopkg.in/yaml.v3"
 )
 
 const (
@@ -137,14 +136,17 @@ func (m *PipelineManifest) MarshalBinary() ([]byte, error) {
 }
 
 // UnmarshalPipeline deserializes the YAML input stream into a pipeline
-// manifest object. It returns an error if any issue occurs during
-// deserialization or the YAML input contains invalid fields.
+// manifest object, after expanding the environment variables referenced in its values.
+// It returns an error if any issue occurs during deserialization or the YAML input contains invalid fields.
 func UnmarshalPipeline(in []byte) (*PipelineManifest, error) {
-	pm := PipelineManifest{}
-	err := yaml.Unmarshal(in, &pm)
+	doc, err := unmarshalInterpolated(in)
 	if err != nil {
 		return nil, err
 	}
+	pm := PipelineManifest{}
+	if err := doc.Decode(&pm); err != nil {
+		return nil, err
+	}
 
 	var version PipelineSchemaMajorVersion
 	if version, err = validateVersion(&pm); err != nil {
@@ -0,0 +1,31 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package manifest
+
+// Capacity providers that the tasks of a service can be placed on.
+const (
+	CapacityProviderFargate     = "FARGATE"
+	CapacityProviderFargateSpot = "FARGATE_SPOT"
+)
+
+var capacityProviders = []string{CapacityProviderFargate, CapacityProviderFargateSpot}
+
+// Platform holds the configuration of the compute that the tasks of the service run on.
+type Platform struct {
+	// Strategy to spread the tasks across capacity providers, defaults to running every task on FARGATE.
+	CapacityProviders []CapacityProviderStrategy `yaml:"capacity_providers"`
+}
+
+// CapacityProviderStrategy holds the share of the tasks of the service placed on a capacity provider.
+// See https://docs.aws.amazon.com/AmazonECS/latest/developerguide/cluster-capacity-providers.html
+type CapacityProviderStrategy struct {
+	Name   *string `yaml:"name"`   // Either FARGATE or FARGATE_SPOT.
+	Base   *int    `yaml:"base"`   // Minimum number of tasks to run on the capacity provider.
+	Weight *int    `yaml:"weight"` // Relative share of the tasks beyond the base.
+}
+
+// IsEmpty returns whether Platform is empty.
+func (p Platform) IsEmpty() bool {
+	return len(p.CapacityProviders) == 0
+}
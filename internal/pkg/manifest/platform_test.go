@@ -0,0 +1,79 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package manifest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlatform_applyOverride(t *testing.T) {
+	testCases := map[string]struct {
+		inPlatform Platform
+		inOther    Platform
+
+		wanted Platform
+	}{
+		"no overrides": {
+			inPlatform: Platform{
+				CapacityProviders: []CapacityProviderStrategy{
+					{
+						Name:   stringp(CapacityProviderFargate),
+						Weight: intp(1),
+					},
+				},
+			},
+			wanted: Platform{
+				CapacityProviders: []CapacityProviderStrategy{
+					{
+						Name:   stringp(CapacityProviderFargate),
+						Weight: intp(1),
+					},
+				},
+			},
+		},
+		"overriding the capacity providers replaces the strategy": {
+			inPlatform: Platform{
+				CapacityProviders: []CapacityProviderStrategy{
+					{
+						Name:   stringp(CapacityProviderFargate),
+						Base:   intp(1),
+						Weight: intp(1),
+					},
+					{
+						Name:   stringp(CapacityProviderFargateSpot),
+						Weight: intp(1),
+					},
+				},
+			},
+			inOther: Platform{
+				CapacityProviders: []CapacityProviderStrategy{
+					{
+						Name:   stringp(CapacityProviderFargateSpot),
+						Weight: intp(1),
+					},
+				},
+			},
+			wanted: Platform{
+				CapacityProviders: []CapacityProviderStrategy{
+					{
+						Name:   stringp(CapacityProviderFargateSpot),
+						Weight: intp(1),
+					},
+				},
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// WHEN
+			got := applyOverride(tc.inPlatform, tc.inOther)
+
+			// THEN
+			require.Equal(t, tc.wanted, got)
+		})
+	}
+}
@@ -0,0 +1,187 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package manifest
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/template"
+	"github.com/aws/aws-sdk-go/aws"
+)
+
+const (
+	scheduledJobManifestPath = "jobs/scheduled/manifest.yml"
+)
+
+var (
+	errScheduleRequired = errors.New(`"schedule" is required for a scheduled job`)
+	errJobIngress       = errors.New(`"network.vpc.ingress" is not supported for a scheduled job, nothing sends traffic to its tasks`)
+)
+
+// Predefined schedules that can be used instead of a rate or cron expression.
+var predefinedSchedules = map[string]string{
+	"@hourly":  "rate(1 hour)",
+	"@daily":   "rate(1 day)",
+	"@weekly":  "rate(7 days)",
+	"@monthly": "cron(0 0 1 * ? *)",
+	"@yearly":  "cron(0 0 1 1 ? *)",
+}
+
+// ScheduledJob holds the configuration to build a container image that runs
+// to completion on a schedule with AWS Fargate as the compute engine.
+type ScheduledJob struct {
+	Service                 `yaml:",inline"`
+	Image                   ServiceImage `yaml:",flow"`
+	ImageOverride           `yaml:",inline"`
+	On                      JobTriggerConfig `yaml:"on,flow"`
+	JobFailureHandlerConfig `yaml:",inline"`
+	TaskConfig              `yaml:",inline"`
+	Network                 NetworkConfig                         `yaml:"network"`
+	Environments            map[string]scheduledJobOverrideConfig `yaml:",flow"` // Fields to override per environment.
+
+	envNulls map[string][][]string // Paths of the fields set to null in each environment override.
+	parser   template.Parser
+}
+
+type scheduledJobOverrideConfig struct {
+	Image                   ServiceImage `yaml:",flow"`
+	ImageOverride           `yaml:",inline"`
+	On                      JobTriggerConfig `yaml:"on,flow"`
+	JobFailureHandlerConfig `yaml:",inline"`
+	TaskConfig              `yaml:",inline"`
+	Network                 NetworkConfig `yaml:"network"`
+}
+
+// JobTriggerConfig represents the configuration for the event that triggers the job.
+type JobTriggerConfig struct {
+	Schedule string `yaml:"schedule"` // A rate or cron expression, or one of the predefined schedules such as "@daily".
+}
+
+// JobFailureHandlerConfig represents the error handling configuration of the job.
+type JobFailureHandlerConfig struct {
+	Timeout *time.Duration `yaml:"timeout"` // Maximum duration of a single execution of the job.
+	Retries *int           `yaml:"retries"` // Number of times to retry the job before failing.
+}
+
+// ScheduledJobProps contains properties for creating a new scheduled job manifest.
+type ScheduledJobProps struct {
+	*ServiceProps
+	Schedule string
+	Timeout  *time.Duration
+	Retries  *int
+}
+
+// NewScheduledJob creates a new scheduled job that runs a single task with minimal CPU and memory thresholds
+// on the specified schedule.
+func NewScheduledJob(props *ScheduledJobProps) *ScheduledJob {
+	job := newDefaultScheduledJob()
+	job.Name = props.Name
+	job.Version = LatestServiceManifestVersion()
+	job.Image.Build = BuildArgsOrString{BuildString: stringp(props.Dockerfile)}
+	job.On.Schedule = props.Schedule
+	job.Timeout = props.Timeout
+	job.Retries = props.Retries
+	job.parser = template.New()
+	return job
+}
+
+// newDefaultScheduledJob returns an empty ScheduledJob with only the default values set.
+func newDefaultScheduledJob() *ScheduledJob {
+	return &ScheduledJob{
+		Service: Service{
+			Type: ScheduledJobType,
+		},
+		TaskConfig: TaskConfig{
+			CPU:    256,
+			Memory: 512,
+			Count: Count{
+				Value: intp(1),
+			},
+		},
+	}
+}
+
+// MarshalBinary serializes the manifest object into a binary YAML document.
+// Implements the encoding.BinaryMarshaler interface.
+func (j *ScheduledJob) MarshalBinary() ([]byte, error) {
+	content, err := j.parser.Parse(scheduledJobManifestPath, *j)
+	if err != nil {
+		return nil, err
+	}
+	return content.Bytes(), nil
+}
+
+// DockerfilePath returns the path to the image's Dockerfile.
+func (j *ScheduledJob) DockerfilePath() string {
+	return aws.StringValue(j.Image.BuildConfig().Dockerfile)
+}
+
+// BuildArgs returns the docker build options of the image in the environment.
+func (j *ScheduledJob) BuildArgs(envName string) DockerBuildArgs {
+	return j.ApplyEnv(envName).Image.BuildConfig()
+}
+
+// ImageLocation returns the location of the pre-built container image in the environment, if any.
+func (j *ScheduledJob) ImageLocation(envName string) string {
+	return j.ApplyEnv(envName).Image.Location
+}
+
+// EnvFiles returns the paths of the environment files of the containers in the environment, keyed by the container name.
+func (j *ScheduledJob) EnvFiles(envName string) map[string]string {
+	mft := j.ApplyEnv(envName)
+	return envFiles(mft.Name, mft.TaskConfig, nil)
+}
+
+// ApplyEnv returns the job manifest with environment overrides.
+// If the environment passed in does not have any overrides then it returns itself.
+func (j *ScheduledJob) ApplyEnv(envName string) *ScheduledJob {
+	target, ok := j.Environments[envName]
+	if !ok {
+		return j
+	}
+	out := &ScheduledJob{}
+	applyEnvOverride(out, j, target, j.envNulls[envName])
+	return out
+}
+
+// ScheduleExpression converts the schedule into an Amazon EventBridge schedule expression.
+// The schedule must either be a "rate()" or "cron()" expression, or one of the predefined schedules.
+// See https://docs.aws.amazon.com/eventbridge/latest/userguide/scheduled-events.html
+func (c JobTriggerConfig) ScheduleExpression() (string, error) {
+	schedule := strings.TrimSpace(c.Schedule)
+	if schedule == "" {
+		return "", errScheduleRequired
+	}
+	if expr, ok := predefinedSchedules[schedule]; ok {
+		return expr, nil
+	}
+	for _, prefix := range []string{"rate(", "cron("} {
+		if strings.HasPrefix(schedule, prefix) && strings.HasSuffix(schedule, ")") {
+			return schedule, nil
+		}
+	}
+	return "", fmt.Errorf(`schedule %s must be a "rate()" or "cron()" expression, or one of @hourly, @daily, @weekly, @monthly, @yearly`, schedule)
+}
+
+// validate returns an error if the manifest or any of its environment overrides are invalid.
+func (j *ScheduledJob) validate() error {
+	if err := j.Image.validate(); err != nil {
+		return err
+	}
+	if j.Network.VPC.Ingress.Services != nil {
+		return errJobIngress
+	}
+	for env, override := range j.Environments {
+		if err := override.Image.validate(); err != nil {
+			return fmt.Errorf("environment %s: %w", env, err)
+		}
+		if override.Network.VPC.Ingress.Services != nil {
+			return fmt.Errorf("environment %s: %w", env, errJobIngress)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,193 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package manifest
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/template"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/template/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduledJob_MarshalBinary(t *testing.T) {
+	testCases := map[string]struct {
+		mockDependencies func(ctrl *gomock.Controller, manifest *ScheduledJob)
+
+		wantedBinary []byte
+		wantedError  error
+	}{
+		"error parsing template": {
+			mockDependencies: func(ctrl *gomock.Controller, manifest *ScheduledJob) {
+				m := mocks.NewMockParser(ctrl)
+				manifest.parser = m
+				m.EXPECT().Parse(scheduledJobManifestPath, *manifest).Return(nil, errors.New("some error"))
+			},
+
+			wantedError: errors.New("some error"),
+		},
+		"returns rendered content": {
+			mockDependencies: func(ctrl *gomock.Controller, manifest *ScheduledJob) {
+				m := mocks.NewMockParser(ctrl)
+				manifest.parser = m
+				m.EXPECT().Parse(scheduledJobManifestPath, *manifest).Return(&template.Content{Buffer: bytes.NewBufferString("hello")}, nil)
+			},
+
+			wantedBinary: []byte("hello"),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			manifest := &ScheduledJob{}
+			tc.mockDependencies(ctrl, manifest)
+
+			// WHEN
+			b, err := manifest.MarshalBinary()
+
+			// THEN
+			require.Equal(t, tc.wantedError, err)
+			require.Equal(t, tc.wantedBinary, b)
+		})
+	}
+}
+
+func TestScheduledJob_ApplyEnv(t *testing.T) {
+	testCases := map[string]struct {
+		in         *ScheduledJob
+		envToApply string
+
+		wanted *ScheduledJob
+	}{
+		"with no existing environments": {
+			in: &ScheduledJob{
+				Service: Service{Name: "report", Type: ScheduledJobType},
+				Image:   ServiceImage{Build: BuildArgsOrString{BuildString: stringp("./Dockerfile")}},
+				On:      JobTriggerConfig{Schedule: "@daily"},
+			},
+			envToApply: "prod",
+
+			wanted: &ScheduledJob{
+				Service: Service{Name: "report", Type: ScheduledJobType},
+				Image:   ServiceImage{Build: BuildArgsOrString{BuildString: stringp("./Dockerfile")}},
+				On:      JobTriggerConfig{Schedule: "@daily"},
+			},
+		},
+		"with overrides": {
+			in: &ScheduledJob{
+				Service: Service{Name: "report", Type: ScheduledJobType},
+				Image:   ServiceImage{Build: BuildArgsOrString{BuildString: stringp("./Dockerfile")}},
+				On:      JobTriggerConfig{Schedule: "@daily"},
+				JobFailureHandlerConfig: JobFailureHandlerConfig{
+					Timeout: durationp(time.Hour),
+					Retries: intp(1),
+				},
+				TaskConfig: TaskConfig{
+					CPU:    256,
+					Memory: 512,
+					Count:  Count{Value: intp(1)},
+				},
+				Environments: map[string]scheduledJobOverrideConfig{
+					"prod": {
+						On: JobTriggerConfig{Schedule: "@hourly"},
+						JobFailureHandlerConfig: JobFailureHandlerConfig{
+							Retries: intp(3),
+						},
+						TaskConfig: TaskConfig{
+							Memory: 1024,
+						},
+						Network: NetworkConfig{
+							VPC: VPCConfig{
+								Placement: stringp(PrivateSubnetPlacement),
+							},
+						},
+					},
+				},
+			},
+			envToApply: "prod",
+
+			wanted: &ScheduledJob{
+				Service: Service{Name: "report", Type: ScheduledJobType},
+				Image:   ServiceImage{Build: BuildArgsOrString{BuildString: stringp("./Dockerfile")}},
+				On:      JobTriggerConfig{Schedule: "@hourly"},
+				JobFailureHandlerConfig: JobFailureHandlerConfig{
+					Timeout: durationp(time.Hour),
+					Retries: intp(3),
+				},
+				TaskConfig: TaskConfig{
+					CPU:    256,
+					Memory: 1024,
+					Count:  Count{Value: intp(1)},
+				},
+				Network: NetworkConfig{
+					VPC: VPCConfig{
+						Placement: stringp(PrivateSubnetPlacement),
+					},
+				},
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// WHEN
+			conf := tc.in.ApplyEnv(tc.envToApply)
+
+			// THEN
+			require.Equal(t, tc.wanted, conf, "returned configuration should have overrides from the environment")
+		})
+	}
+}
+
+func TestJobTriggerConfig_ScheduleExpression(t *testing.T) {
+	testCases := map[string]struct {
+		inSchedule string
+
+		wanted    string
+		wantedErr error
+	}{
+		"missing schedule": {
+			inSchedule: "",
+			wantedErr:  errScheduleRequired,
+		},
+		"predefined schedule": {
+			inSchedule: "@weekly",
+			wanted:     "rate(7 days)",
+		},
+		"rate expression": {
+			inSchedule: "rate(30 minutes)",
+			wanted:     "rate(30 minutes)",
+		},
+		"cron expression": {
+			inSchedule: "cron(0 9 ? * MON-FRI *)",
+			wanted:     "cron(0 9 ? * MON-FRI *)",
+		},
+		"invalid schedule": {
+			inSchedule: "0 9 * * *",
+			wantedErr:  errors.New(`schedule 0 9 * * * must be a "rate()" or "cron()" expression, or one of @hourly, @daily, @weekly, @monthly, @yearly`),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// WHEN
+			expr, err := JobTriggerConfig{Schedule: tc.inSchedule}.ScheduleExpression()
+
+			// THEN
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.wanted, expr)
+			}
+		})
+	}
+}
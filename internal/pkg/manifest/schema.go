@@ -0,0 +1,543 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package manifest
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	environmentsKey = "environments"
+	anyKey          = "*" // Matches any key of a map in a field path.
+
+	maxEditDistanceForSuggestion = 2
+)
+
+var (
+	durationType = reflect.TypeOf(time.Duration(0))
+)
+
+// unionTypes are the fields that can be unmarshaled from either a scalar, or a map or a list.
+// The value of each field is validated against the scalar, the map or the list type depending on its YAML kind.
+var unionTypes = map[reflect.Type]struct {
+	scalar   reflect.Type
+	mapping  reflect.Type
+	sequence reflect.Type
+}{
+	reflect.TypeOf(Count{}): {
+		scalar:  reflect.TypeOf(0),
+		mapping: reflect.TypeOf(Autoscaling{}),
+	},
+	reflect.TypeOf(BuildArgsOrString{}): {
+		scalar:  reflect.TypeOf(""),
+		mapping: reflect.TypeOf(DockerBuildArgs{}),
+	},
+	reflect.TypeOf(EFSConfigOrBool{}): {
+		scalar:  reflect.TypeOf(true),
+		mapping: reflect.TypeOf(EFSVolumeConfiguration{}),
+	},
+	reflect.TypeOf(HealthCheckArgsOrString{}): {
+		scalar:  reflect.TypeOf(""),
+		mapping: reflect.TypeOf(HTTPHealthCheckArgs{}),
+	},
+	reflect.TypeOf(SecretArgsOrString{}): {
+		scalar:  reflect.TypeOf(""),
+		mapping: reflect.TypeOf(SecretArgs{}),
+	},
+	reflect.TypeOf(StringSliceOrString{}): {
+		scalar:   reflect.TypeOf(""),
+		sequence: reflect.TypeOf([]string{}),
+	},
+}
+
+// fieldRules validate the value of a field beyond its type.
+// They are keyed by the path of the field from the root of the manifest or of an environment override,
+// where the keys of maps and the items of lists are replaced with "*".
+// A rule replaces the type check of scalar fields.
+var fieldRules = map[string]func(value *yaml.Node) string{
+	"image.port":                                      portRule,
+	"sidecars.*.port":                                 sidecarPortRule,
+	"sidecars.*.depends_on.*":                         oneOfRule(dependsOnConditions),
+	"sidecars.*.env_file":                             envFileRule,
+	"sidecars.*.secrets.*":                            secretRule,
+	"sidecars.*.secrets.*.from":                       secretRule,
+	"sidecars.*.secrets.*.kms_key":                    kmsKeyRule,
+	"env_file":                                        envFileRule,
+	"secrets.*":                                       secretRule,
+	"secrets.*.from":                                  secretRule,
+	"secrets.*.kms_key":                               kmsKeyRule,
+	"http.healthcheck.healthy_threshold":              intRangeRule(2, 10),
+	"http.healthcheck.unhealthy_threshold":            intRangeRule(2, 10),
+	"http.protocol_version":                           oneOfRule(targetProtocolVersions),
+	"nlb.listeners.*.port":                            nlbPortRule,
+	"nlb.listeners.*.target_port":                     portRule,
+	"nlb.listeners.*.healthcheck.port":                portRule,
+	"nlb.listeners.*.healthcheck.healthy_threshold":   intRangeRule(2, 10),
+	"nlb.listeners.*.healthcheck.unhealthy_threshold": intRangeRule(2, 10),
+	"count.range":                                     rangeRule,
+	"count.cpu_percentage":                            percentageRule,
+	"count.memory_percentage":                         percentageRule,
+	"queue.dead_letter.tries":                         intRangeRule(1, 1000),
+	"platform.capacity_providers.*.name":              oneOfRule(capacityProviders),
+	"platform.capacity_providers.*.base":              intRangeRule(0, 100000),
+	"platform.capacity_providers.*.weight":            intRangeRule(0, 1000),
+	"network.vpc.placement":                           oneOfRule(subnetPlacements),
+	"deployment.minimum_healthy_percent":              intRangeRule(0, 100),
+	"deployment.maximum_percent":                      intRangeRule(100, 200),
+	"deployment.strategy":                             oneOfRule(deploymentStrategies),
+	"deployment.blue_green.traffic_shifting":          oneOfRule(trafficShiftings),
+	"deployment.blue_green.percentage":                intRangeRule(1, 99),
+	"deployment.blue_green.test_port":                 portRule,
+}
+
+// Valid combinations of CPU units and memory in MiB for a Fargate task.
+// See https://docs.aws.amazon.com/AmazonECS/latest/developerguide/task-cpu-memory-error.html
+var fargateTaskSizes = map[int][]int{
+	256:  {512, 1024, 2048},
+	512:  memoryRange(1024, 4096),
+	1024: memoryRange(2048, 8192),
+	2048: memoryRange(4096, 16384),
+	4096: memoryRange(8192, 30720),
+}
+
+// validateSchema returns an *ErrInvalidManifest if the manifest has fields that are unknown to the type of v,
+// fields whose values can't be decoded into v, or values that can't be deployed.
+// The position of every violation in the YAML document is reported, in the order of the document.
+func validateSchema(doc *yaml.Node, v interface{}) error {
+	if len(doc.Content) == 0 {
+		return nil
+	}
+	root := doc.Content[0]
+	s := &schemaValidator{}
+	s.validate(root, reflect.TypeOf(v), "", "")
+	s.validateTaskSize(root)
+	if len(s.errs) > 0 {
+		sort.SliceStable(s.errs, func(i, j int) bool {
+			if s.errs[i].Line != s.errs[j].Line {
+				return s.errs[i].Line < s.errs[j].Line
+			}
+			return s.errs[i].Column < s.errs[j].Column
+		})
+		return &ErrInvalidManifest{
+			Errors: s.errs,
+		}
+	}
+	return nil
+}
+
+// unmarshalStrict decodes the manifest document into v, and returns an error if the manifest contains fields that don't exist in v.
+func unmarshalStrict(doc *yaml.Node, v interface{}) error {
+	// Nodes can't be decoded strictly, so the document is encoded again for a strict decoder.
+	in, err := yaml.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	dec := yaml.NewDecoder(bytes.NewReader(in))
+	dec.KnownFields(true)
+	return dec.Decode(v)
+}
+
+type schemaValidator struct {
+	errs []*ErrInvalidField
+}
+
+// validate checks that the node can be decoded into a value of type t.
+// path is the full path of the field for error messages, and rulePath is the key into fieldRules.
+func (s *schemaValidator) validate(node *yaml.Node, t reflect.Type, path, rulePath string) {
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	if node.ShortTag() == "!!null" {
+		// Empty fields are left to their default values.
+		return
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if rule, ok := fieldRules[rulePath]; ok && node.Kind == yaml.ScalarNode {
+		if reason := rule(node); reason != "" {
+			s.addErr(node, path, fmt.Sprintf("%q %s", path, reason))
+		}
+		return
+	}
+	if t == durationType {
+		if _, err := time.ParseDuration(node.Value); node.Kind != yaml.ScalarNode || err != nil {
+			s.addErr(node, path, fmt.Sprintf(`%q must be a duration such as "30s" or "1h30m"`, path))
+		}
+		return
+	}
+	if union, ok := unionTypes[t]; ok {
+		switch {
+		case node.Kind == yaml.ScalarNode:
+			s.validate(node, union.scalar, path, rulePath)
+		case node.Kind == yaml.MappingNode && union.mapping != nil:
+			s.validate(node, union.mapping, path, rulePath)
+		case node.Kind == yaml.SequenceNode && union.sequence != nil:
+			s.validate(node, union.sequence, path, rulePath)
+		case union.mapping != nil:
+			s.addErr(node, path, fmt.Sprintf("%q must be %s or a map", path, kindName(union.scalar)))
+		default:
+			s.addErr(node, path, fmt.Sprintf("%q must be %s or a list", path, kindName(union.scalar)))
+		}
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		if !s.expectKind(node, yaml.MappingNode, path, "a map") {
+			return
+		}
+		fields := yamlFields(t)
+		for i := 0; i < len(node.Content)-1; i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			if key.ShortTag() == "!!merge" {
+				s.validate(value, t, path, rulePath)
+				continue
+			}
+			fieldType, ok := fields[key.Value]
+			if !ok {
+				s.addErr(key, joinPath(path, key.Value), unknownFieldReason(key.Value, fields))
+				continue
+			}
+			s.validate(value, fieldType, joinPath(path, key.Value), joinPath(rulePath, key.Value))
+		}
+	case reflect.Map:
+		if !s.expectKind(node, yaml.MappingNode, path, "a map") {
+			return
+		}
+		for i := 0; i < len(node.Content)-1; i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			valueRulePath := joinPath(rulePath, anyKey)
+			if rulePath == environmentsKey {
+				// Environment overrides follow the same rules as the root of the manifest.
+				valueRulePath = ""
+			}
+			s.validate(value, t.Elem(), joinPath(path, key.Value), valueRulePath)
+		}
+	case reflect.Slice:
+		if !s.expectKind(node, yaml.SequenceNode, path, "a list") {
+			return
+		}
+		for i, item := range node.Content {
+			s.validate(item, t.Elem(), fmt.Sprintf("%s[%d]", path, i), joinPath(rulePath, anyKey))
+		}
+	case reflect.String:
+		s.expectKind(node, yaml.ScalarNode, path, "a string")
+	case reflect.Bool:
+		if node.Kind != yaml.ScalarNode || node.ShortTag() != "!!bool" {
+			s.addErr(node, path, fmt.Sprintf("%q must be a boolean", path))
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if _, err := strconv.ParseInt(node.Value, 0, t.Bits()); node.ShortTag() != "!!int" || err != nil {
+			s.addErr(node, path, fmt.Sprintf("%q must be an integer", path))
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if _, err := strconv.ParseUint(node.Value, 0, t.Bits()); node.ShortTag() != "!!int" || err != nil {
+			s.addErr(node, path, fmt.Sprintf("%q must be an integer between 0 and %d", path, uint64(1)<<uint(t.Bits())-1))
+		}
+	case reflect.Float32, reflect.Float64:
+		if tag := node.ShortTag(); tag != "!!float" && tag != "!!int" {
+			s.addErr(node, path, fmt.Sprintf("%q must be a number", path))
+		}
+	}
+}
+
+// validateTaskSize checks that the CPU and memory of the task, and of every environment override, is a valid Fargate task size.
+func (s *schemaValidator) validateTaskSize(root *yaml.Node) {
+	// All workloads default to the smallest task size.
+	cpu, memory := 256, 512
+	cpuNode, memoryNode := mappingValue(root, "cpu"), mappingValue(root, "memory")
+	if !parseInt(cpuNode, &cpu) || !parseInt(memoryNode, &memory) {
+		return
+	}
+	if cpuNode != nil || memoryNode != nil {
+		s.checkTaskSize(cpuNode, memoryNode, "", cpu, memory)
+	}
+
+	envs := mappingValue(root, environmentsKey)
+	if envs == nil || envs.Kind != yaml.MappingNode {
+		return
+	}
+	for i := 0; i < len(envs.Content)-1; i += 2 {
+		env := envs.Content[i+1]
+		envCPU, envMemory := cpu, memory
+		envCPUNode, envMemoryNode := mappingValue(env, "cpu"), mappingValue(env, "memory")
+		if envCPUNode == nil && envMemoryNode == nil {
+			continue
+		}
+		if !parseInt(envCPUNode, &envCPU) || !parseInt(envMemoryNode, &envMemory) {
+			continue
+		}
+		s.checkTaskSize(envCPUNode, envMemoryNode, joinPath(environmentsKey, envs.Content[i].Value), envCPU, envMemory)
+	}
+}
+
+func (s *schemaValidator) checkTaskSize(cpuNode, memoryNode *yaml.Node, path string, cpu, memory int) {
+	memories, ok := fargateTaskSizes[cpu]
+	if !ok {
+		node := cpuNode
+		if node == nil {
+			// The CPU is inherited from the root of the manifest, which was already reported.
+			return
+		}
+		s.addErr(node, joinPath(path, "cpu"), fmt.Sprintf("%q must be one of %s", joinPath(path, "cpu"), fmtInts(sortedKeys(fargateTaskSizes))))
+		return
+	}
+	for _, m := range memories {
+		if m == memory {
+			return
+		}
+	}
+	// Report the error on the field that was set last.
+	node, field := memoryNode, joinPath(path, "memory")
+	if node == nil {
+		node, field = cpuNode, joinPath(path, "cpu")
+	}
+	allowed := fmt.Sprintf("one of %s", fmtInts(memories))
+	if len(memories) > 3 {
+		allowed = fmt.Sprintf("between %d and %d in increments of 1024", memories[0], memories[len(memories)-1])
+	}
+	s.addErr(node, field, fmt.Sprintf(`invalid Fargate task size: "memory" must be %s for %d CPU units, but got %d`, allowed, cpu, memory))
+}
+
+func (s *schemaValidator) expectKind(node *yaml.Node, kind yaml.Kind, path, name string) bool {
+	if node.Kind != kind {
+		s.addErr(node, path, fmt.Sprintf("%q must be %s", path, name))
+		return false
+	}
+	return true
+}
+
+func (s *schemaValidator) addErr(node *yaml.Node, path, reason string) {
+	s.errs = append(s.errs, &ErrInvalidField{
+		Field:  path,
+		Line:   node.Line,
+		Column: node.Column,
+		Reason: reason,
+	})
+}
+
+// yamlFields returns the type of each field of the struct keyed by its YAML key, following the yaml.v3 conventions.
+func yamlFields(t reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" && !f.Anonymous {
+			// Unexported fields are ignored by the decoder.
+			continue
+		}
+		tag := f.Tag.Get("yaml")
+		if tag == "-" {
+			continue
+		}
+		opts := strings.Split(tag, ",")
+		name, flags := opts[0], opts[1:]
+		if contains(flags, "inline") {
+			for k, v := range yamlFields(f.Type) {
+				fields[k] = v
+			}
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(f.Name)
+		}
+		fields[name] = f.Type
+	}
+	return fields
+}
+
+func unknownFieldReason(key string, fields map[string]reflect.Type) string {
+	reason := fmt.Sprintf("unknown field %q", key)
+	suggestion, min := "", maxEditDistanceForSuggestion+1
+	for name := range fields {
+		if d := editDistance(key, name); d < min || (d == min && name < suggestion) {
+			suggestion, min = name, d
+		}
+	}
+	if suggestion != "" {
+		reason = fmt.Sprintf("%s, did you mean %q?", reason, suggestion)
+	}
+	return reason
+}
+
+// editDistance returns the Levenshtein distance between the two strings.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr := make([]int, len(b)+1)
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = minInt(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev = curr
+	}
+	return prev[len(b)]
+}
+
+func portRule(value *yaml.Node) string {
+	if port, err := strconv.Atoi(value.Value); value.ShortTag() != "!!int" || err != nil || port < 1 || port > 65535 {
+		return "must be a port between 1 and 65535"
+	}
+	return ""
+}
+
+func sidecarPortRule(value *yaml.Node) string {
+	parts := strings.Split(value.Value, "/")
+	if len(parts) > 2 || (len(parts) == 2 && parts[1] != "tcp" && parts[1] != "udp") {
+		return `must be a port optionally followed by a protocol, such as "80" or "80/tcp"`
+	}
+	if port, err := strconv.Atoi(parts[0]); err != nil || port < 1 || port > 65535 {
+		return "must be a port between 1 and 65535"
+	}
+	return ""
+}
+
+func nlbPortRule(value *yaml.Node) string {
+	if _, _, err := (NetworkLoadBalancerListener{Port: value.Value}).PortAndProtocol(); err != nil {
+		return fmt.Sprintf(`must be a port followed by one of the protocols %s, such as "1883/tcp"`, strings.Join(nlbProtocols, ", "))
+	}
+	return ""
+}
+
+func envFileRule(value *yaml.Node) string {
+	if path.Ext(value.Value) != ".env" {
+		return `must be the path of a file with the ".env" extension`
+	}
+	return ""
+}
+
+func oneOfRule(values []string) func(value *yaml.Node) string {
+	return func(value *yaml.Node) string {
+		if !contains(values, value.Value) {
+			return fmt.Sprintf("must be one of %s", strings.Join(values, ", "))
+		}
+		return ""
+	}
+}
+
+func rangeRule(value *yaml.Node) string {
+	if _, _, err := Range(value.Value).Parse(); err != nil {
+		return fmt.Sprintf(`must be a range such as "1-10": %s`, err)
+	}
+	return ""
+}
+
+func percentageRule(value *yaml.Node) string {
+	return intRangeRule(1, 100)(value)
+}
+
+func intRangeRule(min, max int) func(value *yaml.Node) string {
+	return func(value *yaml.Node) string {
+		if v, err := strconv.Atoi(value.Value); value.ShortTag() != "!!int" || err != nil || v < min || v > max {
+			return fmt.Sprintf("must be an integer between %d and %d", min, max)
+		}
+		return ""
+	}
+}
+
+// mappingValue returns the value of the key in the mapping node, or nil if the key doesn't exist.
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i < len(node.Content)-1; i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// parseInt sets out to the integer value of the node if the node exists.
+// It returns false if the node exists but isn't an integer.
+func parseInt(node *yaml.Node, out *int) bool {
+	if node == nil || node.ShortTag() == "!!null" {
+		return true
+	}
+	v, err := strconv.Atoi(node.Value)
+	if err != nil {
+		return false
+	}
+	*out = v
+	return true
+}
+
+func memoryRange(min, max int) []int {
+	var memories []int
+	for m := min; m <= max; m += 1024 {
+		memories = append(memories, m)
+	}
+	return memories
+}
+
+func sortedKeys(m map[int][]int) []int {
+	keys := make([]int, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+	return keys
+}
+
+func fmtInts(values []int) string {
+	s := make([]string, len(values))
+	for i, v := range values {
+		s[i] = strconv.Itoa(v)
+	}
+	return strings.Join(s, ", ")
+}
+
+func kindName(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.String:
+		return "a string"
+	case reflect.Int:
+		return "an integer"
+	case reflect.Bool:
+		return "a boolean"
+	}
+	return t.String()
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func contains(items []string, s string) bool {
+	for _, item := range items {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+func minInt(values ...int) int {
+	min := values[0]
+	for _, v := range values[1:] {
+		if v < min {
+			min = v
+		}
+	}
+	return min
+}
@@ -0,0 +1,679 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package manifest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestValidateSchema(t *testing.T) {
+	testCases := map[string]struct {
+		inManifest interface{}
+		inContent  string
+
+		wantedErrs []*ErrInvalidField
+	}{
+		"valid load balanced web service": {
+			inManifest: &LoadBalancedWebService{},
+			inContent: `
+name: frontend
+type: Load Balanced Web Service
+image:
+  build:
+    dockerfile: frontend/Dockerfile
+    args:
+      GOOS: linux
+  port: 80
+http:
+  path: '/'
+cpu: 2048
+memory: 4096
+count:
+  range: 1-10
+  cpu_percentage: 70
+sidecars:
+  xray:
+    port: 2000/udp
+    image: amazon/aws-xray-daemon
+environments:
+  test:
+    count: 1
+`,
+		},
+		"valid backend service with aliases": {
+			inManifest: &BackendService{},
+			inContent: `
+name: api
+type: Backend Service
+image:
+  build: api/Dockerfile
+  port: 8080
+  healthcheck: &healthcheck
+    command: ["CMD-SHELL", "curl -f http://localhost:8080 || exit 1"]
+    interval: 10s
+environments:
+  prod:
+    image:
+      healthcheck: *healthcheck
+`,
+		},
+		"empty fields are allowed": {
+			inManifest: &WorkerService{},
+			inContent: `
+name: processor
+type: Worker Service
+image:
+  build: processor/Dockerfile
+variables:
+queue:
+  retention:
+`,
+		},
+		"unknown fields are reported with suggestions": {
+			inManifest: &BackendService{},
+			inContent: `name: api
+type: Backend Service
+image:
+  build: api/Dockerfile
+  prot: 8080
+varibles:
+  LOG_LEVEL: info
+foo: bar
+`,
+			wantedErrs: []*ErrInvalidField{
+				{
+					Field:  "image.prot",
+					Line:   5,
+					Column: 3,
+					Reason: `unknown field "prot", did you mean "port"?`,
+				},
+				{
+					Field:  "varibles",
+					Line:   6,
+					Column: 1,
+					Reason: `unknown field "varibles", did you mean "variables"?`,
+				},
+				{
+					Field:  "foo",
+					Line:   8,
+					Column: 1,
+					Reason: `unknown field "foo"`,
+				},
+			},
+		},
+		"values that don't match the type of the field": {
+			inManifest: &WorkerService{},
+			inContent: `name: processor
+type: Worker Service
+image:
+  build: [processor/Dockerfile]
+cpu: lots
+variables: [LOG_LEVEL]
+queue:
+  timeout: 30
+  dead_letter:
+    tries: 0
+`,
+			wantedErrs: []*ErrInvalidField{
+				{
+					Field:  "image.build",
+					Line:   4,
+					Column: 10,
+					Reason: `"image.build" must be a string or a map`,
+				},
+				{
+					Field:  "cpu",
+					Line:   5,
+					Column: 6,
+					Reason: `"cpu" must be an integer`,
+				},
+				{
+					Field:  "variables",
+					Line:   6,
+					Column: 12,
+					Reason: `"variables" must be a map`,
+				},
+				{
+					Field:  "queue.timeout",
+					Line:   8,
+					Column: 12,
+					Reason: `"queue.timeout" must be a duration such as "30s" or "1h30m"`,
+				},
+				{
+					Field:  "queue.dead_letter.tries",
+					Line:   10,
+					Column: 12,
+					Reason: `"queue.dead_letter.tries" must be an integer between 1 and 1000`,
+				},
+			},
+		},
+		"invalid ports": {
+			inManifest: &LoadBalancedWebService{},
+			inContent: `name: frontend
+type: Load Balanced Web Service
+image:
+  build: frontend/Dockerfile
+  port: 0
+sidecars:
+  nginx:
+    port: 80/http
+  xray:
+    port: 70000
+environments:
+  prod:
+    image:
+      port: 65536
+`,
+			wantedErrs: []*ErrInvalidField{
+				{
+					Field:  "image.port",
+					Line:   5,
+					Column: 9,
+					Reason: `"image.port" must be a port between 1 and 65535`,
+				},
+				{
+					Field:  "sidecars.nginx.port",
+					Line:   8,
+					Column: 11,
+					Reason: `"sidecars.nginx.port" must be a port optionally followed by a protocol, such as "80" or "80/tcp"`,
+				},
+				{
+					Field:  "sidecars.xray.port",
+					Line:   10,
+					Column: 11,
+					Reason: `"sidecars.xray.port" must be a port between 1 and 65535`,
+				},
+				{
+					Field:  "environments.prod.image.port",
+					Line:   14,
+					Column: 13,
+					Reason: `"environments.prod.image.port" must be a port between 1 and 65535`,
+				},
+			},
+		},
+		"invalid autoscaling": {
+			inManifest: &BackendService{},
+			inContent: `name: api
+type: Backend Service
+image:
+  build: api/Dockerfile
+  port: 8080
+count:
+  range: 10-1
+  memory_percentage: 120
+`,
+			wantedErrs: []*ErrInvalidField{
+				{
+					Field:  "count.range",
+					Line:   7,
+					Column: 10,
+					Reason: `"count.range" must be a range such as "1-10": minimum value 10 cannot be larger than maximum value 1`,
+				},
+				{
+					Field:  "count.memory_percentage",
+					Line:   8,
+					Column: 22,
+					Reason: `"count.memory_percentage" must be an integer between 1 and 100`,
+				},
+			},
+		},
+		"invalid storage": {
+			inManifest: &BackendService{},
+			inContent: `name: api
+type: Backend Service
+image:
+  build: api/Dockerfile
+  port: 8080
+storage:
+  volumes:
+    data:
+      efs: [fs-1234]
+      read_only: no thanks
+    shared:
+      efs:
+        id: fs-1234
+        auth:
+          iam: true
+sidecars:
+  nginx:
+    image: nginx
+    mount_points:
+      - source_volume: data
+        pth: /var/www
+`,
+			wantedErrs: []*ErrInvalidField{
+				{
+					Field:  "storage.volumes.data.efs",
+					Line:   9,
+					Column: 12,
+					Reason: `"storage.volumes.data.efs" must be a boolean or a map`,
+				},
+				{
+					Field:  "storage.volumes.data.read_only",
+					Line:   10,
+					Column: 18,
+					Reason: `"storage.volumes.data.read_only" must be a boolean`,
+				},
+				{
+					Field:  "sidecars.nginx.mount_points[0].pth",
+					Line:   21,
+					Column: 9,
+					Reason: `unknown field "pth", did you mean "path"?`,
+				},
+			},
+		},
+		"invalid sidecars": {
+			inManifest: &LoadBalancedWebService{},
+			inContent: `name: frontend
+type: Load Balanced Web Service
+image:
+  build: frontend/Dockerfile
+  port: 80
+sidecars:
+  nginx:
+    image: nginx
+    essential: false
+    command: ["nginx", "-g", "daemon off;"]
+    entrypoint: {sh: -c}
+    depends_on:
+      frontend: STARTED
+    healthcheck:
+      command: ["CMD-SHELL", "curl -f http://localhost || exit 1"]
+      interval: 10
+`,
+			wantedErrs: []*ErrInvalidField{
+				{
+					Field:  "sidecars.nginx.entrypoint",
+					Line:   11,
+					Column: 17,
+					Reason: `"sidecars.nginx.entrypoint" must be a string or a list`,
+				},
+				{
+					Field:  "sidecars.nginx.depends_on.frontend",
+					Line:   13,
+					Column: 17,
+					Reason: `"sidecars.nginx.depends_on.frontend" must be one of START, COMPLETE, SUCCESS, HEALTHY`,
+				},
+				{
+					Field:  "sidecars.nginx.healthcheck.interval",
+					Line:   16,
+					Column: 17,
+					Reason: `"sidecars.nginx.healthcheck.interval" must be a duration such as "30s" or "1h30m"`,
+				},
+			},
+		},
+		"invalid logging": {
+			inManifest: &BackendService{},
+			inContent: `name: api
+type: Backend Service
+image:
+  build: api/Dockerfile
+logging:
+  enable_metadata: yes please
+  destination:
+    elasticsearch:
+      host: search-logs.us-west-2.es.amazonaws.com
+      port: https
+`,
+			wantedErrs: []*ErrInvalidField{
+				{
+					Field:  "logging.enable_metadata",
+					Line:   6,
+					Column: 20,
+					Reason: `"logging.enable_metadata" must be a boolean`,
+				},
+				{
+					Field:  "logging.destination.elasticsearch.port",
+					Line:   10,
+					Column: 13,
+					Reason: `"logging.destination.elasticsearch.port" must be an integer`,
+				},
+			},
+		},
+		"invalid capacity providers": {
+			inManifest: &WorkerService{},
+			inContent: `name: orders
+type: Worker Service
+image:
+  build: orders/Dockerfile
+platform:
+  capacity_providers:
+    - name: FARGATE
+      base: -1
+    - name: SPOT
+      weight: 2000
+`,
+			wantedErrs: []*ErrInvalidField{
+				{
+					Field:  "platform.capacity_providers[0].base",
+					Line:   8,
+					Column: 13,
+					Reason: `"platform.capacity_providers[0].base" must be an integer between 0 and 100000`,
+				},
+				{
+					Field:  "platform.capacity_providers[1].name",
+					Line:   9,
+					Column: 13,
+					Reason: `"platform.capacity_providers[1].name" must be one of FARGATE, FARGATE_SPOT`,
+				},
+				{
+					Field:  "platform.capacity_providers[1].weight",
+					Line:   10,
+					Column: 15,
+					Reason: `"platform.capacity_providers[1].weight" must be an integer between 0 and 1000`,
+				},
+			},
+		},
+		"invalid subnet placement": {
+			inManifest: &BackendService{},
+			inContent: `name: api
+type: Backend Service
+image:
+  build: api/Dockerfile
+  port: 8080
+network:
+  vpc:
+    placement: isolated
+`,
+			wantedErrs: []*ErrInvalidField{
+				{
+					Field:  "network.vpc.placement",
+					Line:   8,
+					Column: 16,
+					Reason: `"network.vpc.placement" must be one of public, private`,
+				},
+			},
+		},
+		"invalid secrets": {
+			inManifest: &BackendService{},
+			inContent: `name: api
+type: Backend Service
+image:
+  build: api/Dockerfile
+  port: 8080
+secrets:
+  GITHUB_TOKEN: GITHUB_TOKEN
+  DB_PASSWORD: arn:aws:s3:::bucket/password
+  DB_USER:
+    from: arn:aws:secretsmanager
+    json_key: username
+    kms_key: alias/db
+sidecars:
+  nginx:
+    image: nginx
+    secrets:
+      LICENSE: [license]
+`,
+			wantedErrs: []*ErrInvalidField{
+				{
+					Field:  "secrets.DB_PASSWORD",
+					Line:   8,
+					Column: 16,
+					Reason: `"secrets.DB_PASSWORD" must be the name or ARN of an SSM parameter, or the ARN of a Secrets Manager secret`,
+				},
+				{
+					Field:  "secrets.DB_USER.from",
+					Line:   10,
+					Column: 11,
+					Reason: `"secrets.DB_USER.from" must be a valid ARN`,
+				},
+				{
+					Field:  "secrets.DB_USER.kms_key",
+					Line:   12,
+					Column: 14,
+					Reason: `"secrets.DB_USER.kms_key" must be the ARN of a KMS key`,
+				},
+				{
+					Field:  "sidecars.nginx.secrets.LICENSE",
+					Line:   17,
+					Column: 16,
+					Reason: `"sidecars.nginx.secrets.LICENSE" must be a string or a map`,
+				},
+			},
+		},
+		"invalid env files": {
+			inManifest: &BackendService{},
+			inContent: `name: api
+type: Backend Service
+image:
+  build: api/Dockerfile
+  port: 8080
+env_file: api/config.txt
+sidecars:
+  nginx:
+    image: nginx
+    env_file: nginx.env
+environments:
+  test:
+    env_file: api/test
+`,
+			wantedErrs: []*ErrInvalidField{
+				{
+					Field:  "env_file",
+					Line:   6,
+					Column: 11,
+					Reason: `"env_file" must be the path of a file with the ".env" extension`,
+				},
+				{
+					Field:  "environments.test.env_file",
+					Line:   13,
+					Column: 15,
+					Reason: `"environments.test.env_file" must be the path of a file with the ".env" extension`,
+				},
+			},
+		},
+		"invalid network load balancer listeners": {
+			inManifest: &LoadBalancedWebService{},
+			inContent: `name: broker
+type: Load Balanced Web Service
+image:
+  build: broker/Dockerfile
+  port: 8080
+nlb:
+  listeners:
+    - port: 1883/tcp
+      target_port: 70000
+    - port: 8883
+      healthcheck:
+        healthy_threshold: 1
+environments:
+  test:
+    nlb:
+      listeners:
+        - port: 1883/http
+`,
+			wantedErrs: []*ErrInvalidField{
+				{
+					Field:  "nlb.listeners[0].target_port",
+					Line:   9,
+					Column: 20,
+					Reason: `"nlb.listeners[0].target_port" must be a port between 1 and 65535`,
+				},
+				{
+					Field:  "nlb.listeners[1].port",
+					Line:   10,
+					Column: 13,
+					Reason: `"nlb.listeners[1].port" must be a port followed by one of the protocols tcp, udp, tcp_udp, tls, such as "1883/tcp"`,
+				},
+				{
+					Field:  "nlb.listeners[1].healthcheck.healthy_threshold",
+					Line:   12,
+					Column: 28,
+					Reason: `"nlb.listeners[1].healthcheck.healthy_threshold" must be an integer between 2 and 10`,
+				},
+				{
+					Field:  "environments.test.nlb.listeners[0].port",
+					Line:   17,
+					Column: 17,
+					Reason: `"environments.test.nlb.listeners[0].port" must be a port followed by one of the protocols tcp, udp, tcp_udp, tls, such as "1883/tcp"`,
+				},
+			},
+		},
+		"invalid deployment percentages": {
+			inManifest: &BackendService{},
+			inContent: `name: api
+type: Backend Service
+image:
+  build: api/Dockerfile
+  port: 8080
+deployment:
+  minimum_healthy_percent: 150
+  maximum_percent: 50
+`,
+			wantedErrs: []*ErrInvalidField{
+				{
+					Field:  "deployment.minimum_healthy_percent",
+					Line:   7,
+					Column: 28,
+					Reason: `"deployment.minimum_healthy_percent" must be an integer between 0 and 100`,
+				},
+				{
+					Field:  "deployment.maximum_percent",
+					Line:   8,
+					Column: 20,
+					Reason: `"deployment.maximum_percent" must be an integer between 100 and 200`,
+				},
+			},
+		},
+		"invalid blue/green deployments": {
+			inManifest: &LoadBalancedWebService{},
+			inContent: `name: frontend
+type: Load Balanced Web Service
+image:
+  build: frontend/Dockerfile
+  port: 80
+deployment:
+  strategy: red_black
+  blue_green:
+    traffic_shifting: exponential
+    percentage: 100
+    interval: 5
+`,
+			wantedErrs: []*ErrInvalidField{
+				{
+					Field:  "deployment.strategy",
+					Line:   7,
+					Column: 13,
+					Reason: `"deployment.strategy" must be one of rolling, blue_green`,
+				},
+				{
+					Field:  "deployment.blue_green.traffic_shifting",
+					Line:   9,
+					Column: 23,
+					Reason: `"deployment.blue_green.traffic_shifting" must be one of canary, linear, all_at_once`,
+				},
+				{
+					Field:  "deployment.blue_green.percentage",
+					Line:   10,
+					Column: 17,
+					Reason: `"deployment.blue_green.percentage" must be an integer between 1 and 99`,
+				},
+				{
+					Field:  "deployment.blue_green.interval",
+					Line:   11,
+					Column: 15,
+					Reason: `"deployment.blue_green.interval" must be a duration such as "30s" or "1h30m"`,
+				},
+			},
+		},
+		"invalid target group": {
+			inManifest: &LoadBalancedWebService{},
+			inContent: `name: frontend
+type: Load Balanced Web Service
+image:
+  build: frontend/Dockerfile
+  port: 80
+http:
+  path: '/'
+  healthcheck:
+    path: /ping
+    healthy_threshold: 1
+  protocol_version: HTTP3
+`,
+			wantedErrs: []*ErrInvalidField{
+				{
+					Field:  "http.healthcheck.healthy_threshold",
+					Line:   10,
+					Column: 24,
+					Reason: `"http.healthcheck.healthy_threshold" must be an integer between 2 and 10`,
+				},
+				{
+					Field:  "http.protocol_version",
+					Line:   11,
+					Column: 21,
+					Reason: `"http.protocol_version" must be one of HTTP1, HTTP2, GRPC`,
+				},
+			},
+		},
+		"invalid Fargate CPU": {
+			inManifest: &ScheduledJob{},
+			inContent: `name: report
+type: Scheduled Job
+image:
+  build: report/Dockerfile
+on:
+  schedule: "@daily"
+cpu: 300
+`,
+			wantedErrs: []*ErrInvalidField{
+				{
+					Field:  "cpu",
+					Line:   7,
+					Column: 6,
+					Reason: `"cpu" must be one of 256, 512, 1024, 2048, 4096`,
+				},
+			},
+		},
+		"invalid Fargate memory": {
+			inManifest: &ScheduledJob{},
+			inContent: `name: report
+type: Scheduled Job
+image:
+  build: report/Dockerfile
+on:
+  schedule: "@daily"
+memory: 4096
+environments:
+  prod:
+    cpu: 512
+    memory: 8192
+`,
+			wantedErrs: []*ErrInvalidField{
+				{
+					Field:  "memory",
+					Line:   7,
+					Column: 9,
+					Reason: `invalid Fargate task size: "memory" must be one of 512, 1024, 2048 for 256 CPU units, but got 4096`,
+				},
+				{
+					Field:  "environments.prod.memory",
+					Line:   11,
+					Column: 13,
+					Reason: `invalid Fargate task size: "memory" must be between 1024 and 4096 in increments of 1024 for 512 CPU units, but got 8192`,
+				},
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			var doc yaml.Node
+			require.NoError(t, yaml.Unmarshal([]byte(tc.inContent), &doc))
+
+			// WHEN
+			err := validateSchema(&doc, tc.inManifest)
+
+			// THEN
+			if tc.wantedErrs == nil {
+				require.NoError(t, err)
+				return
+			}
+			require.Equal(t, &ErrInvalidManifest{Errors: tc.wantedErrs}, err)
+		})
+	}
+}
@@ -0,0 +1,189 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package manifest
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"gopkg.in/yaml.v3"
+)
+
+// Services that store the secrets injected into the containers.
+const (
+	ssmService            = "ssm"
+	secretsManagerService = "secretsmanager"
+	kmsService            = "kms"
+)
+
+// Prefixes of the resources of the ARNs of the secrets and of the keys that encrypt them.
+const (
+	ssmParameterResourcePrefix = "parameter/"
+	secretResourcePrefix       = "secret:"
+	kmsKeyResourcePrefix       = "key/"
+)
+
+var (
+	errUnmarshalSecret = errors.New(`unable to unmarshal secret into a string or a map`)
+)
+
+// SecretArgsOrString is a custom type which supports unmarshaling yaml which
+// can either be of type string, the name or ARN of the secret, or type SecretArgs.
+type SecretArgsOrString struct {
+	SecretString *string
+	SecretArgs   SecretArgs // Mutually exclusive with SecretString.
+}
+
+// SecretArgs represents a secret stored in SSM Parameter Store or in Secrets Manager,
+// and the selectors of the value of a Secrets Manager secret.
+type SecretArgs struct {
+	From         *string `yaml:"from,omitempty"`          // Name or ARN of an SSM parameter, or ARN of a Secrets Manager secret.
+	JSONKey      *string `yaml:"json_key,omitempty"`      // Key of the value if the Secrets Manager secret is a JSON object.
+	VersionStage *string `yaml:"version_stage,omitempty"` // Staging label of the version of the Secrets Manager secret, such as "AWSPREVIOUS".
+	VersionID    *string `yaml:"version_id,omitempty"`
+	KMSKey       *string `yaml:"kms_key,omitempty"` // ARN of the customer managed key that encrypts the secret.
+}
+
+// IsEmpty returns whether SecretArgs is empty.
+func (a SecretArgs) IsEmpty() bool {
+	return a.From == nil && a.JSONKey == nil && a.VersionStage == nil && a.VersionID == nil && a.KMSKey == nil
+}
+
+// UnmarshalYAML overrides the default YAML unmarshaling logic for the SecretArgsOrString
+// struct, allowing it to perform more complex unmarshaling behavior.
+// This method implements the yaml.Unmarshaler (v3) interface.
+func (s *SecretArgsOrString) UnmarshalYAML(value *yaml.Node) error {
+	switch value.Kind {
+	case yaml.ScalarNode:
+		var str string
+		if err := value.Decode(&str); err != nil {
+			return errUnmarshalSecret
+		}
+		s.SecretString = &str
+		s.SecretArgs = SecretArgs{}
+		return nil
+	case yaml.MappingNode:
+		var args SecretArgs
+		if err := value.Decode(&args); err != nil {
+			return fmt.Errorf("unmarshal secret: %w", err)
+		}
+		s.SecretString = nil
+		s.SecretArgs = args
+		return nil
+	default:
+		return errUnmarshalSecret
+	}
+}
+
+// MarshalYAML serializes the secret back into either its string or map form.
+// This method implements the yaml.Marshaler (v3) interface.
+func (s SecretArgsOrString) MarshalYAML() (interface{}, error) {
+	if s.SecretString != nil {
+		return *s.SecretString, nil
+	}
+	if s.SecretArgs.IsEmpty() {
+		return nil, nil
+	}
+	return s.SecretArgs, nil
+}
+
+// IsEmpty returns whether SecretArgsOrString is empty.
+func (s SecretArgsOrString) IsEmpty() bool {
+	return s.SecretString == nil && s.SecretArgs.IsEmpty()
+}
+
+// Args returns the secret in its map form, the string form being the "from" of the secret.
+func (s SecretArgsOrString) Args() SecretArgs {
+	if s.SecretString != nil {
+		return SecretArgs{From: s.SecretString}
+	}
+	return s.SecretArgs
+}
+
+// IsSecretsManager returns true if the secret is stored in Secrets Manager rather than in SSM Parameter Store.
+func (a SecretArgs) IsSecretsManager() bool {
+	parsed, err := arn.Parse(aws.StringValue(a.From))
+	return err == nil && parsed.Service == secretsManagerService
+}
+
+// ResourceARN returns the ARN of the SSM parameter or of the Secrets Manager secret without the selectors
+// of its value, or an empty string if the secret is the name of an SSM parameter in the account and region of the task.
+func (a SecretArgs) ResourceARN() string {
+	parsed, err := arn.Parse(aws.StringValue(a.From))
+	if err != nil {
+		return ""
+	}
+	if parsed.Service == secretsManagerService {
+		// The resource is "secret:name" optionally followed by ":json-key:version-stage:version-id".
+		parsed.Resource = strings.Join(strings.SplitN(parsed.Resource, ":", 3)[:2], ":")
+	}
+	return parsed.String()
+}
+
+// ValueFrom returns the reference to the secret in the format of the "valueFrom" of ECS task definition secrets.
+// The ARN of a Secrets Manager secret is followed by the JSON key, the version stage and the version ID if any are set.
+func (a SecretArgs) ValueFrom() string {
+	from := aws.StringValue(a.From)
+	if a.JSONKey == nil && a.VersionStage == nil && a.VersionID == nil {
+		return from
+	}
+	return strings.Join([]string{from, aws.StringValue(a.JSONKey), aws.StringValue(a.VersionStage), aws.StringValue(a.VersionID)}, ":")
+}
+
+// Validate returns an error if the secret can't be referenced by a container.
+func (a SecretArgs) Validate() error {
+	if a.From == nil {
+		return errors.New(`"from" is required`)
+	}
+	if reason := secretReason(*a.From); reason != "" {
+		return fmt.Errorf(`"from" %s`, reason)
+	}
+	hasSelectors := a.JSONKey != nil || a.VersionStage != nil || a.VersionID != nil
+	if !hasSelectors {
+		return nil
+	}
+	if !a.IsSecretsManager() {
+		return errors.New(`"json_key", "version_stage" and "version_id" are only supported by Secrets Manager secrets`)
+	}
+	if a.ResourceARN() != *a.From {
+		return errors.New(`"json_key", "version_stage" and "version_id" can't be set if "from" already selects the value of the secret`)
+	}
+	if a.VersionStage != nil && a.VersionID != nil {
+		return errors.New(`must specify one, not both, of "version_stage" and "version_id"`)
+	}
+	return nil
+}
+
+// secretReason returns why the value can't reference a secret, or an empty string if it can.
+func secretReason(from string) string {
+	if !strings.HasPrefix(from, "arn:") {
+		// The name of an SSM parameter in the account and region of the task.
+		return ""
+	}
+	parsed, err := arn.Parse(from)
+	switch {
+	case err != nil:
+		return "must be a valid ARN"
+	case parsed.Service == ssmService && strings.HasPrefix(parsed.Resource, ssmParameterResourcePrefix):
+		return ""
+	case parsed.Service == secretsManagerService && strings.HasPrefix(parsed.Resource, secretResourcePrefix):
+		return ""
+	default:
+		return "must be the name or ARN of an SSM parameter, or the ARN of a Secrets Manager secret"
+	}
+}
+
+func secretRule(value *yaml.Node) string {
+	return secretReason(value.Value)
+}
+
+func kmsKeyRule(value *yaml.Node) string {
+	if parsed, err := arn.Parse(value.Value); err != nil || parsed.Service != kmsService || !strings.HasPrefix(parsed.Resource, kmsKeyResourcePrefix) {
+		return "must be the ARN of a KMS key"
+	}
+	return ""
+}
@@ -0,0 +1,130 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package manifest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestSecretArgsOrString_UnmarshalYAML(t *testing.T) {
+	testCases := map[string]struct {
+		inContent []byte
+
+		wantedStruct SecretArgsOrString
+		wantedError  error
+	}{
+		"name of an SSM parameter": {
+			inContent:    []byte(`secret: /app/token`),
+			wantedStruct: SecretArgsOrString{SecretString: stringp("/app/token")},
+		},
+		"Secrets Manager secret with selectors": {
+			inContent: []byte(`secret:
+  from: arn:aws:secretsmanager:us-west-2:123456789012:secret:db-AbCdEf
+  json_key: username
+  version_stage: AWSPREVIOUS
+  kms_key: arn:aws:kms:us-west-2:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab`),
+			wantedStruct: SecretArgsOrString{
+				SecretArgs: SecretArgs{
+					From:         stringp("arn:aws:secretsmanager:us-west-2:123456789012:secret:db-AbCdEf"),
+					JSONKey:      stringp("username"),
+					VersionStage: stringp("AWSPREVIOUS"),
+					KMSKey:       stringp("arn:aws:kms:us-west-2:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab"),
+				},
+			},
+		},
+		"error if the secret is a list": {
+			inContent:   []byte(`secret: [/app/token]`),
+			wantedError: errUnmarshalSecret,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			var v struct {
+				Secret SecretArgsOrString `yaml:"secret"`
+			}
+
+			// WHEN
+			err := yaml.Unmarshal(tc.inContent, &v)
+
+			// THEN
+			if tc.wantedError != nil {
+				require.EqualError(t, err, tc.wantedError.Error())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.wantedStruct, v.Secret)
+		})
+	}
+}
+
+func TestSecretArgs(t *testing.T) {
+	testCases := map[string]struct {
+		in SecretArgs
+
+		wantedSecretsManager bool
+		wantedResourceARN    string
+		wantedValueFrom      string
+		wantedErr            error
+	}{
+		"name of an SSM parameter": {
+			in:              SecretArgs{From: stringp("GITHUB_TOKEN")},
+			wantedValueFrom: "GITHUB_TOKEN",
+		},
+		"ARN of an SSM parameter in another account": {
+			in:                SecretArgs{From: stringp("arn:aws:ssm:us-west-2:210987654321:parameter/api/key")},
+			wantedResourceARN: "arn:aws:ssm:us-west-2:210987654321:parameter/api/key",
+			wantedValueFrom:   "arn:aws:ssm:us-west-2:210987654321:parameter/api/key",
+		},
+		"ARN of a Secrets Manager secret followed by selectors": {
+			in:                   SecretArgs{From: stringp("arn:aws:secretsmanager:us-west-2:123456789012:secret:db-AbCdEf:password::")},
+			wantedSecretsManager: true,
+			wantedResourceARN:    "arn:aws:secretsmanager:us-west-2:123456789012:secret:db-AbCdEf",
+			wantedValueFrom:      "arn:aws:secretsmanager:us-west-2:123456789012:secret:db-AbCdEf:password::",
+		},
+		"Secrets Manager secret with a version id": {
+			in: SecretArgs{
+				From:      stringp("arn:aws:secretsmanager:us-west-2:123456789012:secret:db-AbCdEf"),
+				VersionID: stringp("EXAMPLE1-90ab-cdef-fedc-ba987EXAMPLE"),
+			},
+			wantedSecretsManager: true,
+			wantedResourceARN:    "arn:aws:secretsmanager:us-west-2:123456789012:secret:db-AbCdEf",
+			wantedValueFrom:      "arn:aws:secretsmanager:us-west-2:123456789012:secret:db-AbCdEf:::EXAMPLE1-90ab-cdef-fedc-ba987EXAMPLE",
+		},
+		"error if the ARN isn't a secret": {
+			in:                SecretArgs{From: stringp("arn:aws:s3:::bucket/password")},
+			wantedResourceARN: "arn:aws:s3:::bucket/password",
+			wantedValueFrom:   "arn:aws:s3:::bucket/password",
+			wantedErr:         errors.New(`"from" must be the name or ARN of an SSM parameter, or the ARN of a Secrets Manager secret`),
+		},
+		"error if the selectors are set twice": {
+			in: SecretArgs{
+				From:    stringp("arn:aws:secretsmanager:us-west-2:123456789012:secret:db-AbCdEf:password::"),
+				JSONKey: stringp("username"),
+			},
+			wantedSecretsManager: true,
+			wantedResourceARN:    "arn:aws:secretsmanager:us-west-2:123456789012:secret:db-AbCdEf",
+			wantedValueFrom:      "arn:aws:secretsmanager:us-west-2:123456789012:secret:db-AbCdEf:password:::username::",
+			wantedErr:            errors.New(`"json_key", "version_stage" and "version_id" can't be set if "from" already selects the value of the secret`),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.wantedSecretsManager, tc.in.IsSecretsManager())
+			require.Equal(t, tc.wantedResourceARN, tc.in.ResourceARN())
+			require.Equal(t, tc.wantedValueFrom, tc.in.ValueFrom())
+			if tc.wantedErr != nil {
+				require.EqualError(t, tc.in.Validate(), tc.wantedErr.Error())
+			} else {
+				require.NoError(t, tc.in.Validate())
+			}
+		})
+	}
+}
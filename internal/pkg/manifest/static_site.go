@@ -0,0 +1,95 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package manifest
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/template"
+)
+
+const (
+	staticSiteManifestPath = "services/static-site/manifest.yml"
+)
+
+var (
+	errStaticSiteWithoutPath = errors.New(`"path" must be the directory of the files of the site`)
+)
+
+// StaticSiteProps represents the configuration needed to create a static site.
+type StaticSiteProps struct {
+	Name string
+	Path string // Directory of the files of the site relative to the root of the workspace.
+}
+
+// StaticSite holds the configuration to serve the files of a local directory from an S3 bucket through a CloudFront distribution.
+type StaticSite struct {
+	Service      `yaml:",inline"`
+	Path         string                              `yaml:"path"`  // Directory of the files of the site relative to the root of the workspace.
+	Alias        *string                             `yaml:"alias"` // Domain name in the application's hosted zone that resolves to the distribution.
+	Environments map[string]staticSiteOverrideConfig `yaml:",flow"`
+
+	envNulls map[string][][]string // Paths of the fields set to null in each environment override.
+	parser   template.Parser
+}
+
+type staticSiteOverrideConfig struct {
+	Path  string  `yaml:"path"`
+	Alias *string `yaml:"alias"`
+}
+
+// NewStaticSite applies the props to a default static site configuration and returns it.
+func NewStaticSite(props StaticSiteProps) *StaticSite {
+	site := newDefaultStaticSite()
+	// Apply overrides.
+	site.Name = props.Name
+	site.Version = LatestServiceManifestVersion()
+	site.Path = props.Path
+	site.parser = template.New()
+	return site
+}
+
+func newDefaultStaticSite() *StaticSite {
+	return &StaticSite{
+		Service: Service{
+			Type: StaticSiteType,
+		},
+	}
+}
+
+// MarshalBinary serializes the manifest object into a binary YAML document.
+// Implements the encoding.BinaryMarshaler interface.
+func (s *StaticSite) MarshalBinary() ([]byte, error) {
+	content, err := s.parser.Parse(staticSiteManifestPath, *s)
+	if err != nil {
+		return nil, err
+	}
+	return content.Bytes(), nil
+}
+
+// ApplyEnv returns the static site manifest with environment overrides.
+// If the environment passed in does not have any overrides then it returns itself.
+func (s *StaticSite) ApplyEnv(envName string) *StaticSite {
+	target, ok := s.Environments[envName]
+	if !ok {
+		return s
+	}
+	out := &StaticSite{}
+	applyEnvOverride(out, s, target, s.envNulls[envName])
+	return out
+}
+
+// validate returns an error if the manifest or any of its environment overrides are invalid.
+func (s *StaticSite) validate() error {
+	if s.Path == "" {
+		return errStaticSiteWithoutPath
+	}
+	for env := range s.Environments {
+		if s.ApplyEnv(env).Path == "" {
+			return fmt.Errorf("environment %s: %w", env, errStaticSiteWithoutPath)
+		}
+	}
+	return nil
+}
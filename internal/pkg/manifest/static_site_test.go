@@ -0,0 +1,58 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package manifest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStaticSite_ApplyEnv(t *testing.T) {
+	testCases := map[string]struct {
+		in      *StaticSite
+		envName string
+
+		wanted *StaticSite
+	}{
+		"without overrides": {
+			in: &StaticSite{
+				Service: Service{Name: "website", Type: StaticSiteType},
+				Path:    "dist",
+			},
+			envName: "test",
+
+			wanted: &StaticSite{
+				Service: Service{Name: "website", Type: StaticSiteType},
+				Path:    "dist",
+			},
+		},
+		"with overrides": {
+			in: &StaticSite{
+				Service: Service{Name: "website", Type: StaticSiteType},
+				Path:    "dist",
+				Alias:   stringp("www.phonetool.example.com"),
+				Environments: map[string]staticSiteOverrideConfig{
+					"test": {
+						Path:  "dist/test",
+						Alias: stringp("test.phonetool.example.com"),
+					},
+				},
+			},
+			envName: "test",
+
+			wanted: &StaticSite{
+				Service: Service{Name: "website", Type: StaticSiteType},
+				Path:    "dist/test",
+				Alias:   stringp("test.phonetool.example.com"),
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.wanted, tc.in.ApplyEnv(tc.envName))
+		})
+	}
+}
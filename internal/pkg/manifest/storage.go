@@ -0,0 +1,122 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package manifest
+
+import (
+	"errors"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	errUnmarshalEFSOpts = errors.New(`unable to unmarshal "efs" field into a boolean or a map`)
+)
+
+// Storage represents the file systems that can be mounted into the containers of the task.
+type Storage struct {
+	Volumes map[string]Volume `yaml:"volumes"`
+}
+
+// IsEmpty returns whether Storage is empty.
+func (s Storage) IsEmpty() bool {
+	return len(s.Volumes) == 0
+}
+
+// Volume represents a file system that can be mounted into the containers of the task.
+type Volume struct {
+	EFS            EFSConfigOrBool  `yaml:"efs"`
+	MountPointOpts `yaml:",inline"` // Where to mount the volume in the main container, if anywhere.
+}
+
+// MountPointOpts holds where and how a volume is mounted into a container.
+type MountPointOpts struct {
+	ContainerPath *string `yaml:"path"`
+	ReadOnly      *bool   `yaml:"read_only"` // Defaults to true.
+}
+
+// SidecarMountPoint represents a volume mounted into a sidecar container.
+type SidecarMountPoint struct {
+	SourceVolume   *string `yaml:"source_volume"` // Name of a volume under "storage.volumes".
+	MountPointOpts `yaml:",inline"`
+}
+
+// EFSConfigOrBool is a custom type which supports unmarshaling yaml which
+// can either be of type bool or type EFSVolumeConfiguration.
+// "true" means that the file system is created and managed by Copilot.
+type EFSConfigOrBool struct {
+	Enabled  *bool
+	Advanced EFSVolumeConfiguration // Mutually exclusive with Enabled.
+}
+
+// EFSVolumeConfiguration holds options for mounting an existing EFS file system.
+type EFSVolumeConfiguration struct {
+	FileSystemID  *string             `yaml:"id,omitempty"`
+	RootDirectory *string             `yaml:"root_dir,omitempty"`
+	AuthConfig    AuthorizationConfig `yaml:"auth,omitempty"`
+}
+
+// IsEmpty returns whether EFSVolumeConfiguration is empty.
+func (e EFSVolumeConfiguration) IsEmpty() bool {
+	return e.FileSystemID == nil && e.RootDirectory == nil && e.AuthConfig.IsEmpty()
+}
+
+// AuthorizationConfig holds options for authorizing the task with an EFS file system.
+type AuthorizationConfig struct {
+	IAM           *bool   `yaml:"iam,omitempty"` // Whether to authorize with the task role.
+	AccessPointID *string `yaml:"access_point_id,omitempty"`
+}
+
+// IsEmpty returns whether AuthorizationConfig is empty.
+func (a AuthorizationConfig) IsEmpty() bool {
+	return a.IAM == nil && a.AccessPointID == nil
+}
+
+// UnmarshalYAML overrides the default YAML unmarshaling logic for the EFSConfigOrBool
+// struct, allowing it to perform more complex unmarshaling behavior.
+// This method implements the yaml.Unmarshaler (v3) interface.
+func (e *EFSConfigOrBool) UnmarshalYAML(value *yaml.Node) error {
+	switch value.Kind {
+	case yaml.ScalarNode:
+		var enabled bool
+		if err := value.Decode(&enabled); err != nil {
+			return errUnmarshalEFSOpts
+		}
+		e.Enabled = &enabled
+		e.Advanced = EFSVolumeConfiguration{}
+		return nil
+	case yaml.MappingNode:
+		var conf EFSVolumeConfiguration
+		if err := value.Decode(&conf); err != nil {
+			return fmt.Errorf("unmarshal efs configuration: %w", err)
+		}
+		e.Enabled = nil
+		e.Advanced = conf
+		return nil
+	default:
+		return errUnmarshalEFSOpts
+	}
+}
+
+// MarshalYAML serializes the efs field back into either its boolean or map form.
+// This method implements the yaml.Marshaler (v3) interface.
+func (e EFSConfigOrBool) MarshalYAML() (interface{}, error) {
+	if e.Enabled != nil {
+		return *e.Enabled, nil
+	}
+	if e.Advanced.IsEmpty() {
+		return nil, nil
+	}
+	return e.Advanced, nil
+}
+
+// IsEmpty returns whether EFSConfigOrBool is empty.
+func (e EFSConfigOrBool) IsEmpty() bool {
+	return e.Enabled == nil && e.Advanced.IsEmpty()
+}
+
+// UseManagedFS returns true if the file system should be created and managed by Copilot.
+func (e EFSConfigOrBool) UseManagedFS() bool {
+	return e.Enabled != nil && *e.Enabled
+}
@@ -0,0 +1,218 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package manifest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestStorage_UnmarshalYAML(t *testing.T) {
+	testCases := map[string]struct {
+		inContent []byte
+
+		wantedStruct Storage
+		wantedError  error
+	}{
+		"file system managed by Copilot": {
+			inContent: []byte(`volumes:
+  data:
+    efs: true
+    path: /var/data
+    read_only: false`),
+			wantedStruct: Storage{
+				Volumes: map[string]Volume{
+					"data": {
+						EFS: EFSConfigOrBool{
+							Enabled: boolp(true),
+						},
+						MountPointOpts: MountPointOpts{
+							ContainerPath: stringp("/var/data"),
+							ReadOnly:      boolp(false),
+						},
+					},
+				},
+			},
+		},
+		"existing file system with an access point": {
+			inContent: []byte(`volumes:
+  shared:
+    efs:
+      id: fs-1234
+      root_dir: /
+      auth:
+        iam: true
+        access_point_id: fsap-1234
+    path: /etc/shared`),
+			wantedStruct: Storage{
+				Volumes: map[string]Volume{
+					"shared": {
+						EFS: EFSConfigOrBool{
+							Advanced: EFSVolumeConfiguration{
+								FileSystemID:  stringp("fs-1234"),
+								RootDirectory: stringp("/"),
+								AuthConfig: AuthorizationConfig{
+									IAM:           boolp(true),
+									AccessPointID: stringp("fsap-1234"),
+								},
+							},
+						},
+						MountPointOpts: MountPointOpts{
+							ContainerPath: stringp("/etc/shared"),
+						},
+					},
+				},
+			},
+		},
+		"error if efs is a list": {
+			inContent: []byte(`volumes:
+  data:
+    efs: [fs-1234]`),
+			wantedError: errUnmarshalEFSOpts,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			var s Storage
+
+			// WHEN
+			err := yaml.Unmarshal(tc.inContent, &s)
+
+			// THEN
+			if tc.wantedError != nil {
+				require.EqualError(t, err, tc.wantedError.Error())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.wantedStruct, s)
+		})
+	}
+}
+
+func TestStorage_applyOverride(t *testing.T) {
+	testCases := map[string]struct {
+		inStorage Storage
+		inOther   Storage
+
+		wanted Storage
+	}{
+		"no overrides": {
+			inStorage: Storage{
+				Volumes: map[string]Volume{
+					"data": {
+						EFS: EFSConfigOrBool{
+							Enabled: boolp(true),
+						},
+					},
+				},
+			},
+			wanted: Storage{
+				Volumes: map[string]Volume{
+					"data": {
+						EFS: EFSConfigOrBool{
+							Enabled: boolp(true),
+						},
+					},
+				},
+			},
+		},
+		"overrides volumes field by field and adds new volumes": {
+			inStorage: Storage{
+				Volumes: map[string]Volume{
+					"data": {
+						EFS: EFSConfigOrBool{
+							Enabled: boolp(true),
+						},
+						MountPointOpts: MountPointOpts{
+							ContainerPath: stringp("/var/data"),
+						},
+					},
+				},
+			},
+			inOther: Storage{
+				Volumes: map[string]Volume{
+					"data": {
+						MountPointOpts: MountPointOpts{
+							ReadOnly: boolp(false),
+						},
+					},
+					"shared": {
+						EFS: EFSConfigOrBool{
+							Advanced: EFSVolumeConfiguration{
+								FileSystemID: stringp("fs-1234"),
+							},
+						},
+					},
+				},
+			},
+			wanted: Storage{
+				Volumes: map[string]Volume{
+					"data": {
+						EFS: EFSConfigOrBool{
+							Enabled: boolp(true),
+						},
+						MountPointOpts: MountPointOpts{
+							ContainerPath: stringp("/var/data"),
+							ReadOnly:      boolp(false),
+						},
+					},
+					"shared": {
+						EFS: EFSConfigOrBool{
+							Advanced: EFSVolumeConfiguration{
+								FileSystemID: stringp("fs-1234"),
+							},
+						},
+					},
+				},
+			},
+		},
+		"overriding the file system replaces it": {
+			inStorage: Storage{
+				Volumes: map[string]Volume{
+					"data": {
+						EFS: EFSConfigOrBool{
+							Enabled: boolp(true),
+						},
+					},
+				},
+			},
+			inOther: Storage{
+				Volumes: map[string]Volume{
+					"data": {
+						EFS: EFSConfigOrBool{
+							Advanced: EFSVolumeConfiguration{
+								FileSystemID: stringp("fs-1234"),
+							},
+						},
+					},
+				},
+			},
+			wanted: Storage{
+				Volumes: map[string]Volume{
+					"data": {
+						EFS: EFSConfigOrBool{
+							Advanced: EFSVolumeConfiguration{
+								FileSystemID: stringp("fs-1234"),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// WHEN
+			got := applyOverride(tc.inStorage, tc.inOther)
+
+			// THEN
+			require.Equal(t, tc.wanted, got)
+		})
+	}
+}
@@ -5,34 +5,168 @@
 package manifest
 
 import (
+	"errors"
 	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"time"
+	"unicode"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"gopkg.in/yaml.v3"
 )
 
+const (
+	defaultDockerfileName = "Dockerfile"
+)
+
 const (
 	// LoadBalancedWebServiceType is a web service with a load balancer and Fargate as compute.
 	LoadBalancedWebServiceType = "Load Balanced Web Service"
 	// BackendServiceType is a service that cannot be accessed from the internet but can be reached from other services.
 	BackendServiceType = "Backend Service"
+	// WorkerServiceType is a service that consumes messages from a queue and cannot be reached by other services.
+	WorkerServiceType = "Worker Service"
+	// ScheduledJobType is a job that runs a task to completion on a schedule.
+	ScheduledJobType = "Scheduled Job"
+	// StaticSiteType is a website whose files are served from an S3 bucket through a CloudFront distribution.
+	StaticSiteType = "Static Site"
 )
 
 // ServiceTypes are the supported service manifest types.
 var ServiceTypes = []string{
 	LoadBalancedWebServiceType,
 	BackendServiceType,
+	WorkerServiceType,
+	ScheduledJobType,
+	StaticSiteType,
 }
 
 // Service holds the basic data that every service manifest file needs to have.
 type Service struct {
-	Name string `yaml:"name"`
-	Type string `yaml:"type"` // must be one of the supported manifest types.
+	Name    string `yaml:"name"`
+	Type    string `yaml:"type"`    // must be one of the supported manifest types.
+	Version int    `yaml:"version"` // Version of the schema of the manifest, manifests without a version are at version 1.
 }
 
+var (
+	errImageBuildAndLocation = errors.New(`must specify one, not both, of "build" and "location" for "image"`)
+	errUnmarshalBuildOpts    = errors.New(`unable to unmarshal "build" field into a string or compose-style map`)
+	errUnmarshalStringSlice  = errors.New(`unable to unmarshal field into a string or a list of strings`)
+)
+
 // ServiceImage represents the service's container image.
 type ServiceImage struct {
-	Build string `yaml:"build"` // Path to the Dockerfile.
+	Build    BuildArgsOrString `yaml:"build"`    // Path to the Dockerfile or the docker build options.
+	Location string            `yaml:"location"` // URI of an existing image, mutually exclusive with Build.
+}
+
+// BuildConfig returns the docker build options of the image with default values applied.
+// If only a Dockerfile is specified, the build context is the Dockerfile's directory.
+// If only a context is specified, the Dockerfile is expected at the root of the context.
+func (i ServiceImage) BuildConfig() DockerBuildArgs {
+	if i.Build.BuildString != nil {
+		return DockerBuildArgs{
+			Dockerfile: stringp(*i.Build.BuildString),
+			Context:    stringp(filepath.Dir(*i.Build.BuildString)),
+		}
+	}
+	args := i.Build.BuildArgs
+	dockerfile := filepath.Join(aws.StringValue(args.Context), defaultDockerfileName)
+	if args.Dockerfile != nil {
+		dockerfile = *args.Dockerfile
+	}
+	context := filepath.Dir(dockerfile)
+	if args.Context != nil {
+		context = *args.Context
+	}
+	return DockerBuildArgs{
+		Dockerfile: stringp(dockerfile),
+		Context:    stringp(context),
+		Target:     args.Target,
+		Args:       args.Args,
+		CacheFrom:  args.CacheFrom,
+	}
+}
+
+func (i ServiceImage) validate() error {
+	if !i.Build.IsEmpty() && i.Location != "" {
+		return errImageBuildAndLocation
+	}
+	return nil
+}
+
+// ImageOverride holds the fields that override the ENTRYPOINT and CMD of the main container's image.
+type ImageOverride struct {
+	EntryPoint StringSliceOrString `yaml:"entrypoint"`
+	Command    StringSliceOrString `yaml:"command"`
+}
+
+// BuildArgsOrString is a custom type which supports unmarshaling yaml which
+// can either be of type string or type DockerBuildArgs.
+type BuildArgsOrString struct {
+	BuildString *string
+	BuildArgs   DockerBuildArgs // Mutually exclusive with BuildString.
+}
+
+// DockerBuildArgs represents the options specifiable under the "build" field of
+// Docker Compose services. For more information, see:
+// https://docs.docker.com/compose/compose-file/#build
+type DockerBuildArgs struct {
+	Dockerfile *string           `yaml:"dockerfile,omitempty"`
+	Context    *string           `yaml:"context,omitempty"`
+	Target     *string           `yaml:"target,omitempty"`
+	Args       map[string]string `yaml:"args,omitempty"`
+	CacheFrom  []string          `yaml:"cache_from,omitempty"`
+}
+
+// IsEmpty returns whether DockerBuildArgs is empty.
+func (a DockerBuildArgs) IsEmpty() bool {
+	return a.Dockerfile == nil && a.Context == nil && a.Target == nil && a.Args == nil && a.CacheFrom == nil
+}
+
+// UnmarshalYAML overrides the default YAML unmarshaling logic for the BuildArgsOrString
+// struct, allowing it to perform more complex unmarshaling behavior.
+// This method implements the yaml.Unmarshaler (v3) interface.
+func (b *BuildArgsOrString) UnmarshalYAML(value *yaml.Node) error {
+	switch value.Kind {
+	case yaml.ScalarNode:
+		var s string
+		if err := value.Decode(&s); err != nil {
+			return errUnmarshalBuildOpts
+		}
+		b.BuildString = &s
+		b.BuildArgs = DockerBuildArgs{}
+		return nil
+	case yaml.MappingNode:
+		var args DockerBuildArgs
+		if err := value.Decode(&args); err != nil {
+			return fmt.Errorf("unmarshal build options: %w", err)
+		}
+		b.BuildString = nil
+		b.BuildArgs = args
+		return nil
+	default:
+		return errUnmarshalBuildOpts
+	}
+}
+
+// MarshalYAML serializes the build field back into either its string or map form.
+// This method implements the yaml.Marshaler (v3) interface.
+func (b BuildArgsOrString) MarshalYAML() (interface{}, error) {
+	if b.BuildString != nil {
+		return *b.BuildString, nil
+	}
+	if b.BuildArgs.IsEmpty() {
+		return nil, nil
+	}
+	return b.BuildArgs, nil
+}
+
+// IsEmpty returns whether BuildArgsOrString is empty.
+func (b BuildArgsOrString) IsEmpty() bool {
+	return b.BuildString == nil && b.BuildArgs.IsEmpty()
 }
 
 // ServiceImageWithPort represents a container image with an exposed port.
@@ -46,87 +180,234 @@ type Sidecar struct {
 	Sidecars map[string]SidecarConfig `yaml:"sidecars"`
 }
 
+// Conditions that a container can depend on another container for.
+// See https://docs.aws.amazon.com/AmazonECS/latest/APIReference/API_ContainerDependency.html
+var dependsOnConditions = []string{"START", "COMPLETE", "SUCCESS", "HEALTHY"}
+
 // SidecarConfig represents the configurable options for setting up a sidecar container.
 type SidecarConfig struct {
-	Port      string `yaml:"port"`
-	Image     string `yaml:"image"`
-	CredParam string `yaml:"credentialsParameter"`
+	Port        string                        `yaml:"port"`
+	Image       string                        `yaml:"image"`
+	CredParam   string                        `yaml:"credentialsParameter"`
+	Essential   *bool                         `yaml:"essential"` // Defaults to true.
+	Variables   map[string]string             `yaml:"variables"`
+	EnvFile     *string                       `yaml:"env_file"` // Path to a .env file relative to the root of the workspace.
+	Secrets     map[string]SecretArgsOrString `yaml:"secrets"`
+	DependsOn   map[string]string             `yaml:"depends_on"` // Container name to the condition, for example "START".
+	Command     StringSliceOrString           `yaml:"command"`
+	EntryPoint  StringSliceOrString           `yaml:"entrypoint"`
+	HealthCheck *ContainerHealthCheck         `yaml:"healthcheck"`
+	MountPoints []SidecarMountPoint           `yaml:"mount_points"`
 }
 
-func (s Sidecar) copyAndApply(other Sidecar) Sidecar {
-	// TODO: abstract away copyandApply and deepCopy.
-	override := s.deepcopy()
-	for k, v := range other.Sidecars {
-		config := override.Sidecars[k]
-		if v.CredParam != "" {
-			config.CredParam = v.CredParam
+// envFiles returns the paths of the environment files of the main container and of the sidecars, keyed by the container name.
+func envFiles(name string, tc TaskConfig, sidecars map[string]SidecarConfig) map[string]string {
+	files := make(map[string]string)
+	if tc.EnvFile != nil {
+		files[name] = *tc.EnvFile
+	}
+	for sidecarName, sidecar := range sidecars {
+		if sidecar.EnvFile != nil {
+			files[sidecarName] = *sidecar.EnvFile
 		}
-		if v.Image != "" {
-			config.Image = v.Image
+	}
+	if len(files) == 0 {
+		return nil
+	}
+	return files
+}
+
+// StringSliceOrString is a custom type which supports unmarshaling yaml which
+// can either be of type string or type slice of strings, such as the "command" of a container.
+type StringSliceOrString struct {
+	String      *string
+	StringSlice []string // Mutually exclusive with String.
+}
+
+// UnmarshalYAML overrides the default YAML unmarshaling logic for the StringSliceOrString
+// struct, allowing it to perform more complex unmarshaling behavior.
+// This method implements the yaml.Unmarshaler (v3) interface.
+func (s *StringSliceOrString) UnmarshalYAML(value *yaml.Node) error {
+	switch value.Kind {
+	case yaml.ScalarNode:
+		var str string
+		if err := value.Decode(&str); err != nil {
+			return errUnmarshalStringSlice
+		}
+		if _, err := splitCommand(str); err != nil {
+			return err
 		}
-		if v.Port != "" {
-			config.Port = v.Port
+		s.String = &str
+		s.StringSlice = nil
+		return nil
+	case yaml.SequenceNode:
+		var slice []string
+		if err := value.Decode(&slice); err != nil {
+			return errUnmarshalStringSlice
 		}
-		override.Sidecars[k] = config
+		s.String = nil
+		s.StringSlice = slice
+		return nil
+	default:
+		return errUnmarshalStringSlice
 	}
-	return override
 }
 
-func (s Sidecar) deepcopy() Sidecar {
-	config := make(map[string]SidecarConfig, len(s.Sidecars))
-	for k, v := range s.Sidecars {
-		config[k] = v
-	}
-	return Sidecar{
-		Sidecars: config,
+// MarshalYAML serializes the field back into either its string or list form.
+// This method implements the yaml.Marshaler (v3) interface.
+func (s StringSliceOrString) MarshalYAML() (interface{}, error) {
+	if s.String != nil {
+		return *s.String, nil
 	}
+	return s.StringSlice, nil
 }
 
-// TaskConfig represents the resource boundaries and environment variables for the containers in the task.
-type TaskConfig struct {
-	CPU       int               `yaml:"cpu"`
-	Memory    int               `yaml:"memory"`
-	Count     *int              `yaml:"count"` // 0 is a valid value, so we want the default value to be nil.
-	Variables map[string]string `yaml:"variables"`
-	Secrets   map[string]string `yaml:"secrets"`
+// IsEmpty returns whether StringSliceOrString is empty.
+func (s StringSliceOrString) IsEmpty() bool {
+	return s.String == nil && s.StringSlice == nil
 }
 
-func (tc TaskConfig) copyAndApply(other TaskConfig) TaskConfig {
-	override := tc.deepcopy()
-	if other.CPU != 0 {
-		override.CPU = other.CPU
+// ToStringSlice returns the value as a list of arguments.
+// The string form is split on whitespace like a shell would, honoring quotes.
+func (s StringSliceOrString) ToStringSlice() []string {
+	if s.String != nil {
+		args, _ := splitCommand(*s.String) // The string is validated while unmarshaling.
+		return args
 	}
-	if other.Memory != 0 {
-		override.Memory = other.Memory
+	return s.StringSlice
+}
+
+// splitCommand splits the command into arguments on whitespace, like a shell would.
+// Whitespace inside single or double quotes and characters escaped with a backslash are kept.
+func splitCommand(cmd string) ([]string, error) {
+	var args []string
+	var arg strings.Builder
+	var inArg, escaped bool
+	var quote rune
+	for _, r := range cmd {
+		switch {
+		case escaped:
+			arg.WriteRune(r)
+			escaped = false
+		case r == '\\' && quote != '\'':
+			escaped, inArg = true, true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				arg.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote, inArg = r, true
+		case unicode.IsSpace(r):
+			if inArg {
+				args = append(args, arg.String())
+				arg.Reset()
+				inArg = false
+			}
+		default:
+			arg.WriteRune(r)
+			inArg = true
+		}
 	}
-	if other.Count != nil {
-		override.Count = intp(*other.Count)
+	if quote != 0 || escaped {
+		return nil, fmt.Errorf("unterminated quote or escape in command %q", cmd)
 	}
-	for k, v := range other.Variables {
-		override.Variables[k] = v
+	if inArg {
+		args = append(args, arg.String())
 	}
-	for k, v := range other.Secrets {
-		override.Secrets[k] = v
+	return args, nil
+}
+
+var (
+	errUnmarshalCount = errors.New(`unable to unmarshal "count" field to an integer or autoscaling configuration`)
+)
+
+// TaskConfig represents the resource boundaries and environment variables for the containers in the task.
+type TaskConfig struct {
+	CPU       int                           `yaml:"cpu"`
+	Memory    int                           `yaml:"memory"`
+	Count     Count                         `yaml:"count"`
+	Variables map[string]string             `yaml:"variables"`
+	EnvFile   *string                       `yaml:"env_file"` // Path to a .env file relative to the root of the workspace.
+	Secrets   map[string]SecretArgsOrString `yaml:"secrets"`
+	Storage   Storage                       `yaml:"storage"`
+}
+
+// Count is a custom type which supports unmarshaling yaml which
+// can either be of type int or type Autoscaling.
+type Count struct {
+	Value       *int        // 0 is a valid value, so we want the default value to be nil.
+	Autoscaling Autoscaling // Mutually exclusive with Value.
+}
+
+// UnmarshalYAML overrides the default YAML unmarshaling logic for the Count
+// struct, allowing it to perform more complex unmarshaling behavior.
+// This method implements the yaml.Unmarshaler (v3) interface.
+func (c *Count) UnmarshalYAML(value *yaml.Node) error {
+	switch value.Kind {
+	case yaml.ScalarNode:
+		var v int
+		if err := value.Decode(&v); err != nil {
+			return errUnmarshalCount
+		}
+		c.Value = &v
+		c.Autoscaling = Autoscaling{}
+		return nil
+	case yaml.MappingNode:
+		var a Autoscaling
+		if err := value.Decode(&a); err != nil {
+			return fmt.Errorf("unmarshal autoscaling configuration: %w", err)
+		}
+		c.Value = nil
+		c.Autoscaling = a
+		return nil
+	default:
+		return errUnmarshalCount
 	}
-	return override
 }
 
-func (tc TaskConfig) deepcopy() TaskConfig {
-	vars := make(map[string]string, len(tc.Variables))
-	for k, v := range tc.Variables {
-		vars[k] = v
+// IsEmpty returns whether Count is empty.
+func (c Count) IsEmpty() bool {
+	return c.Value == nil && c.Autoscaling.IsEmpty()
+}
+
+// Autoscaling represents the configurable options for Auto Scaling.
+type Autoscaling struct {
+	Range      Range `yaml:"range"`
+	CPU        *int  `yaml:"cpu_percentage"`
+	Memory     *int  `yaml:"memory_percentage"`
+	Requests   *int  `yaml:"requests"`
+	QueueDepth *int  `yaml:"queue_depth"` // Target number of visible messages in the service's queue per running task.
+}
+
+// IsEmpty returns whether Autoscaling is empty.
+func (a Autoscaling) IsEmpty() bool {
+	return a.Range == "" && a.CPU == nil && a.Memory == nil && a.Requests == nil && a.QueueDepth == nil
+}
+
+// Range is a number range with maximum and minimum values, for example "1-10".
+type Range string
+
+// Parse parses Range string and returns the min and max values.
+// For example: 1-100 returns 1 and 100.
+func (r Range) Parse() (min int, max int, err error) {
+	minMax := strings.Split(string(r), "-")
+	if len(minMax) != 2 {
+		return 0, 0, fmt.Errorf("invalid range value %s. Should be in format of ${min}-${max}", string(r))
+	}
+	min, err = strconv.Atoi(strings.TrimSpace(minMax[0]))
+	if err != nil {
+		return 0, 0, fmt.Errorf("cannot convert minimum value %s to integer", minMax[0])
 	}
-	secrets := make(map[string]string, len(tc.Secrets))
-	for k, v := range tc.Secrets {
-		secrets[k] = v
+	max, err = strconv.Atoi(strings.TrimSpace(minMax[1]))
+	if err != nil {
+		return 0, 0, fmt.Errorf("cannot convert maximum value %s to integer", minMax[1])
 	}
-	return TaskConfig{
-		CPU:       tc.CPU,
-		Memory:    tc.Memory,
-		Count:     intp(*tc.Count),
-		Variables: vars,
-		Secrets:   secrets,
+	if min > max {
+		return 0, 0, fmt.Errorf("minimum value %d cannot be larger than maximum value %d", min, max)
 	}
+	return min, max, nil
 }
 
 // ServiceProps contains properties for creating a new service manifest.
@@ -136,30 +417,92 @@ type ServiceProps struct {
 }
 
 // UnmarshalService deserializes the YAML input stream into a service manifest object.
+// References to environment variables in the values, such as "${VAR}" or "${VAR:-default}", are expanded first.
+// Manifests at an older schema version are upgraded to the latest version before they're deserialized.
 // If an error occurs during deserialization, then returns the error.
 // If the service type in the manifest is invalid, then returns an ErrInvalidManifestType.
 func UnmarshalService(in []byte) (interface{}, error) {
+	doc, err := unmarshalInterpolated(in)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal to service manifest: %w", err)
+	}
+	if _, err := migrateServiceManifest(doc.Content[0]); err != nil {
+		return nil, fmt.Errorf("unmarshal to service manifest: %w", err)
+	}
 	am := Service{}
-	if err := yaml.Unmarshal(in, &am); err != nil {
+	if err := doc.Decode(&am); err != nil {
 		return nil, fmt.Errorf("unmarshal to service manifest: %w", err)
 	}
 
 	switch am.Type {
 	case LoadBalancedWebServiceType:
 		m := newDefaultLoadBalancedWebService()
-		if err := yaml.Unmarshal(in, m); err != nil {
+		if err := validateSchema(doc, m); err != nil {
+			return nil, fmt.Errorf("validate load balanced web service: %w", err)
+		}
+		if err := unmarshalStrict(doc, m); err != nil {
 			return nil, fmt.Errorf("unmarshal to load balanced web service: %w", err)
 		}
+		m.envNulls = environmentNulls(doc)
+		if err := m.validate(); err != nil {
+			return nil, fmt.Errorf("validate load balanced web service: %w", err)
+		}
 		return m, nil
 	case BackendServiceType:
 		m := newDefaultBackendService()
-		if err := yaml.Unmarshal(in, m); err != nil {
+		if err := validateSchema(doc, m); err != nil {
+			return nil, fmt.Errorf("validate backend service: %w", err)
+		}
+		if err := unmarshalStrict(doc, m); err != nil {
 			return nil, fmt.Errorf("unmarshal to backend service: %w", err)
 		}
+		m.envNulls = environmentNulls(doc)
 		if m.Image.HealthCheck != nil {
 			// Make sure that unset fields in the healthcheck gets a default value.
 			m.Image.HealthCheck.applyIfNotSet(newDefaultContainerHealthCheck())
 		}
+		if err := m.validate(); err != nil {
+			return nil, fmt.Errorf("validate backend service: %w", err)
+		}
+		return m, nil
+	case WorkerServiceType:
+		m := newDefaultWorkerService()
+		if err := validateSchema(doc, m); err != nil {
+			return nil, fmt.Errorf("validate worker service: %w", err)
+		}
+		if err := unmarshalStrict(doc, m); err != nil {
+			return nil, fmt.Errorf("unmarshal to worker service: %w", err)
+		}
+		m.envNulls = environmentNulls(doc)
+		if err := m.validate(); err != nil {
+			return nil, fmt.Errorf("validate worker service: %w", err)
+		}
+		return m, nil
+	case ScheduledJobType:
+		m := newDefaultScheduledJob()
+		if err := validateSchema(doc, m); err != nil {
+			return nil, fmt.Errorf("validate scheduled job: %w", err)
+		}
+		if err := unmarshalStrict(doc, m); err != nil {
+			return nil, fmt.Errorf("unmarshal to scheduled job: %w", err)
+		}
+		m.envNulls = environmentNulls(doc)
+		if err := m.validate(); err != nil {
+			return nil, fmt.Errorf("validate scheduled job: %w", err)
+		}
+		return m, nil
+	case StaticSiteType:
+		m := newDefaultStaticSite()
+		if err := validateSchema(doc, m); err != nil {
+			return nil, fmt.Errorf("validate static site: %w", err)
+		}
+		if err := unmarshalStrict(doc, m); err != nil {
+			return nil, fmt.Errorf("unmarshal to static site: %w", err)
+		}
+		m.envNulls = environmentNulls(doc)
+		if err := m.validate(); err != nil {
+			return nil, fmt.Errorf("validate static site: %w", err)
+		}
 		return m, nil
 	default:
 		return nil, &ErrInvalidSvcManifestType{Type: am.Type}
@@ -170,6 +513,18 @@ func intp(v int) *int {
 	return &v
 }
 
+func stringp(v string) *string {
+	return &v
+}
+
+func boolp(v bool) *bool {
+	return &v
+}
+
 func durationp(v time.Duration) *time.Duration {
 	return &v
 }
+
+func uint16p(v uint16) *uint16 {
+	return &v
+}
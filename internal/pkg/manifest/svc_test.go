@@ -4,10 +4,12 @@
 package manifest
 
 import (
+	"errors"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
 )
 
 func TestUnmarshalSvc(t *testing.T) {
@@ -19,7 +21,6 @@ func TestUnmarshalSvc(t *testing.T) {
 	}{
 		"load balanced web service": {
 			inContent: `
-version: 1.0
 name: frontend
 type: "Load Balanced Web Service"
 image:
@@ -48,10 +49,12 @@ environments:
 				require.True(t, ok)
 				wantedManifest := &LoadBalancedWebService{
 					Service: Service{Name: "frontend", Type: LoadBalancedWebServiceType},
-					Image:   ServiceImageWithPort{ServiceImage: ServiceImage{Build: "frontend/Dockerfile"}, Port: 80},
+					Image:   ServiceImageWithPort{ServiceImage: ServiceImage{Build: BuildArgsOrString{BuildString: stringp("frontend/Dockerfile")}}, Port: 80},
 					RoutingRule: RoutingRule{
-						Path:            "svc",
-						HealthCheckPath: "/",
+						Path: "svc",
+						HealthCheck: HealthCheckArgsOrString{
+							HealthCheckPath: stringp("/"),
+						},
 					},
 					LogsConfig: LogsConfig{
 						LogRetention: 30,
@@ -59,12 +62,12 @@ environments:
 					TaskConfig: TaskConfig{
 						CPU:    512,
 						Memory: 1024,
-						Count:  intp(1),
+						Count:  Count{Value: intp(1)},
 						Variables: map[string]string{
 							"LOG_LEVEL": "WARN",
 						},
-						Secrets: map[string]string{
-							"DB_PASSWORD": "MYSQL_DB_PASSWORD",
+						Secrets: map[string]SecretArgsOrString{
+							"DB_PASSWORD": {SecretString: stringp("MYSQL_DB_PASSWORD")},
 						},
 					},
 					Sidecar: Sidecar{
@@ -79,7 +82,7 @@ environments:
 					Environments: map[string]loadBalancedWebServiceOverrideConfig{
 						"test": {
 							TaskConfig: TaskConfig{
-								Count: intp(3),
+								Count: Count{Value: intp(3)},
 							},
 						},
 					},
@@ -96,8 +99,10 @@ image:
   port: 8080
   healthcheck:
     command: ['CMD-SHELL', 'curl http://localhost:5000/ || exit 1']
+entrypoint: /bin/subscribers
+command: [--port, "8080"]
 cpu: 1024
-memory: 1024
+memory: 2048
 secrets:
   API_TOKEN: SUBS_API_TOKEN`,
 			requireCorrectValues: func(t *testing.T, i interface{}) {
@@ -111,7 +116,7 @@ secrets:
 					Image: imageWithPortAndHealthcheck{
 						ServiceImageWithPort: ServiceImageWithPort{
 							ServiceImage: ServiceImage{
-								Build: "./subscribers/Dockerfile",
+								Build: BuildArgsOrString{BuildString: stringp("./subscribers/Dockerfile")},
 							},
 							Port: 8080,
 						},
@@ -123,18 +128,250 @@ secrets:
 							StartPeriod: durationp(0 * time.Second),
 						},
 					},
+					ImageOverride: ImageOverride{
+						EntryPoint: StringSliceOrString{String: stringp("/bin/subscribers")},
+						Command:    StringSliceOrString{StringSlice: []string{"--port", "8080"}},
+					},
 					TaskConfig: TaskConfig{
 						CPU:    1024,
+						Memory: 2048,
+						Count:  Count{Value: intp(1)},
+						Secrets: map[string]SecretArgsOrString{
+							"API_TOKEN": {SecretString: stringp("SUBS_API_TOKEN")},
+						},
+					},
+				}
+				require.Equal(t, wantedManifest, actualManifest)
+			},
+		},
+		"load balanced web service with autoscaling": {
+			inContent: `
+name: frontend
+type: "Load Balanced Web Service"
+image:
+  build: frontend/Dockerfile
+  port: 80
+count:
+  range: 1-10
+  cpu_percentage: 70
+  requests: 1000
+environments:
+  test:
+    count: 1
+`,
+			requireCorrectValues: func(t *testing.T, i interface{}) {
+				actualManifest, ok := i.(*LoadBalancedWebService)
+				require.True(t, ok)
+				require.Equal(t, Count{
+					Autoscaling: Autoscaling{
+						Range:    Range("1-10"),
+						CPU:      intp(70),
+						Requests: intp(1000),
+					},
+				}, actualManifest.Count)
+				require.Equal(t, Count{Value: intp(1)}, actualManifest.Environments["test"].Count)
+			},
+		},
+		"scheduled job": {
+			inContent: `
+name: report
+type: Scheduled Job
+image:
+  build: ./report/Dockerfile
+on:
+  schedule: "@daily"
+retries: 3
+timeout: 1h30m
+memory: 1024`,
+			requireCorrectValues: func(t *testing.T, i interface{}) {
+				actualManifest, ok := i.(*ScheduledJob)
+				require.True(t, ok)
+				wantedManifest := &ScheduledJob{
+					Service: Service{
+						Name: "report",
+						Type: ScheduledJobType,
+					},
+					Image: ServiceImage{
+						Build: BuildArgsOrString{BuildString: stringp("./report/Dockerfile")},
+					},
+					On: JobTriggerConfig{
+						Schedule: "@daily",
+					},
+					JobFailureHandlerConfig: JobFailureHandlerConfig{
+						Timeout: durationp(90 * time.Minute),
+						Retries: intp(3),
+					},
+					TaskConfig: TaskConfig{
+						CPU:    256,
 						Memory: 1024,
-						Count:  intp(1),
-						Secrets: map[string]string{
-							"API_TOKEN": "SUBS_API_TOKEN",
+						Count:  Count{Value: intp(1)},
+					},
+				}
+				require.Equal(t, wantedManifest, actualManifest)
+			},
+		},
+		"worker service": {
+			inContent: `
+name: processor
+type: Worker Service
+image:
+  build: ./processor/Dockerfile
+queue:
+  timeout: 1m
+count:
+  range: 1-5
+  queue_depth: 50`,
+			requireCorrectValues: func(t *testing.T, i interface{}) {
+				actualManifest, ok := i.(*WorkerService)
+				require.True(t, ok)
+				wantedManifest := &WorkerService{
+					Service: Service{
+						Name: "processor",
+						Type: WorkerServiceType,
+					},
+					Image: ServiceImage{
+						Build: BuildArgsOrString{BuildString: stringp("./processor/Dockerfile")},
+					},
+					TaskConfig: TaskConfig{
+						CPU:    256,
+						Memory: 512,
+						Count: Count{
+							Autoscaling: Autoscaling{
+								Range:      Range("1-5"),
+								QueueDepth: intp(50),
+							},
 						},
 					},
+					Queue: SQSQueue{
+						Timeout: durationp(time.Minute),
+						DeadLetter: DeadLetterQueue{
+							Tries: intp(3),
+						},
+					},
+				}
+				require.Equal(t, wantedManifest, actualManifest)
+			},
+		},
+		"static site": {
+			inContent: `
+name: website
+type: Static Site
+path: ./website/dist
+alias: www.phonetool.example.com`,
+			requireCorrectValues: func(t *testing.T, i interface{}) {
+				actualManifest, ok := i.(*StaticSite)
+				require.True(t, ok)
+				wantedManifest := &StaticSite{
+					Service: Service{
+						Name: "website",
+						Type: StaticSiteType,
+					},
+					Path:  "./website/dist",
+					Alias: stringp("www.phonetool.example.com"),
 				}
 				require.Equal(t, wantedManifest, actualManifest)
 			},
 		},
+		"static site without a path": {
+			inContent: `
+name: website
+type: Static Site
+`,
+			wantedErr: errors.New("validate static site: " + errStaticSiteWithoutPath.Error()),
+		},
+		"image with both build and location": {
+			inContent: `
+name: frontend
+type: "Load Balanced Web Service"
+image:
+  build: ./frontend/Dockerfile
+  location: nginx:latest
+  port: 80
+`,
+			wantedErr: errors.New("validate load balanced web service: " + errImageBuildAndLocation.Error()),
+		},
+		"environment image with both build and location": {
+			inContent: `
+name: subscribers
+type: "Backend Service"
+image:
+  location: nginx:latest
+  port: 80
+environments:
+  prod:
+    image:
+      build: ./subscribers/Dockerfile
+      location: nginx:stable
+`,
+			wantedErr: errors.New("validate backend service: environment prod: " + errImageBuildAndLocation.Error()),
+		},
+		"invalid count": {
+			inContent: `
+name: frontend
+type: "Load Balanced Web Service"
+count: [1, 2]
+`,
+			wantedErr: errors.New(`validate load balanced web service: line 4, column 8: "count" must be an integer or a map`),
+		},
+		"unknown field": {
+			inContent: `
+name: subscribers
+type: Backend Service
+image:
+  build: ./subscribers/Dockerfile
+  port: 8080
+varibles:
+  LOG_LEVEL: "WARN"
+`,
+			wantedErr: errors.New(`validate backend service: line 7, column 1: unknown field "varibles", did you mean "variables"?`),
+		},
+		"unknown field in environment override": {
+			inContent: `
+name: processor
+type: Worker Service
+image:
+  build: ./processor/Dockerfile
+environments:
+  prod:
+    image:
+      healtcheck:
+        retries: 3
+`,
+			wantedErr: errors.New(`validate worker service: line 9, column 7: unknown field "healtcheck"`),
+		},
+		"invalid task size in environment override": {
+			inContent: `
+name: report
+type: Scheduled Job
+image:
+  build: ./report/Dockerfile
+on:
+  schedule: "@daily"
+cpu: 1024
+memory: 2048
+environments:
+  test:
+    cpu: 4096
+`,
+			wantedErr: errors.New(`validate scheduled job: line 12, column 10: invalid Fargate task size: "memory" must be between 8192 and 30720 in increments of 1024 for 4096 CPU units, but got 2048`),
+		},
+		"ingress of a scheduled job": {
+			inContent: `
+name: report
+type: Scheduled Job
+image:
+  build: ./report/Dockerfile
+on:
+  schedule: "@daily"
+environments:
+  test:
+    network:
+      vpc:
+        ingress:
+          services: [api]
+`,
+			wantedErr: errors.New(`validate scheduled job: environment test: "network.vpc.ingress" is not supported for a scheduled job, nothing sends traffic to its tasks`),
+		},
 		"invalid svc type": {
 			inContent: `
 name: CowSvc
@@ -156,3 +393,408 @@ type: 'OH NO'
 		})
 	}
 }
+
+func TestRange_Parse(t *testing.T) {
+	testCases := map[string]struct {
+		in Range
+
+		wantedMin int
+		wantedMax int
+		wantedErr error
+	}{
+		"invalid format": {
+			in:        Range("1to10"),
+			wantedErr: errors.New("invalid range value 1to10. Should be in format of ${min}-${max}"),
+		},
+		"invalid minimum value": {
+			in:        Range("a-10"),
+			wantedErr: errors.New("cannot convert minimum value a to integer"),
+		},
+		"invalid maximum value": {
+			in:        Range("1-b"),
+			wantedErr: errors.New("cannot convert maximum value b to integer"),
+		},
+		"minimum larger than maximum": {
+			in:        Range("10-1"),
+			wantedErr: errors.New("minimum value 10 cannot be larger than maximum value 1"),
+		},
+		"success": {
+			in:        Range("1-10"),
+			wantedMin: 1,
+			wantedMax: 10,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			min, max, err := tc.in.Parse()
+
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.wantedMin, min)
+				require.Equal(t, tc.wantedMax, max)
+			}
+		})
+	}
+}
+
+func TestCount_applyOverride(t *testing.T) {
+	testCases := map[string]struct {
+		in    Count
+		other Count
+
+		wanted Count
+	}{
+		"keeps the original value if there are no overrides": {
+			in:     Count{Value: intp(1)},
+			other:  Count{},
+			wanted: Count{Value: intp(1)},
+		},
+		"overrides a fixed count with autoscaling": {
+			in: Count{Value: intp(1)},
+			other: Count{
+				Autoscaling: Autoscaling{
+					Range: Range("1-10"),
+					CPU:   intp(70),
+				},
+			},
+			wanted: Count{
+				Autoscaling: Autoscaling{
+					Range: Range("1-10"),
+					CPU:   intp(70),
+				},
+			},
+		},
+		"overrides autoscaling with a fixed count": {
+			in: Count{
+				Autoscaling: Autoscaling{
+					Range: Range("1-10"),
+				},
+			},
+			other:  Count{Value: intp(0)},
+			wanted: Count{Value: intp(0)},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.wanted, applyOverride(tc.in, tc.other))
+		})
+	}
+}
+
+func TestServiceImage_applyOverride(t *testing.T) {
+	testCases := map[string]struct {
+		inImage    ServiceImage
+		inOverride ServiceImage
+
+		wanted ServiceImage
+	}{
+		"keeps the image if there are no overrides": {
+			inImage: ServiceImage{
+				Build: BuildArgsOrString{BuildString: stringp("./Dockerfile")},
+			},
+			wanted: ServiceImage{
+				Build: BuildArgsOrString{BuildString: stringp("./Dockerfile")},
+			},
+		},
+		"replaces build with location": {
+			inImage: ServiceImage{
+				Build: BuildArgsOrString{BuildString: stringp("./Dockerfile")},
+			},
+			inOverride: ServiceImage{
+				Location: "nginx:latest",
+			},
+			wanted: ServiceImage{
+				Location: "nginx:latest",
+			},
+		},
+		"replaces the build string with build options": {
+			inImage: ServiceImage{
+				Build: BuildArgsOrString{BuildString: stringp("./Dockerfile")},
+			},
+			inOverride: ServiceImage{
+				Build: BuildArgsOrString{BuildArgs: DockerBuildArgs{Target: stringp("prod")}},
+			},
+			wanted: ServiceImage{
+				Build: BuildArgsOrString{BuildArgs: DockerBuildArgs{Target: stringp("prod")}},
+			},
+		},
+		"replaces location with build": {
+			inImage: ServiceImage{
+				Location: "nginx:latest",
+			},
+			inOverride: ServiceImage{
+				Build: BuildArgsOrString{BuildString: stringp("./Dockerfile")},
+			},
+			wanted: ServiceImage{
+				Build: BuildArgsOrString{BuildString: stringp("./Dockerfile")},
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.wanted, applyOverride(tc.inImage, tc.inOverride))
+		})
+	}
+}
+
+func TestBuildArgsOrString_UnmarshalYAML(t *testing.T) {
+	testCases := map[string]struct {
+		inContent []byte
+
+		wantedStruct ServiceImage
+		wantedError  error
+	}{
+		"legacy case: simple build string": {
+			inContent: []byte(`build: ./Dockerfile`),
+			wantedStruct: ServiceImage{
+				Build: BuildArgsOrString{
+					BuildString: stringp("./Dockerfile"),
+				},
+			},
+		},
+		"all build options specified": {
+			inContent: []byte(`build:
+  dockerfile: path/to/Dockerfile.prod
+  context: path
+  target: build-stage
+  args:
+    GOOS: linux
+  cache_from:
+    - foo/bar:latest
+    - foo/bar/baz:1.2.3`),
+			wantedStruct: ServiceImage{
+				Build: BuildArgsOrString{
+					BuildArgs: DockerBuildArgs{
+						Dockerfile: stringp("path/to/Dockerfile.prod"),
+						Context:    stringp("path"),
+						Target:     stringp("build-stage"),
+						Args: map[string]string{
+							"GOOS": "linux",
+						},
+						CacheFrom: []string{"foo/bar:latest", "foo/bar/baz:1.2.3"},
+					},
+				},
+			},
+		},
+		"error if build is a list": {
+			inContent:   []byte(`build: [./Dockerfile]`),
+			wantedError: errUnmarshalBuildOpts,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			var img ServiceImage
+
+			// WHEN
+			err := yaml.Unmarshal(tc.inContent, &img)
+
+			// THEN
+			if tc.wantedError != nil {
+				require.EqualError(t, err, tc.wantedError.Error())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.wantedStruct, img)
+		})
+	}
+}
+
+func TestServiceImage_BuildConfig(t *testing.T) {
+	testCases := map[string]struct {
+		inBuild BuildArgsOrString
+
+		wanted DockerBuildArgs
+	}{
+		"uses the Dockerfile's directory as the context for a build string": {
+			inBuild: BuildArgsOrString{BuildString: stringp("web/Dockerfile")},
+			wanted: DockerBuildArgs{
+				Dockerfile: stringp("web/Dockerfile"),
+				Context:    stringp("web"),
+			},
+		},
+		"uses the Dockerfile's directory as the context if only the Dockerfile is specified": {
+			inBuild: BuildArgsOrString{BuildArgs: DockerBuildArgs{Dockerfile: stringp("web/Dockerfile.prod")}},
+			wanted: DockerBuildArgs{
+				Dockerfile: stringp("web/Dockerfile.prod"),
+				Context:    stringp("web"),
+			},
+		},
+		"looks for a Dockerfile in the context if only the context is specified": {
+			inBuild: BuildArgsOrString{BuildArgs: DockerBuildArgs{Context: stringp("web")}},
+			wanted: DockerBuildArgs{
+				Dockerfile: stringp("web/Dockerfile"),
+				Context:    stringp("web"),
+			},
+		},
+		"keeps all the specified build options": {
+			inBuild: BuildArgsOrString{BuildArgs: DockerBuildArgs{
+				Dockerfile: stringp("web/Dockerfile"),
+				Context:    stringp("."),
+				Target:     stringp("prod"),
+				Args:       map[string]string{"GOOS": "linux"},
+				CacheFrom:  []string{"web:latest"},
+			}},
+			wanted: DockerBuildArgs{
+				Dockerfile: stringp("web/Dockerfile"),
+				Context:    stringp("."),
+				Target:     stringp("prod"),
+				Args:       map[string]string{"GOOS": "linux"},
+				CacheFrom:  []string{"web:latest"},
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			img := ServiceImage{Build: tc.inBuild}
+
+			require.Equal(t, tc.wanted, img.BuildConfig())
+		})
+	}
+}
+
+func TestSidecarConfig_applyOverride(t *testing.T) {
+	testCases := map[string]struct {
+		inConfig   SidecarConfig
+		inOverride SidecarConfig
+
+		wanted SidecarConfig
+	}{
+		"keeps the configuration if there are no overrides": {
+			inConfig: SidecarConfig{
+				Image:     "nginx",
+				Port:      "80",
+				Variables: map[string]string{"LOG_LEVEL": "info"},
+				Command:   StringSliceOrString{String: stringp("nginx -g 'daemon off;'")},
+			},
+			wanted: SidecarConfig{
+				Image:     "nginx",
+				Port:      "80",
+				Variables: map[string]string{"LOG_LEVEL": "info"},
+				Command:   StringSliceOrString{String: stringp("nginx -g 'daemon off;'")},
+			},
+		},
+		"merges maps and healthchecks field by field and replaces lists": {
+			inConfig: SidecarConfig{
+				Image:     "nginx",
+				Essential: boolp(true),
+				Variables: map[string]string{"LOG_LEVEL": "info", "PORT": "80"},
+				Secrets:   map[string]SecretArgsOrString{"TOKEN": {SecretString: stringp("/app/token")}},
+				DependsOn: map[string]string{"frontend": "START"},
+				Command:   StringSliceOrString{String: stringp("nginx")},
+				HealthCheck: &ContainerHealthCheck{
+					Command:  []string{"CMD-SHELL", "curl -f http://localhost || exit 1"},
+					Interval: durationp(10 * time.Second),
+				},
+				MountPoints: []SidecarMountPoint{
+					{SourceVolume: stringp("data")},
+				},
+			},
+			inOverride: SidecarConfig{
+				Essential: boolp(false),
+				Variables: map[string]string{"LOG_LEVEL": "debug"},
+				DependsOn: map[string]string{"xray": "HEALTHY"},
+				Command:   StringSliceOrString{StringSlice: []string{"nginx", "-g", "daemon off;"}},
+				HealthCheck: &ContainerHealthCheck{
+					Retries: intp(5),
+				},
+				MountPoints: []SidecarMountPoint{
+					{SourceVolume: stringp("logs")},
+				},
+			},
+			wanted: SidecarConfig{
+				Image:     "nginx",
+				Essential: boolp(false),
+				Variables: map[string]string{"LOG_LEVEL": "debug", "PORT": "80"},
+				Secrets:   map[string]SecretArgsOrString{"TOKEN": {SecretString: stringp("/app/token")}},
+				DependsOn: map[string]string{"frontend": "START", "xray": "HEALTHY"},
+				Command:   StringSliceOrString{StringSlice: []string{"nginx", "-g", "daemon off;"}},
+				HealthCheck: &ContainerHealthCheck{
+					Command:  []string{"CMD-SHELL", "curl -f http://localhost || exit 1"},
+					Interval: durationp(10 * time.Second),
+					Retries:  intp(5),
+				},
+				MountPoints: []SidecarMountPoint{
+					{SourceVolume: stringp("logs")},
+				},
+			},
+		},
+		"adds a healthcheck": {
+			inConfig: SidecarConfig{
+				Image: "nginx",
+			},
+			inOverride: SidecarConfig{
+				HealthCheck: &ContainerHealthCheck{
+					Command: []string{"CMD", "healthcheck"},
+				},
+			},
+			wanted: SidecarConfig{
+				Image: "nginx",
+				HealthCheck: &ContainerHealthCheck{
+					Command: []string{"CMD", "healthcheck"},
+				},
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.wanted, applyOverride(tc.inConfig, tc.inOverride))
+		})
+	}
+}
+
+func TestStringSliceOrString_UnmarshalYAML(t *testing.T) {
+	testCases := map[string]struct {
+		inContent []byte
+
+		wantedStruct StringSliceOrString
+		wantedArgs   []string
+		wantedError  error
+	}{
+		"string is split like a shell would": {
+			inContent:    []byte(`command: nginx -g 'daemon off;' "a b" c\ d`),
+			wantedStruct: StringSliceOrString{String: stringp(`nginx -g 'daemon off;' "a b" c\ d`)},
+			wantedArgs:   []string{"nginx", "-g", "daemon off;", "a b", "c d"},
+		},
+		"list of strings": {
+			inContent:    []byte(`command: ["nginx", "-g", "daemon off;"]`),
+			wantedStruct: StringSliceOrString{StringSlice: []string{"nginx", "-g", "daemon off;"}},
+			wantedArgs:   []string{"nginx", "-g", "daemon off;"},
+		},
+		"error if a quote is not terminated": {
+			inContent:   []byte(`command: nginx -g 'daemon off;`),
+			wantedError: errors.New(`unterminated quote or escape in command "nginx -g 'daemon off;"`),
+		},
+		"error if command is a map": {
+			inContent:   []byte(`command: {nginx: daemon}`),
+			wantedError: errUnmarshalStringSlice,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			var sc SidecarConfig
+
+			// WHEN
+			err := yaml.Unmarshal(tc.inContent, &sc)
+
+			// THEN
+			if tc.wantedError != nil {
+				require.EqualError(t, err, tc.wantedError.Error())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.wantedStruct, sc.Command)
+			require.Equal(t, tc.wantedArgs, sc.Command.ToStringSlice())
+		})
+	}
+}
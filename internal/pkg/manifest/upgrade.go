@@ -0,0 +1,197 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package manifest
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// legacyServiceManifestVersion is the version of the service manifests without a "version" field,
+	// which were written before service manifests were versioned.
+	legacyServiceManifestVersion = 1
+
+	versionFieldName = "version"
+)
+
+// serviceManifestMigration rewrites the YAML document of a service manifest from one schema version to the next.
+type serviceManifestMigration struct {
+	description string                 // Summary of the change to the schema.
+	migrate     func(*yaml.Node) error // Receives the root mapping node of the manifest.
+}
+
+// serviceManifestMigrations is the registry of the migrations of the service manifests in the order of the versions.
+// The migration at index i upgrades a manifest from version i+1 to version i+2.
+//
+// When a release changes the meaning of a field or a default value, it appends a migration that rewrites older
+// manifests so that they keep their previous behavior, for example by writing the old default value explicitly.
+var serviceManifestMigrations []serviceManifestMigration
+
+// LatestServiceManifestVersion returns the version of the schema of the service manifests written by this release.
+func LatestServiceManifestVersion() int {
+	return legacyServiceManifestVersion + len(serviceManifestMigrations)
+}
+
+// UpgradeServiceManifest rewrites the service manifest to the latest schema version by running the migrations
+// registered since the manifest's version. Comments and the order of the fields are preserved, and environment
+// variables referenced in the manifest are left unexpanded.
+// If the manifest is already at the latest version, the input is returned unchanged.
+func UpgradeServiceManifest(in []byte) ([]byte, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(in, &doc); err != nil {
+		return nil, fmt.Errorf("unmarshal service manifest: %w", err)
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("unmarshal service manifest: manifest must be a map")
+	}
+	root := doc.Content[0]
+	versioned := mappingValue(root, versionFieldName) != nil
+	version, err := migrateServiceManifest(root)
+	if err != nil {
+		return nil, err
+	}
+	latest := LatestServiceManifestVersion()
+	if versioned && version == latest {
+		return in, nil
+	}
+	setServiceManifestVersion(root, latest)
+
+	buf := new(bytes.Buffer)
+	enc := yaml.NewEncoder(buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return nil, fmt.Errorf("marshal upgraded service manifest: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return nil, fmt.Errorf("marshal upgraded service manifest: %w", err)
+	}
+	return restoreBlankLines(in, buf.Bytes())
+}
+
+// migrateServiceManifest runs the migrations from the version of the manifest to the latest version on the root
+// mapping node of the manifest, and returns the version of the manifest before the migrations.
+func migrateServiceManifest(root *yaml.Node) (int, error) {
+	version, err := serviceManifestVersion(root)
+	if err != nil {
+		return 0, err
+	}
+	for v := version; v < LatestServiceManifestVersion(); v++ {
+		m := serviceManifestMigrations[v-legacyServiceManifestVersion]
+		if err := m.migrate(root); err != nil {
+			return 0, fmt.Errorf("upgrade manifest from version %d to %d (%s): %w", v, v+1, m.description, err)
+		}
+	}
+	return version, nil
+}
+
+// serviceManifestVersion returns the value of the "version" field of the manifest.
+func serviceManifestVersion(root *yaml.Node) (int, error) {
+	node := mappingValue(root, versionFieldName)
+	if node == nil {
+		return legacyServiceManifestVersion, nil
+	}
+	version, err := strconv.Atoi(node.Value)
+	if err != nil || version < legacyServiceManifestVersion {
+		return 0, &ErrInvalidSvcManifestVersion{Version: node.Value, Latest: LatestServiceManifestVersion()}
+	}
+	if version > LatestServiceManifestVersion() {
+		return 0, &ErrInvalidSvcManifestVersion{Version: node.Value, Latest: LatestServiceManifestVersion(), isNewer: true}
+	}
+	return version, nil
+}
+
+// setServiceManifestVersion sets the "version" field of the manifest, which is added after the "type" field if missing.
+func setServiceManifestVersion(root *yaml.Node, version int) {
+	value := &yaml.Node{
+		Kind:  yaml.ScalarNode,
+		Tag:   "!!int",
+		Value: strconv.Itoa(version),
+	}
+	if node := mappingValue(root, versionFieldName); node != nil {
+		*node = *value
+		return
+	}
+	key := &yaml.Node{
+		Kind:  yaml.ScalarNode,
+		Tag:   "!!str",
+		Value: versionFieldName,
+	}
+	at := 0
+	for i := 0; i < len(root.Content)-1; i += 2 {
+		if root.Content[i].Value == "type" {
+			at = i + 2
+			break
+		}
+	}
+	content := make([]*yaml.Node, 0, len(root.Content)+2)
+	content = append(content, root.Content[:at]...)
+	content = append(content, key, value)
+	root.Content = append(content, root.Content[at:]...)
+}
+
+// restoreBlankLines adds back the blank lines that separated the fields of the original manifest, which are
+// dropped when the YAML document is marshaled again.
+func restoreBlankLines(original, upgraded []byte) ([]byte, error) {
+	var origDoc, upDoc yaml.Node
+	if err := yaml.Unmarshal(original, &origDoc); err != nil {
+		return nil, fmt.Errorf("unmarshal service manifest: %w", err)
+	}
+	if err := yaml.Unmarshal(upgraded, &upDoc); err != nil {
+		return nil, fmt.Errorf("unmarshal upgraded service manifest: %w", err)
+	}
+	origLines := strings.Split(string(original), "\n")
+	separated := make(map[string]bool)
+	walkMappingKeys(&origDoc, "", func(path string, line int) {
+		if line > 1 && strings.TrimSpace(origLines[line-2]) == "" {
+			separated[path] = true
+		}
+	})
+	blankBefore := make(map[int]bool)
+	walkMappingKeys(&upDoc, "", func(path string, line int) {
+		if separated[path] {
+			blankBefore[line] = true
+		}
+	})
+
+	upLines := strings.Split(string(upgraded), "\n")
+	out := make([]string, 0, len(upLines)+len(blankBefore))
+	for i, l := range upLines {
+		if blankBefore[i+1] && i > 0 && strings.TrimSpace(upLines[i-1]) != "" {
+			out = append(out, "")
+		}
+		out = append(out, l)
+	}
+	return []byte(strings.Join(out, "\n")), nil
+}
+
+// walkMappingKeys calls fn with the path and the first line, including the head comment, of every key of
+// the mappings under the node.
+func walkMappingKeys(node *yaml.Node, path string, fn func(path string, line int)) {
+	switch node.Kind {
+	case yaml.DocumentNode, yaml.SequenceNode:
+		for i, child := range node.Content {
+			childPath := path
+			if node.Kind == yaml.SequenceNode {
+				childPath = joinPath(path, strconv.Itoa(i))
+			}
+			walkMappingKeys(child, childPath, fn)
+		}
+	case yaml.MappingNode:
+		for i := 0; i < len(node.Content)-1; i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			keyPath := joinPath(path, key.Value)
+			line := key.Line
+			if key.HeadComment != "" {
+				line -= strings.Count(key.HeadComment, "\n") + 1
+			}
+			fn(keyPath, line)
+			walkMappingKeys(value, keyPath, fn)
+		}
+	}
+}
@@ -0,0 +1,262 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package manifest
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+// explicitCountMigration is a migration that writes the default "count" explicitly, as a release
+// changing the default number of tasks would.
+var explicitCountMigration = serviceManifestMigration{
+	description: `write the default "count" explicitly`,
+	migrate: func(root *yaml.Node) error {
+		if mappingValue(root, "count") != nil {
+			return nil
+		}
+		root.Content = append(root.Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "count"},
+			&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int", Value: "1"})
+		return nil
+	},
+}
+
+func TestUpgradeServiceManifest(t *testing.T) {
+	testCases := map[string]struct {
+		inManifest   string
+		inMigrations []serviceManifestMigration
+
+		wantedManifest string
+		wantedErr      error
+	}{
+		"adds the version to a legacy manifest and preserves comments and blank lines": {
+			inManifest: `# The manifest for the "frontend" service.
+
+# Your service name.
+name: frontend
+# The "architecture" of the service.
+type: Load Balanced Web Service
+
+image:
+  # Path to your service's Dockerfile.
+  build: ./frontend/Dockerfile
+  port: 80 # The container port.
+
+variables:
+  LOG_LEVEL: ${LOG_LEVEL:-info}
+
+environments:
+  test:
+    count: 2
+`,
+			wantedManifest: `# The manifest for the "frontend" service.
+
+# Your service name.
+name: frontend
+# The "architecture" of the service.
+type: Load Balanced Web Service
+version: 1
+
+image:
+  # Path to your service's Dockerfile.
+  build: ./frontend/Dockerfile
+  port: 80 # The container port.
+
+variables:
+  LOG_LEVEL: ${LOG_LEVEL:-info}
+
+environments:
+  test:
+    count: 2
+`,
+		},
+		"returns the manifest unchanged if it's at the latest version": {
+			inManifest: `name: frontend
+type: Load Balanced Web Service
+version: 1
+
+
+image:
+    build: ./frontend/Dockerfile
+`,
+			wantedManifest: `name: frontend
+type: Load Balanced Web Service
+version: 1
+
+
+image:
+    build: ./frontend/Dockerfile
+`,
+		},
+		"runs the migrations since the version of the manifest": {
+			inManifest: `name: frontend
+type: Backend Service
+version: 1
+
+image:
+  build: ./frontend/Dockerfile
+`,
+			inMigrations: []serviceManifestMigration{explicitCountMigration},
+			wantedManifest: `name: frontend
+type: Backend Service
+version: 2
+
+image:
+  build: ./frontend/Dockerfile
+count: 1
+`,
+		},
+		"skips the migrations older than the version of the manifest": {
+			inManifest: `name: frontend
+type: Backend Service
+version: 2
+`,
+			inMigrations: []serviceManifestMigration{
+				{
+					description: "fail",
+					migrate: func(*yaml.Node) error {
+						return errors.New("some error")
+					},
+				},
+				explicitCountMigration,
+			},
+			wantedManifest: `name: frontend
+type: Backend Service
+version: 3
+count: 1
+`,
+		},
+		"wraps the error of a migration": {
+			inManifest: `name: frontend
+type: Backend Service
+`,
+			inMigrations: []serviceManifestMigration{
+				{
+					description: "fail",
+					migrate: func(*yaml.Node) error {
+						return errors.New("some error")
+					},
+				},
+			},
+			wantedErr: errors.New("upgrade manifest from version 1 to 2 (fail): some error"),
+		},
+		"errors if the manifest is newer than the release": {
+			inManifest: `name: frontend
+type: Backend Service
+version: 3
+`,
+			wantedErr: errors.New("manifest version 3 is newer than the latest version 1 supported by this release, upgrade copilot to use this manifest"),
+		},
+		"errors if the version isn't an integer": {
+			inManifest: `name: frontend
+type: Backend Service
+version: latest
+`,
+			wantedErr: errors.New("invalid manifest version latest, must be an integer between 1 and 1"),
+		},
+		"errors if the manifest isn't a map": {
+			inManifest: `- frontend`,
+			wantedErr:  errors.New("unmarshal service manifest: manifest must be a map"),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			defer func(migrations []serviceManifestMigration) {
+				serviceManifestMigrations = migrations
+			}(serviceManifestMigrations)
+			serviceManifestMigrations = tc.inMigrations
+
+			// WHEN
+			got, err := UpgradeServiceManifest([]byte(tc.inManifest))
+
+			// THEN
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.wantedManifest, string(got))
+		})
+	}
+}
+
+func TestUnmarshalService_Versions(t *testing.T) {
+	testCases := map[string]struct {
+		inManifest   string
+		inMigrations []serviceManifestMigration
+
+		wantedCount int
+		wantedErr   error
+	}{
+		"upgrades a legacy manifest before unmarshaling it": {
+			inManifest: `name: frontend
+type: Backend Service
+image:
+  build: ./frontend/Dockerfile
+`,
+			inMigrations: []serviceManifestMigration{
+				{
+					description: "run two tasks",
+					migrate: func(root *yaml.Node) error {
+						root.Content = append(root.Content,
+							&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "count"},
+							&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int", Value: "2"})
+						return nil
+					},
+				},
+			},
+			wantedCount: 2,
+		},
+		"unmarshals a manifest at the latest version": {
+			inManifest: `name: frontend
+type: Backend Service
+version: 1
+image:
+  build: ./frontend/Dockerfile
+`,
+			wantedCount: 1,
+		},
+		"errors if the manifest is newer than the release": {
+			inManifest: `name: frontend
+type: Backend Service
+version: 2
+image:
+  build: ./frontend/Dockerfile
+`,
+			wantedErr: fmt.Errorf("unmarshal to service manifest: %w", &ErrInvalidSvcManifestVersion{
+				Version: "2",
+				Latest:  1,
+				isNewer: true,
+			}),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			defer func(migrations []serviceManifestMigration) {
+				serviceManifestMigrations = migrations
+			}(serviceManifestMigrations)
+			serviceManifestMigrations = tc.inMigrations
+
+			// WHEN
+			got, err := UnmarshalService([]byte(tc.inManifest))
+
+			// THEN
+			if tc.wantedErr != nil {
+				require.EqualError(t, err, tc.wantedErr.Error())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.wantedCount, *got.(*BackendService).Count.Value)
+		})
+	}
+}
@@ -0,0 +1,155 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package manifest
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/template"
+	"github.com/aws/aws-sdk-go/aws"
+)
+
+const (
+	workerSvcManifestPath = "services/worker/manifest.yml"
+
+	defaultDeadLetterTries = 3
+)
+
+// WorkerServiceProps represents the configuration needed to create a worker service.
+type WorkerServiceProps struct {
+	ServiceProps
+}
+
+// WorkerService holds the configuration to create a worker service that consumes messages from a queue.
+type WorkerService struct {
+	Service       `yaml:",inline"`
+	Image         ServiceImage `yaml:",flow"`
+	ImageOverride `yaml:",inline"`
+	TaskConfig    `yaml:",inline"`
+	Sidecar       `yaml:",inline"`
+	Logging       Logging                                `yaml:"logging,flow"`
+	Platform      Platform                               `yaml:"platform,flow"`
+	Network       NetworkConfig                          `yaml:"network"`
+	Deployment    DeploymentConfig                       `yaml:"deployment"`
+	Queue         SQSQueue                               `yaml:"queue"`
+	Environments  map[string]workerServiceOverrideConfig `yaml:",flow"`
+
+	envNulls map[string][][]string // Paths of the fields set to null in each environment override.
+	parser   template.Parser
+}
+
+type workerServiceOverrideConfig struct {
+	Image         ServiceImage `yaml:",flow"`
+	ImageOverride `yaml:",inline"`
+	TaskConfig    `yaml:",inline"`
+	Sidecar       `yaml:",inline"`
+	Logging       Logging          `yaml:"logging,flow"`
+	Platform      Platform         `yaml:"platform,flow"`
+	Network       NetworkConfig    `yaml:"network"`
+	Deployment    DeploymentConfig `yaml:"deployment"`
+	Queue         SQSQueue         `yaml:"queue"`
+}
+
+// SQSQueue represents the configurable options for the queue that the worker service consumes from.
+type SQSQueue struct {
+	Retention  *time.Duration  `yaml:"retention"` // How long a message is kept in the queue.
+	Delay      *time.Duration  `yaml:"delay"`     // How long the delivery of a new message is postponed.
+	Timeout    *time.Duration  `yaml:"timeout"`   // How long a received message is hidden from other consumers.
+	DeadLetter DeadLetterQueue `yaml:"dead_letter"`
+}
+
+// DeadLetterQueue represents the configurable options for the queue that holds messages that couldn't be processed.
+type DeadLetterQueue struct {
+	Tries *int `yaml:"tries"` // Number of times a message is received before it's moved to the dead-letter queue.
+}
+
+// NewWorkerService applies the props to a default worker service configuration with
+// minimal task sizes, single replica, and a queue with a dead-letter queue, and then returns it.
+func NewWorkerService(props WorkerServiceProps) *WorkerService {
+	svc := newDefaultWorkerService()
+	// Apply overrides.
+	svc.Name = props.Name
+	svc.Version = LatestServiceManifestVersion()
+	svc.Image.Build = BuildArgsOrString{BuildString: stringp(props.Dockerfile)}
+	svc.parser = template.New()
+	return svc
+}
+
+// newDefaultWorkerService returns a worker service with minimal task sizes and a single replica.
+func newDefaultWorkerService() *WorkerService {
+	return &WorkerService{
+		Service: Service{
+			Type: WorkerServiceType,
+		},
+		TaskConfig: TaskConfig{
+			CPU:    256,
+			Memory: 512,
+			Count: Count{
+				Value: intp(1),
+			},
+		},
+		Queue: SQSQueue{
+			DeadLetter: DeadLetterQueue{
+				Tries: intp(defaultDeadLetterTries),
+			},
+		},
+	}
+}
+
+// MarshalBinary serializes the manifest object into a binary YAML document.
+// Implements the encoding.BinaryMarshaler interface.
+func (s *WorkerService) MarshalBinary() ([]byte, error) {
+	content, err := s.parser.Parse(workerSvcManifestPath, *s)
+	if err != nil {
+		return nil, err
+	}
+	return content.Bytes(), nil
+}
+
+// DockerfilePath returns the path to the image's Dockerfile.
+func (s *WorkerService) DockerfilePath() string {
+	return aws.StringValue(s.Image.BuildConfig().Dockerfile)
+}
+
+// BuildArgs returns the docker build options of the image in the environment.
+func (s *WorkerService) BuildArgs(envName string) DockerBuildArgs {
+	return s.ApplyEnv(envName).Image.BuildConfig()
+}
+
+// ImageLocation returns the location of the pre-built container image in the environment, if any.
+func (s *WorkerService) ImageLocation(envName string) string {
+	return s.ApplyEnv(envName).Image.Location
+}
+
+// EnvFiles returns the paths of the environment files of the containers in the environment, keyed by the container name.
+func (s *WorkerService) EnvFiles(envName string) map[string]string {
+	mft := s.ApplyEnv(envName)
+	return envFiles(mft.Name, mft.TaskConfig, mft.Sidecars)
+}
+
+// ApplyEnv returns the service manifest with environment overrides.
+// If the environment passed in does not have any overrides then it returns itself.
+func (s *WorkerService) ApplyEnv(envName string) *WorkerService {
+	target, ok := s.Environments[envName]
+	if !ok {
+		return s
+	}
+	out := &WorkerService{}
+	applyEnvOverride(out, s, target, s.envNulls[envName])
+	return out
+}
+
+// validate returns an error if the manifest or any of its environment overrides are invalid.
+func (s *WorkerService) validate() error {
+	if err := s.Image.validate(); err != nil {
+		return err
+	}
+	for env, override := range s.Environments {
+		if err := override.Image.validate(); err != nil {
+			return fmt.Errorf("environment %s: %w", env, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package manifest
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkerSvc_MarshalBinary(t *testing.T) {
+	// GIVEN
+	wantedBytes, err := ioutil.ReadFile(filepath.Join("testdata", "worker-svc.yml"))
+	require.NoError(t, err)
+	manifest := NewWorkerService(WorkerServiceProps{
+		ServiceProps: ServiceProps{
+			Name:       "processor",
+			Dockerfile: "./processor/Dockerfile",
+		},
+	})
+
+	// WHEN
+	tpl, err := manifest.MarshalBinary()
+	require.NoError(t, err)
+
+	// THEN
+	require.Equal(t, string(wantedBytes), string(tpl))
+}
+
+func TestWorkerSvc_ApplyEnv(t *testing.T) {
+	testCases := map[string]struct {
+		svc       *WorkerService
+		inEnvName string
+
+		wanted *WorkerService
+	}{
+		"environment doesn't exist": {
+			svc: &WorkerService{
+				Service: Service{
+					Name: "processor",
+					Type: WorkerServiceType,
+				},
+				Image: ServiceImage{
+					Build: BuildArgsOrString{BuildString: stringp("./Dockerfile")},
+				},
+				Queue: SQSQueue{
+					DeadLetter: DeadLetterQueue{Tries: intp(3)},
+				},
+			},
+			inEnvName: "test",
+
+			wanted: &WorkerService{
+				Service: Service{
+					Name: "processor",
+					Type: WorkerServiceType,
+				},
+				Image: ServiceImage{
+					Build: BuildArgsOrString{BuildString: stringp("./Dockerfile")},
+				},
+				Queue: SQSQueue{
+					DeadLetter: DeadLetterQueue{Tries: intp(3)},
+				},
+			},
+		},
+		"uses env overrides": {
+			svc: &WorkerService{
+				Service: Service{
+					Name: "processor",
+					Type: WorkerServiceType,
+				},
+				Image: ServiceImage{
+					Build: BuildArgsOrString{BuildString: stringp("./Dockerfile")},
+				},
+				TaskConfig: TaskConfig{
+					CPU:    256,
+					Memory: 512,
+					Count:  Count{Value: intp(1)},
+				},
+				Queue: SQSQueue{
+					Timeout:    durationp(30 * time.Second),
+					DeadLetter: DeadLetterQueue{Tries: intp(3)},
+				},
+				Environments: map[string]workerServiceOverrideConfig{
+					"prod": {
+						TaskConfig: TaskConfig{
+							Count: Count{
+								Autoscaling: Autoscaling{
+									Range:      Range("1-10"),
+									QueueDepth: intp(100),
+								},
+							},
+						},
+						Queue: SQSQueue{
+							Retention:  durationp(24 * time.Hour),
+							DeadLetter: DeadLetterQueue{Tries: intp(5)},
+						},
+					},
+				},
+			},
+			inEnvName: "prod",
+
+			wanted: &WorkerService{
+				Service: Service{
+					Name: "processor",
+					Type: WorkerServiceType,
+				},
+				Image: ServiceImage{
+					Build: BuildArgsOrString{BuildString: stringp("./Dockerfile")},
+				},
+				TaskConfig: TaskConfig{
+					CPU:    256,
+					Memory: 512,
+					Count: Count{
+						Autoscaling: Autoscaling{
+							Range:      Range("1-10"),
+							QueueDepth: intp(100),
+						},
+					},
+				},
+				Queue: SQSQueue{
+					Retention:  durationp(24 * time.Hour),
+					Timeout:    durationp(30 * time.Second),
+					DeadLetter: DeadLetterQueue{Tries: intp(5)},
+				},
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// WHEN
+			got := tc.svc.ApplyEnv(tc.inEnvName)
+
+			// THEN
+			require.Equal(t, tc.wanted, got)
+		})
+	}
+}
@@ -18,6 +18,18 @@ import (
 const (
 	lbWebSvcTplName   = "lb-web"
 	backendSvcTplName = "backend"
+	workerSvcTplName  = "worker"
+	staticSiteTplName = "static-site"
+)
+
+// Names of job templates.
+const (
+	scheduledJobTplName = "scheduled"
+)
+
+// Replaces the dashes of names used in CloudFormation logical IDs, which must be alphanumeric.
+const (
+	dashReplacement = "DASH"
 )
 
 // ServiceNestedStackOpts holds configuration that's needed if the service stack has a nested stack.
@@ -29,16 +41,249 @@ type ServiceNestedStackOpts struct {
 	PolicyOutputs   []string
 }
 
+// AutoscalingOpts holds configuration that's needed for Auto Scaling.
+type AutoscalingOpts struct {
+	MinCapacity *int
+	MaxCapacity *int
+	CPU         *float64
+	Memory      *float64
+	Requests    *float64
+	QueueDepth  *float64
+}
+
+// QueueOpts holds configuration needed for the queue that a worker service consumes messages from.
+type QueueOpts struct {
+	Retention       *int // In seconds.
+	Delay           *int // In seconds.
+	Timeout         *int // In seconds.
+	DeadLetterTries int
+}
+
+// StateMachineOpts holds configuration needed for the state machine that runs a job to completion.
+type StateMachineOpts struct {
+	Timeout *int // In seconds.
+	Retries *int
+}
+
+// SidecarOpts holds configuration that's needed if the service has sidecar containers.
+type SidecarOpts struct {
+	Name        *string
+	Image       *string
+	Essential   *bool
+	Port        *string
+	Protocol    *string
+	CredsParam  *string
+	Variables   map[string]string
+	EnvFileARN  *string // ARN of the S3 object of the environment file, may contain CloudFormation pseudo parameters.
+	Secrets     map[string]string
+	DependsOn   map[string]string // Container name to the condition, for example "START".
+	Command     []*string
+	EntryPoint  []*string
+	HealthCheck *ecs.HealthCheck
+	MountPoints []*MountPoint
+}
+
+// LogConfigOpts holds configuration that's needed if the service routes the logs of its main container with FireLens.
+type LogConfigOpts struct {
+	Image          *string
+	Destination    map[string]string // Options of the "awsfirelens" log driver, each value is a YAML scalar.
+	EnableMetadata *string
+	ConfigFile     *string
+
+	// Permissions that the task role needs to send the logs to the destination.
+	FirehoseStream *string
+	LogGroup       *string
+	Elasticsearch  bool
+}
+
+// StorageOpts holds data structures for rendering volumes and mount points in the task definition.
+type StorageOpts struct {
+	Volumes     []*Volume
+	MountPoints []*MountPoint // Mount points of the main container.
+	EFSPerms    []*EFSPermission
+	ManagedEFS  bool // Whether the service creates its own EFS file system.
+}
+
+// Volume represents a task volume backed by an EFS file system.
+type Volume struct {
+	Name string
+	EFS  *EFSVolumeConfiguration
+}
+
+// EFSVolumeConfiguration holds the options to mount an EFS file system as a task volume.
+type EFSVolumeConfiguration struct {
+	Filesystem    *string // Nil for the file system created by the service stack.
+	RootDirectory *string
+	IAM           *string // Either "ENABLED" or "DISABLED".
+	AccessPointID *string
+}
+
+// MountPoint represents a volume mounted into a container.
+type MountPoint struct {
+	ContainerPath string
+	ReadOnly      bool
+	SourceVolume  string
+}
+
+// EFSPermission holds the permissions that the task role needs to access an EFS file system.
+type EFSPermission struct {
+	Filesystem    *string // Nil for the file system created by the service stack.
+	AccessPointID *string
+	Write         bool
+}
+
+// SecretsPolicyOpts holds the resources that the execution role needs to access to inject the secrets of the containers.
+// The ARNs can contain CloudFormation pseudo parameters such as "${AWS::Region}".
+type SecretsPolicyOpts struct {
+	SSMParameters []string
+	Secrets       []string // ARNs of Secrets Manager secrets.
+	KMSKeys       []string // ARNs of the customer managed keys that encrypt the secrets.
+}
+
+// EnvFilesPolicyOpts holds the S3 objects of the environment files that the execution role reads.
+type EnvFilesPolicyOpts struct {
+	Objects []string // ARNs of the environment files.
+	Buckets []string // ARNs of the buckets that contain them.
+}
+
+// CapacityProviderStrategy holds the share of the service's tasks placed on a capacity provider.
+type CapacityProviderStrategy struct {
+	CapacityProvider string
+	Base             *int
+	Weight           *int
+}
+
+// Values of the "AssignPublicIp" property of the tasks' network configuration.
+const (
+	EnablePublicIP  = "ENABLED"
+	DisablePublicIP = "DISABLED"
+)
+
+// Subnets exported by the environment stack that the tasks can be placed in.
+const (
+	PublicSubnetsPlacement  = "PublicSubnets"
+	PrivateSubnetsPlacement = "PrivateSubnets"
+)
+
+// NetworkOpts holds the configuration of the network interfaces of the service's tasks.
+type NetworkOpts struct {
+	AssignPublicIP  string
+	SubnetsType     string
+	SecurityGroups  []string // IDs of existing security groups attached to the tasks.
+	AllowedServices []string // If set, the tasks leave the environment security group and only accept traffic from these services.
+}
+
+// DeploymentConfigurationOpts holds the configuration of the rolling deployments of the service.
+type DeploymentConfigurationOpts struct {
+	MinHealthyPercent *int // Defaults to 100 if nil.
+	MaxPercent        *int // Defaults to 200 if nil.
+	CircuitBreaker    *CircuitBreakerOpts
+}
+
+// CircuitBreakerOpts holds the configuration of the ECS deployment circuit breaker.
+type CircuitBreakerOpts struct {
+	Enable   bool
+	Rollback bool
+}
+
+// Traffic routing types of the CodeDeploy deployment configurations of blue/green deployments.
+const (
+	CanaryTrafficRouting    = "TimeBasedCanary"
+	LinearTrafficRouting    = "TimeBasedLinear"
+	AllAtOnceTrafficRouting = "AllAtOnce"
+)
+
+// BlueGreenDeploymentOpts holds the configuration of the CodeDeploy blue/green deployments of a load balanced web service.
+type BlueGreenDeploymentOpts struct {
+	TrafficRouting  string // One of TimeBasedCanary, TimeBasedLinear or AllAtOnce.
+	Percentage      int    // Traffic shifted at each step of a canary or linear deployment.
+	IntervalMinutes int    // Time between the steps of a canary or linear deployment.
+	Alarms          []string
+	TestPort        int // Port of the test listener, 0 to listen on the next port available on the load balancer.
+}
+
+// HTTPHealthCheckOpts holds the configuration of the target group's health check, durations are in seconds.
+type HTTPHealthCheckOpts struct {
+	HealthyThreshold   *int
+	UnhealthyThreshold *int
+	Interval           *int
+	Timeout            *int
+	SuccessCodes       *string
+}
+
+// NetworkLoadBalancerOpts holds the configuration of the network load balancer dedicated to a load balanced web service.
+type NetworkLoadBalancerOpts struct {
+	Listeners    []*NetworkLoadBalancerListenerOpts
+	PortMappings []*PortMappingOpts // Port mappings of the main container in addition to the HTTP container port.
+	Ingress      []*NLBIngressOpts  // Ports of the tasks that accept the traffic of the load balancer.
+	Alias        *string
+}
+
+// NetworkLoadBalancerListenerOpts holds the configuration of a listener of the network load balancer and of its target group.
+type NetworkLoadBalancerListenerOpts struct {
+	Port            int
+	Protocol        string  // One of TCP, UDP, TCP_UDP or TLS.
+	TargetContainer *string // Nil for the main container.
+	TargetPort      int
+	TargetProtocol  string  // TLS listeners forward TCP traffic to their targets.
+	Certificate     *string // ARN of the certificate of a TLS listener.
+	HealthCheck     NLBHealthCheckOpts
+}
+
+// NLBHealthCheckOpts holds the configuration of the TCP health check of a network load balancer's target group, durations are in seconds.
+type NLBHealthCheckOpts struct {
+	Port               *int
+	HealthyThreshold   *int
+	UnhealthyThreshold *int
+	Interval           *int
+}
+
+// NLBIngressOpts holds a port of the tasks that accepts the traffic of the network load balancer, and its protocol, either "tcp" or "udp".
+type NLBIngressOpts struct {
+	Port         int
+	Protocol     string
+	FromAnywhere bool // UDP and TCP_UDP target groups preserve the addresses of the clients, instead of the load balancer's addresses in the VPC.
+}
+
+// PortMappingOpts holds a port of a container and its protocol, either "tcp" or "udp".
+type PortMappingOpts struct {
+	Port     int
+	Protocol string
+}
+
 // ServiceOpts holds optional data that can be provided to enable features in a service stack template.
 type ServiceOpts struct {
 	// Additional options that're common between **all** service templates.
-	Variables   map[string]string
-	Secrets     map[string]string
-	NestedStack *ServiceNestedStackOpts // Outputs from nested stacks such as the addons stack.
+	Variables               map[string]string
+	EnvFileARN              *string                 // ARN of the S3 object of the main container's environment file.
+	EnvFilesPolicy          *EnvFilesPolicyOpts     // Nil if no container has an environment file.
+	Secrets                 map[string]string       // Names of the environment variables to the "ValueFrom" of the secrets.
+	SecretsPolicy           *SecretsPolicyOpts      // Nil if the containers don't reference secrets outside of the addons.
+	NestedStack             *ServiceNestedStackOpts // Outputs from nested stacks such as the addons stack.
+	Autoscaling             *AutoscalingOpts
+	Sidecars                []*SidecarOpts
+	Storage                 *StorageOpts
+	LogConfig               *LogConfigOpts
+	CapacityProviders       []*CapacityProviderStrategy // Replaces the FARGATE launch type if set.
+	Network                 *NetworkOpts                // Defaults to the public subnets if nil.
+	DeploymentConfiguration DeploymentConfigurationOpts
+	EntryPoint              []*string // Overrides the ENTRYPOINT of the main container's image if set.
+	Command                 []*string // Overrides the CMD of the main container's image if set.
 
 	// Additional options that're not shared across all service templates.
-	HealthCheck        *ecs.HealthCheck
-	RulePriorityLambda string
+	HealthCheck         *ecs.HealthCheck
+	RulePriorityLambda  string
+	Alias               *string
+	ACMValidationLambda string
+	AliasRecordLambda   string
+	HTTPHealthCheck     HTTPHealthCheckOpts
+	DeregistrationDelay *int // In seconds.
+	Stickiness          bool
+	ProtocolVersion     string                   // One of HTTP1, HTTP2 or GRPC.
+	BlueGreen           *BlueGreenDeploymentOpts // Replaces the rolling deployments of the service with CodeDeploy if set.
+	NLB                 *NetworkLoadBalancerOpts
+	StateMachine        *StateMachineOpts
+	Queue               *QueueOpts
 }
 
 // ParseLoadBalancedWebService parses a load balanced web service's CloudFormation template
@@ -52,9 +297,35 @@ func (t *Template) ParseBackendService(data ServiceOpts) (*Content, error) {
 	return t.parseSvc(backendSvcTplName, data, withSvcParsingFuncs())
 }
 
+// ParseWorkerService parses a worker service's CloudFormation template with the specified data object and returns its content.
+func (t *Template) ParseWorkerService(data ServiceOpts) (*Content, error) {
+	return t.parseSvc(workerSvcTplName, data, withSvcParsingFuncs())
+}
+
+// ParseStaticSite parses a static site's CloudFormation template with the specified data object and returns its content.
+func (t *Template) ParseStaticSite(data ServiceOpts) (*Content, error) {
+	return t.parseSvc(staticSiteTplName, data, withSvcParsingFuncs())
+}
+
+// ParseScheduledJob parses a scheduled job's CloudFormation template with the specified data object and returns its content.
+func (t *Template) ParseScheduledJob(data ServiceOpts) (*Content, error) {
+	return t.parseJob(scheduledJobTplName, data, withSvcParsingFuncs())
+}
+
 // parseSvc parses a service's CloudFormation template with the specified data object and returns its content.
 func (t *Template) parseSvc(name string, data interface{}, options ...ParseOption) (*Content, error) {
-	tpl, err := t.parse("base", fmt.Sprintf(fmtSvcCFTemplatePath, name), options...)
+	return t.parseWorkload(name, fmt.Sprintf(fmtSvcCFTemplatePath, name), data, options...)
+}
+
+// parseJob parses a job's CloudFormation template with the specified data object and returns its content.
+func (t *Template) parseJob(name string, data interface{}, options ...ParseOption) (*Content, error) {
+	return t.parseWorkload(name, fmt.Sprintf(fmtJobCFTemplatePath, name), data, options...)
+}
+
+// parseWorkload parses the CloudFormation template at path along with the common service templates
+// with the specified data object and returns its content.
+func (t *Template) parseWorkload(name, path string, data interface{}, options ...ParseOption) (*Content, error) {
+	tpl, err := t.parse("base", path, options...)
 	if err != nil {
 		return nil, err
 	}
@@ -78,17 +349,24 @@ func (t *Template) parseSvc(name string, data interface{}, options ...ParseOptio
 func withSvcParsingFuncs() ParseOption {
 	return func(t *template.Template) *template.Template {
 		return t.Funcs(map[string]interface{}{
-			"toSnakeCase":    toSnakeCase,
-			"hasSecrets":     hasSecrets,
-			"stringifySlice": stringifySlice,
-			"quoteAll":       quoteAll,
+			"toSnakeCase":              ToSnakeCase,
+			"hasSecrets":               hasSecrets,
+			"hasManagedEFS":            hasManagedEFS,
+			"hasSecretsPolicy":         hasSecretsPolicy,
+			"hasSecretsManagerSecrets": hasSecretsManagerSecrets,
+			"hasAddonsSecrets":         hasAddonsSecrets,
+			"stringifySlice":           stringifySlice,
+			"quoteAll":                 quoteAll,
+			"logicalIDSafe":            logicalIDSafe,
+			"toLower":                  strings.ToLower,
 		})
 	}
 }
 
-// toSnakeCase transforms a CamelCase input string s into an upper SNAKE_CASE string and returns it.
+// ToSnakeCase transforms a CamelCase input string s into an upper SNAKE_CASE string and returns it.
 // For example, "usersDdbTableName" becomes "USERS_DDB_TABLE_NAME".
-func toSnakeCase(s string) string {
+// The templates name the environment variables set to the outputs of the addons stack with it.
+func ToSnakeCase(s string) string {
 	var name string
 	for i, r := range s {
 		if unicode.IsUpper(r) && i != 0 {
@@ -99,6 +377,12 @@ func toSnakeCase(s string) string {
 	return name
 }
 
+// logicalIDSafe replaces the dashes in s so that it can be part of a CloudFormation logical ID.
+// For example, "api-gateway" becomes "apiDASHgateway".
+func logicalIDSafe(s string) string {
+	return strings.ReplaceAll(s, "-", dashReplacement)
+}
+
 func hasSecrets(opts ServiceOpts) bool {
 	if opts.Secrets != nil {
 		return true
@@ -109,6 +393,26 @@ func hasSecrets(opts ServiceOpts) bool {
 	return false
 }
 
+// hasSecretsPolicy returns true if the execution role needs permissions to inject secrets into the containers.
+func hasSecretsPolicy(opts ServiceOpts) bool {
+	return opts.SecretsPolicy != nil || hasAddonsSecrets(opts)
+}
+
+// hasSecretsManagerSecrets returns true if the containers reference secrets stored in Secrets Manager,
+// which is where the secrets of the addons are stored.
+func hasSecretsManagerSecrets(opts ServiceOpts) bool {
+	return (opts.SecretsPolicy != nil && len(opts.SecretsPolicy.Secrets) > 0) || hasAddonsSecrets(opts)
+}
+
+// hasAddonsSecrets returns true if the containers reference the secrets created by the addons.
+func hasAddonsSecrets(opts ServiceOpts) bool {
+	return opts.NestedStack != nil && len(opts.NestedStack.SecretOutputs) > 0
+}
+
+func hasManagedEFS(opts ServiceOpts) bool {
+	return opts.Storage != nil && opts.Storage.ManagedEFS
+}
+
 func stringifySlice(elems []string) string {
 	return fmt.Sprintf("[%s]", strings.Join(elems, ", "))
 }
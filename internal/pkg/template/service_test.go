@@ -5,11 +5,14 @@ package template
 
 import (
 	"fmt"
+	"io/ioutil"
+	"path/filepath"
 	"testing"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/gobuffalo/packd"
 	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
 )
 
 func TestTemplate_ParseSvc(t *testing.T) {
@@ -37,6 +40,15 @@ func TestTemplate_ParseSvc(t *testing.T) {
 				mockBox.AddString("services/common/cf/service-base-properties.yml", "service-base-properties")
 				mockBox.AddString("services/common/cf/servicediscovery.yml", "servicediscovery")
 				mockBox.AddString("services/common/cf/addons.yml", "addons")
+				mockBox.AddString("services/common/cf/autoscaling.yml", "autoscaling")
+				mockBox.AddString("services/common/cf/mount-points.yml", "mount-points")
+				mockBox.AddString("services/common/cf/sidecars.yml", "sidecars")
+				mockBox.AddString("services/common/cf/efs.yml", "efs")
+				mockBox.AddString("services/common/cf/logconfig.yml", "logconfig")
+				mockBox.AddString("services/common/cf/firelens.yml", "firelens")
+				mockBox.AddString("services/common/cf/security-group.yml", "security-group")
+				mockBox.AddString("services/common/cf/target-group-properties.yml", "target-group-properties")
+				mockBox.AddString("services/common/cf/nlb.yml", "nlb")
 
 				t.box = mockBox
 			},
@@ -48,6 +60,15 @@ func TestTemplate_ParseSvc(t *testing.T) {
   service-base-properties
   servicediscovery
   addons
+  autoscaling
+  mount-points
+  sidecars
+  efs
+  logconfig
+  firelens
+  security-group
+  target-group-properties
+  nlb
 `,
 		},
 	}
@@ -91,11 +112,16 @@ func TestToSnakeCase(t *testing.T) {
 
 	for name, tc := range testCases {
 		t.Run(name, func(t *testing.T) {
-			require.Equal(t, tc.wanted, toSnakeCase(tc.in))
+			require.Equal(t, tc.wanted, ToSnakeCase(tc.in))
 		})
 	}
 }
 
+func TestLogicalIDSafe(t *testing.T) {
+	require.Equal(t, "frontend", logicalIDSafe("frontend"))
+	require.Equal(t, "apiDASHgateway", logicalIDSafe("api-gateway"))
+}
+
 func TestHasSecrets(t *testing.T) {
 	testCases := map[string]struct {
 		in     ServiceOpts
@@ -130,6 +156,38 @@ func TestHasSecrets(t *testing.T) {
 	}
 }
 
+func TestHasManagedEFS(t *testing.T) {
+	testCases := map[string]struct {
+		in     ServiceOpts
+		wanted bool
+	}{
+		"no storage": {
+			in:     ServiceOpts{},
+			wanted: false,
+		},
+		"only existing file systems": {
+			in: ServiceOpts{
+				Storage: &StorageOpts{},
+			},
+			wanted: false,
+		},
+		"managed file system": {
+			in: ServiceOpts{
+				Storage: &StorageOpts{
+					ManagedEFS: true,
+				},
+			},
+			wanted: true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.wanted, hasManagedEFS(tc.in))
+		})
+	}
+}
+
 func TestStringifySlice(t *testing.T) {
 	require.Equal(t, "[]", stringifySlice(nil))
 	require.Equal(t, "[a]", stringifySlice([]string{"a"}))
@@ -141,3 +199,91 @@ func TestQuoteAll(t *testing.T) {
 	require.Equal(t, []string{`"a"`}, quoteAll(aws.StringSlice([]string{"a"})))
 	require.Equal(t, []string{`"a"`, `"b"`, `"c"`}, quoteAll(aws.StringSlice([]string{"a", "b", "c"})))
 }
+
+func TestExecutionRole_SecretsPolicy(t *testing.T) {
+	testCases := map[string]struct {
+		in ServiceOpts
+
+		wantedStatements []map[string]interface{}
+	}{
+		"grants access to the secrets of the containers": {
+			in: ServiceOpts{
+				SecretsPolicy: &SecretsPolicyOpts{
+					Secrets: []string{"arn:aws:secretsmanager:us-west-2:123456789012:secret:db-??????"},
+					KMSKeys: []string{"arn:aws:kms:us-west-2:123456789012:key/1234abcd"},
+				},
+			},
+			wantedStatements: []map[string]interface{}{
+				{
+					"Effect":   "Allow",
+					"Action":   []interface{}{"secretsmanager:GetSecretValue"},
+					"Resource": []interface{}{"arn:aws:secretsmanager:us-west-2:123456789012:secret:db-??????"},
+				},
+				{
+					"Effect":   "Allow",
+					"Action":   []interface{}{"kms:Decrypt"},
+					"Resource": []interface{}{"arn:aws:kms:us-west-2:123456789012:key/1234abcd"},
+				},
+			},
+		},
+		"decrypts the secrets of the addons through Secrets Manager": {
+			in: ServiceOpts{
+				NestedStack: &ServiceNestedStackOpts{
+					StackName:     "AddonsStack",
+					SecretOutputs: []string{"MySecretArn"},
+				},
+			},
+			wantedStatements: []map[string]interface{}{
+				{
+					"Effect": "Allow",
+					"Action": []interface{}{"secretsmanager:GetSecretValue"},
+					"Resource": []interface{}{
+						map[string]interface{}{
+							"Fn::GetAtt": []interface{}{"AddonsStack", "Outputs.MySecretArn"},
+						},
+					},
+				},
+				{
+					"Effect":   "Allow",
+					"Action":   []interface{}{"kms:Decrypt"},
+					"Resource": "arn:${AWS::Partition}:kms:${AWS::Region}:${AWS::AccountId}:key/*",
+					"Condition": map[string]interface{}{
+						"StringEquals": map[string]interface{}{
+							"kms:ViaService": "secretsmanager.${AWS::Region}.amazonaws.com",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			role, err := ioutil.ReadFile(filepath.Join("..", "..", "..", "templates", "services", "common", "cf", "executionrole.yml"))
+			require.NoError(t, err)
+			box := packd.NewMemoryBox()
+			box.AddBytes("services/common/cf/executionrole.yml", role)
+			tpl := &Template{box: box}
+
+			// WHEN
+			content, err := tpl.Parse("services/common/cf/executionrole.yml", tc.in, withSvcParsingFuncs())
+
+			// THEN
+			require.NoError(t, err)
+			var parsed struct {
+				ExecutionRole struct {
+					Properties struct {
+						Policies []struct {
+							PolicyDocument struct {
+								Statement []map[string]interface{} `yaml:"Statement"`
+							} `yaml:"PolicyDocument"`
+						} `yaml:"Policies"`
+					} `yaml:"Properties"`
+				} `yaml:"ExecutionRole"`
+			}
+			require.NoError(t, yaml.Unmarshal(content.Bytes(), &parsed))
+			require.Equal(t, tc.wantedStatements, parsed.ExecutionRole.Properties.Policies[0].PolicyDocument.Statement)
+		})
+	}
+}
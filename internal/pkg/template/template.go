@@ -20,6 +20,11 @@ const (
 	fmtSvcCommonCFTemplatePath = "services/common/cf/%s.yml"
 )
 
+// Paths of job cloudformation templates under templates/jobs/.
+const (
+	fmtJobCFTemplatePath = "jobs/%s/cf.yml"
+)
+
 var (
 	// Template names under "services/common/cf/".
 	commonServiceCFTemplateNames = []string{
@@ -31,6 +36,15 @@ var (
 		"service-base-properties",
 		"servicediscovery",
 		"addons",
+		"autoscaling",
+		"mount-points",
+		"sidecars",
+		"efs",
+		"logconfig",
+		"firelens",
+		"security-group",
+		"target-group-properties",
+		"nlb",
 	}
 )
 
@@ -131,6 +131,25 @@ func (p Prompt) SelectOne(message, help string, options []string) (string, error
 	return result, err
 }
 
+// MultiSelect prompts the user with a list of options to choose one or more from with the arrow and space keys.
+func (p Prompt) MultiSelect(message, help string, options []string) ([]string, error) {
+	if len(options) <= 0 {
+		return nil, ErrEmptyOptions
+	}
+
+	prompt := &survey.MultiSelect{
+		Message: message,
+		Help:    help,
+		Options: options,
+	}
+
+	var result []string
+
+	err := p(prompt, &result, stdio(), validators(nil), icons())
+
+	return result, err
+}
+
 type ConfirmOption func(*survey.Confirm)
 
 // Confirm prompts the user with a yes/no option.
@@ -28,12 +28,13 @@ import (
 const (
 	// CopilotDirName is the name of the directory where generated infrastructure code for an application will be stored.
 	CopilotDirName = "copilot"
+	// ManifestFileName is the name of the manifest file of a service under its directory in copilot/.
+	ManifestFileName = "manifest.yml"
 
 	addonsDirName             = "addons"
 	workspaceSummaryFileName  = ".workspace"
 	maximumParentDirsToSearch = 5
 	pipelineFileName          = "pipeline.yml"
-	manifestFileName          = "manifest.yml"
 	buildspecFileName         = "buildspec.yml"
 )
 
@@ -127,7 +128,7 @@ func (ws *Workspace) ServiceNames() ([]string, error) {
 		if !f.IsDir() {
 			continue
 		}
-		if exists, _ := ws.fsUtils.Exists(filepath.Join(copilotPath, f.Name(), manifestFileName)); !exists {
+		if exists, _ := ws.fsUtils.Exists(filepath.Join(copilotPath, f.Name(), ManifestFileName)); !exists {
 			// Swallow the error because we don't want to include any services that we don't have permissions to read.
 			continue
 		}
@@ -138,7 +139,7 @@ func (ws *Workspace) ServiceNames() ([]string, error) {
 
 // ReadServiceManifest returns the contents of the service manifest under copilot/{name}/manifest.yml.
 func (ws *Workspace) ReadServiceManifest(name string) ([]byte, error) {
-	return ws.read(name, manifestFileName)
+	return ws.read(name, ManifestFileName)
 }
 
 // ReadPipelineManifest returns the contents of the pipeline manifest under copilot/pipeline.yml.
@@ -164,7 +165,13 @@ func (ws *Workspace) WriteServiceManifest(marshaler encoding.BinaryMarshaler, na
 	if err != nil {
 		return "", fmt.Errorf("marshal service %s manifest to binary: %w", name, err)
 	}
-	return ws.write(data, name, manifestFileName)
+	return ws.write(data, name, ManifestFileName)
+}
+
+// OverwriteServiceManifest replaces the contents of the service's existing manifest under copilot/{name}/manifest.yml.
+// If successful returns the full path of the file, otherwise returns an empty string and the error.
+func (ws *Workspace) OverwriteServiceManifest(data []byte, name string) (string, error) {
+	return ws.overwrite(data, name, ManifestFileName)
 }
 
 // WritePipelineBuildspec writes the pipeline buildspec under the copilot/ directory.
@@ -234,6 +241,45 @@ func (ws *Workspace) ReadAddonsFile(svcName, fileName string) ([]byte, error) {
 	return ws.read(svcName, addonsDirName, fileName)
 }
 
+// ReadFile returns the contents of a file at a path relative to the root of the workspace,
+// the directory that contains the copilot directory.
+func (ws *Workspace) ReadFile(path string) ([]byte, error) {
+	copilotPath, err := ws.copilotDirPath()
+	if err != nil {
+		return nil, err
+	}
+	return ws.fsUtils.ReadFile(filepath.Join(filepath.Dir(copilotPath), path))
+}
+
+// ListFiles returns the paths of the files under a directory relative to the root of the workspace.
+// The paths are relative to the directory and use forward slashes, such as "css/main.css".
+func (ws *Workspace) ListFiles(dir string) ([]string, error) {
+	copilotPath, err := ws.copilotDirPath()
+	if err != nil {
+		return nil, err
+	}
+	root := filepath.Join(filepath.Dir(copilotPath), dir)
+	var files []string
+	err = ws.fsUtils.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		files = append(files, filepath.ToSlash(rel))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
 func (ws *Workspace) writeSummary(appName string) error {
 	summaryPath, err := ws.summaryPath()
 	if err != nil {
@@ -335,6 +381,28 @@ func (ws *Workspace) write(data []byte, elem ...string) (string, error) {
 	return filename, nil
 }
 
+// overwrite replaces the contents of an existing file under the copilot directory joined by path elements.
+func (ws *Workspace) overwrite(data []byte, elem ...string) (string, error) {
+	copilotPath, err := ws.copilotDirPath()
+	if err != nil {
+		return "", err
+	}
+	pathElems := append([]string{copilotPath}, elem...)
+	filename := filepath.Join(pathElems...)
+
+	exist, err := ws.fsUtils.Exists(filename)
+	if err != nil {
+		return "", fmt.Errorf("check if manifest file %s exists: %w", filename, err)
+	}
+	if !exist {
+		return "", fmt.Errorf("manifest file %s does not exist", filename)
+	}
+	if err := ws.fsUtils.WriteFile(filename, data, 0644 /* -rw-r--r-- */); err != nil {
+		return "", fmt.Errorf("write manifest file: %w", err)
+	}
+	return filename, nil
+}
+
 // read returns the contents of the file under the copilot directory joined by path elements.
 func (ws *Workspace) read(elem ...string) ([]byte, error) {
 	copilotPath, err := ws.copilotDirPath()
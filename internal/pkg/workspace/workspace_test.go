@@ -395,6 +395,54 @@ func TestWorkspace_write(t *testing.T) {
 	}
 }
 
+func TestWorkspace_overwrite(t *testing.T) {
+	testCases := map[string]struct {
+		elems []string
+
+		wantedPath string
+		wantedErr  error
+	}{
+		"replace the contents of an existing file": {
+			elems:      []string{"frontend", "manifest.yml"},
+			wantedPath: "/copilot/frontend/manifest.yml",
+		},
+		"return an error if the file doesn't exist": {
+			elems:     []string{"backend", "manifest.yml"},
+			wantedErr: errors.New("manifest file /copilot/backend/manifest.yml does not exist"),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// GIVEN
+			fs := afero.NewMemMapFs()
+			utils := &afero.Afero{
+				Fs: fs,
+			}
+			utils.MkdirAll("/copilot/frontend", 0755)
+			utils.WriteFile("/copilot/frontend/manifest.yml", []byte("name: frontend"), 0644)
+			ws := &Workspace{
+				workingDir: "/",
+				copilotDir: "/copilot",
+				fsUtils:    utils,
+			}
+
+			// WHEN
+			actualPath, actualErr := ws.overwrite([]byte("version: 1"), tc.elems...)
+
+			// THEN
+			if tc.wantedErr != nil {
+				require.EqualError(t, actualErr, tc.wantedErr.Error(), "expected the same error")
+				return
+			}
+			require.Equal(t, tc.wantedPath, actualPath, "expected the same path")
+			out, err := utils.ReadFile(tc.wantedPath)
+			require.NoError(t, err)
+			require.Equal(t, "version: 1", string(out))
+		})
+	}
+}
+
 func TestWorkspace_DeleteService(t *testing.T) {
 	testCases := map[string]struct {
 		name string
@@ -579,6 +627,47 @@ func TestWorkspace_ReadAddonsDir(t *testing.T) {
 	}
 }
 
+func TestWorkspace_ReadFile(t *testing.T) {
+	// GIVEN
+	fs := afero.NewMemMapFs()
+	fs.MkdirAll("/workspace/copilot/api", 0755)
+	afero.WriteFile(fs, "/workspace/api/.env", []byte("LOG_LEVEL=info"), 0644)
+	ws := &Workspace{
+		copilotDir: "/workspace/copilot",
+		fsUtils: &afero.Afero{
+			Fs: fs,
+		},
+	}
+
+	// WHEN
+	content, err := ws.ReadFile("api/.env")
+
+	// THEN
+	require.NoError(t, err)
+	require.Equal(t, "LOG_LEVEL=info", string(content))
+}
+
+func TestWorkspace_ListFiles(t *testing.T) {
+	// GIVEN
+	fs := afero.NewMemMapFs()
+	fs.MkdirAll("/workspace/copilot/website", 0755)
+	afero.WriteFile(fs, "/workspace/website/dist/index.html", []byte("<html></html>"), 0644)
+	afero.WriteFile(fs, "/workspace/website/dist/css/main.css", []byte("body {}"), 0644)
+	ws := &Workspace{
+		copilotDir: "/workspace/copilot",
+		fsUtils: &afero.Afero{
+			Fs: fs,
+		},
+	}
+
+	// WHEN
+	files, err := ws.ListFiles("website/dist")
+
+	// THEN
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"index.html", "css/main.css"}, files)
+}
+
 func TestWorkspace_ReadPipelineManifest(t *testing.T) {
 	copilotDir := "/copilot"
 	testCases := map[string]struct {